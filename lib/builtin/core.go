@@ -27,6 +27,7 @@ var coreBuiltins = BuiltinMap{
 	"print":   print,
 	"println": println,
 	"typeof":  typeof,
+	"hash":    hash,
 }
 
 func ImportCoreBuiltins(stack *runtime.GDSymbolStack) error {
@@ -69,6 +70,27 @@ func typeof(stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	return typeOfFunc, nil
 }
 
+// Hash of the object, equal objects have the same hash
+func hash(stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
+	objParam := runtime.NewStrRefType("obj")
+	funcType := runtime.NewGDLambdaType(
+		runtime.GDLambdaArgTypes{
+			{Key: objParam, Value: runtime.GDAnyType},
+		},
+		runtime.GDIntType,
+		false,
+	)
+	hashFunc := runtime.NewGDLambdaWithType(
+		funcType,
+		stack,
+		func(_ *runtime.GDSymbolStack, args runtime.GDLambdaArgs) (runtime.GDObject, error) {
+			return runtime.Hash(args.Get(objParam))
+		},
+	)
+
+	return hashFunc, nil
+}
+
 // Print functions
 
 func print(stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
@@ -28,8 +28,9 @@ var Packages = map[string]*runtime.GDPackage[*runtime.GDSymbol]{}
 
 func init() {
 	packages := map[string]func() (*runtime.GDPackage[*runtime.GDSymbol], error){
-		"http": HttpPackage,
-		"math": MathPackage,
+		"http":    HttpPackage,
+		"math":    MathPackage,
+		"strings": StringsPackage,
 	}
 
 	for name, pkg := range packages {
@@ -0,0 +1,113 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package builtin
+
+import (
+	"gdlang/lib/runtime"
+)
+
+func StringsPackage() (*runtime.GDPackage[*runtime.GDSymbol], error) {
+	pkg := runtime.NewGDPackage[*runtime.GDSymbol](runtime.NewGDStringIdent("strings"), "strings", runtime.PackageModeBuiltin)
+	symbols := map[string]*runtime.GDSymbol{
+		"len":           strLen(),
+		"bytes":         strBytes(),
+		"graphemes":     strGraphemes(),
+		"graphemeCount": strGraphemeCount(),
+	}
+
+	for ident, symbol := range symbols {
+		err := pkg.AddPublic(runtime.NewGDStringIdent(ident), symbol)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pkg, nil
+}
+
+// Number of runes in the string
+func strLen() *runtime.GDSymbol {
+	return strOp(runtime.GDIntType, func(str runtime.GDString) (runtime.GDObject, error) {
+		return runtime.NewGDIntNumber(runtime.GDInt(str.Length())), nil
+	})
+}
+
+// UTF-8 encoded bytes of the string
+func strBytes() *runtime.GDSymbol {
+	typ := runtime.NewGDArrayType(runtime.GDIntType)
+
+	return strOp(typ, func(str runtime.GDString) (runtime.GDObject, error) {
+		bytes := str.Bytes()
+		objs := make([]runtime.GDObject, len(bytes))
+		for i, b := range bytes {
+			objs[i] = runtime.NewGDIntNumber(runtime.GDInt(b))
+		}
+
+		return runtime.NewGDArrayWithTypeAndObjects(typ, objs), nil
+	})
+}
+
+// User-perceived characters of the string
+func strGraphemes() *runtime.GDSymbol {
+	typ := runtime.NewGDArrayType(runtime.GDStringType)
+
+	return strOp(typ, func(str runtime.GDString) (runtime.GDObject, error) {
+		graphemes := str.Graphemes()
+		objs := make([]runtime.GDObject, len(graphemes))
+		for i, g := range graphemes {
+			objs[i] = runtime.GDString(g)
+		}
+
+		return runtime.NewGDArrayWithTypeAndObjects(typ, objs), nil
+	})
+}
+
+func strGraphemeCount() *runtime.GDSymbol {
+	return strOp(runtime.GDIntType, func(str runtime.GDString) (runtime.GDObject, error) {
+		return runtime.NewGDIntNumber(runtime.GDInt(len(str.Graphemes()))), nil
+	})
+}
+
+func strOp(returnType runtime.GDTypable, opFunc func(str runtime.GDString) (runtime.GDObject, error)) *runtime.GDSymbol {
+	str := runtime.NewStrRefType("str")
+
+	typ := runtime.NewGDLambdaType(
+		runtime.GDLambdaArgTypes{
+			{Key: str, Value: runtime.GDStringType},
+		},
+		returnType,
+		false,
+	)
+
+	lambda := runtime.NewGDLambdaWithType(
+		typ,
+		nil,
+		func(_ *runtime.GDSymbolStack, args runtime.GDLambdaArgs) (runtime.GDObject, error) {
+			str, err := runtime.ToString(args.Get(str))
+			if err != nil {
+				return nil, err
+			}
+
+			return opFunc(str)
+		},
+	)
+
+	return runtime.NewGDSymbol(true, true, typ, lambda)
+}
@@ -53,6 +53,13 @@ func (gd *GDArray) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, er
 		gd.GDArrayType = typ
 
 		return gd, nil
+	case *GDSetType:
+		objects, err := castObjects(gd.Objects, typ.SubType, typ, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGDSetWithTypeAndObjects(typ, objects)
 	}
 
 	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
@@ -79,7 +79,7 @@ func TestArrayGet(t *testing.T) {
 		t.Error("Error getting value")
 	}
 
-	if object.(runtime.GDInt8) != 1 {
+	if object.(runtime.GDInt) != 1 {
 		t.Errorf("Wrong value got %q but expected 1", object.ToString())
 	}
 }
@@ -97,7 +97,7 @@ func TestArraySet(t *testing.T) {
 		t.Error("Error getting value")
 	}
 
-	if value.(runtime.GDInt8) != 2 {
+	if value.(runtime.GDInt) != 2 {
 		t.Errorf("Wrong value got %q but expected 2", value.ToString())
 	}
 }
@@ -119,7 +119,7 @@ func TestArrayAppend(t *testing.T) {
 		t.Error("Error getting value")
 	}
 
-	if value.(runtime.GDInt8) != 2 {
+	if value.(runtime.GDInt) != 2 {
 		t.Errorf("Wrong value got %q but expected 2", value.ToString())
 	}
 }
@@ -141,7 +141,7 @@ func TestArrayRemove(t *testing.T) {
 		t.Errorf("Error when getting value after removing, expected no error: %v", err)
 	}
 
-	if value.(runtime.GDInt8) != 3 {
+	if value.(runtime.GDInt) != 3 {
 		t.Errorf("Wrong value got %q but expected 3", value.ToString())
 	}
 }
@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+import (
+	"math/big"
+	"strings"
+)
+
+// Arbitrary-precision integer, the value is never
+// mutated, so it can be shared between objects
+type GDBigInt struct {
+	Value *big.Int
+}
+
+func (gd *GDBigInt) GetType() GDTypable    { return GDBigIntType }
+func (gd *GDBigInt) GetSubType() GDTypable { return nil }
+func (gd *GDBigInt) ToString() string      { return gd.Value.String() }
+func (gd *GDBigInt) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	switch typ := typ.(type) {
+	case GDType:
+		if IsSizedIntType(typ) {
+			value, err := bigIntToInt64(gd.Value, typ)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewGDSizedInt(typ, value), nil
+		}
+
+		switch typ {
+		case GDBigIntType:
+			return gd, nil
+		case GDDecimalType:
+			return NewGDDecimal(gd.Value, 0), nil
+		case GDIntType:
+			value, err := bigIntToInt64(gd.Value, typ)
+			return GDInt(value), err
+		case GDFloatType, GDFloat32Type, GDFloat64Type, GDComplexType, GDComplex64Type, GDComplex128Type:
+			f, _ := new(big.Float).SetInt(gd.Value).Float64()
+			return GDFloat64(f).CastToType(typ, stack)
+		case GDStringType:
+			return GDString(gd.ToString()), nil
+		case GDBoolType:
+			return GDBool(gd.Value.Sign() != 0), nil
+		}
+	}
+
+	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
+}
+
+func NewGDBigInt(value *big.Int) *GDBigInt {
+	return &GDBigInt{value}
+}
+
+// The literal can have the `n` suffix, e.g. `123n`
+func NewGDBigIntFromString(number string) (*GDBigInt, error) {
+	value, ok := new(big.Int).SetString(strings.TrimSuffix(number, "n"), 0)
+	if !ok {
+		return nil, InvalidCastingLitErr(number, GDBigIntType)
+	}
+
+	return NewGDBigInt(value), nil
+}
+
+// Unlike the casts between sized integers, the conversion
+// fails if the value doesn't fit in the integer type
+func bigIntToInt64(value *big.Int, typ GDTypable) (int64, error) {
+	if typ == GDUInt64Type {
+		if !value.IsUint64() {
+			return 0, IntOverflowErr(typ)
+		}
+
+		return int64(value.Uint64()), nil
+	}
+
+	minValue, maxValue := intBounds(typ)
+	if !value.IsInt64() || value.Int64() < minValue || value.Int64() > maxValue {
+		return 0, IntOverflowErr(typ)
+	}
+
+	return value.Int64(), nil
+}
@@ -34,6 +34,14 @@ func (gd GDBool) ToString() string {
 func (gd GDBool) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			if gd {
+				return NewGDSizedInt(typ, 1), nil
+			}
+
+			return NewGDSizedInt(typ, 0), nil
+		}
+
 		switch typ {
 		case GDBoolType:
 			return gd, nil
@@ -43,14 +51,6 @@ func (gd GDBool) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, erro
 			}
 
 			return GDInt(0), nil
-		case GDInt8Type:
-			if gd {
-				return GDInt8(1), nil
-			}
-		case GDInt16Type:
-			if gd {
-				return GDInt16(1), nil
-			}
 		case GDFloat32Type:
 			if gd {
 				return GDFloat32(1), nil
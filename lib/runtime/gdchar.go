@@ -19,6 +19,8 @@
 
 package runtime
 
+import "unicode/utf8"
+
 type GDChar rune
 
 func (gd GDChar) GetType() GDTypable    { return GDCharType }
@@ -27,6 +29,10 @@ func (gd GDChar) ToString() string      { return string(gd) }
 func (gd GDChar) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDSizedInt(typ, int64(gd)), nil
+		}
+
 		switch typ {
 		case GDCharType:
 			return gd, nil
@@ -36,10 +42,6 @@ func (gd GDChar) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, erro
 			return GDString(gd.ToString()), nil
 		case GDIntType:
 			return GDInt(int(gd)), nil
-		case GDInt8Type:
-			return GDInt8(int8(gd)), nil
-		case GDInt16Type:
-			return GDInt16(int16(gd)), nil
 		}
 	}
 
@@ -47,9 +49,10 @@ func (gd GDChar) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, erro
 }
 
 func GDCharFromString(value string) (GDChar, error) {
-	if len(value) != 1 {
+	c, size := utf8.DecodeRuneInString(value)
+	if len(value) == 0 || size != len(value) || c == utf8.RuneError {
 		return GDChar(0), InvalidCastingLitErr(value, GDCharType)
 	}
 
-	return GDChar(rune(value[0])), nil
+	return GDChar(c), nil
 }
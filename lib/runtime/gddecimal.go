@@ -0,0 +1,180 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Extra digits kept by a division, over the scale of the operands
+const GDDecimalDivPrecision = 16
+
+// Fixed-point decimal, the value is Unscaled / 10^Scale.
+// The scale is kept, so `1.10d` is printed as `1.10`
+type GDDecimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+func (gd *GDDecimal) GetType() GDTypable    { return GDDecimalType }
+func (gd *GDDecimal) GetSubType() GDTypable { return nil }
+func (gd *GDDecimal) ToString() string {
+	digits := new(big.Int).Abs(gd.Unscaled).String()
+	if gd.Scale > 0 {
+		if len(digits) <= gd.Scale {
+			digits = strings.Repeat("0", gd.Scale-len(digits)+1) + digits
+		}
+
+		digits = digits[:len(digits)-gd.Scale] + "." + digits[len(digits)-gd.Scale:]
+	}
+
+	if gd.Unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+func (gd *GDDecimal) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	switch typ := typ.(type) {
+	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDBigInt(gd.Truncate()).CastToType(typ, stack)
+		}
+
+		switch typ {
+		case GDDecimalType:
+			return gd, nil
+		case GDIntType, GDBigIntType:
+			return NewGDBigInt(gd.Truncate()).CastToType(typ, stack)
+		case GDFloatType, GDFloat32Type, GDFloat64Type, GDComplexType, GDComplex64Type, GDComplex128Type:
+			return GDFloat64(gd.Float64()).CastToType(typ, stack)
+		case GDStringType:
+			return GDString(gd.ToString()), nil
+		case GDBoolType:
+			return GDBool(gd.Unscaled.Sign() != 0), nil
+		}
+	}
+
+	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
+}
+
+// Truncates the fractional part
+func (gd *GDDecimal) Truncate() *big.Int {
+	return new(big.Int).Quo(gd.Unscaled, pow10(gd.Scale))
+}
+
+func (gd *GDDecimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(gd.Unscaled, pow10(gd.Scale)).Float64()
+	return f
+}
+
+// Rescales the decimal to a greater scale
+func (gd *GDDecimal) rescale(scale int) *big.Int {
+	if scale <= gd.Scale {
+		return gd.Unscaled
+	}
+
+	return new(big.Int).Mul(gd.Unscaled, pow10(scale-gd.Scale))
+}
+
+// A negative scale is applied to the unscaled value
+func NewGDDecimal(unscaled *big.Int, scale int) *GDDecimal {
+	if scale < 0 {
+		return &GDDecimal{new(big.Int).Mul(unscaled, pow10(-scale)), 0}
+	}
+
+	return &GDDecimal{unscaled, scale}
+}
+
+// The literal can have the `d` suffix and an exponent, e.g. `1.10d` or `1e3d`
+func NewGDDecimalFromString(number string) (*GDDecimal, error) {
+	mantissa, exp := strings.ReplaceAll(strings.TrimSuffix(number, "d"), "_", ""), 0
+	if i := strings.IndexAny(mantissa, "eE"); i >= 0 {
+		e, err := strconv.Atoi(mantissa[i+1:])
+		if err != nil {
+			return nil, InvalidCastingLitErr(number, GDDecimalType)
+		}
+
+		mantissa, exp = mantissa[:i], e
+	}
+
+	scale := 0
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		scale = len(mantissa) - i - 1
+		mantissa = mantissa[:i] + mantissa[i+1:]
+	}
+
+	unscaled, ok := new(big.Int).SetString(mantissa, 10)
+	if !ok {
+		return nil, InvalidCastingLitErr(number, GDDecimalType)
+	}
+
+	return NewGDDecimal(unscaled, scale-exp), nil
+}
+
+// The shortest decimal that converts back into the same float of the given bit size
+func NewGDDecimalFromFloat(value float64, bitSize int) (*GDDecimal, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, InvalidCastingLitErr(strconv.FormatFloat(value, 'g', -1, 64), GDDecimalType)
+	}
+
+	return NewGDDecimalFromString(strconv.FormatFloat(value, 'f', -1, bitSize))
+}
+
+// Rescales both decimals to the greatest scale of the two
+func alignDecimals(a, b *GDDecimal) (*big.Int, *big.Int, int) {
+	scale := max(a.Scale, b.Scale)
+	return a.rescale(scale), b.rescale(scale), scale
+}
+
+// The quotient is rounded half away from zero, and the trailing zeros
+// over the scale of the operands are removed, e.g. `1.0d / 4d` is `0.25`
+func divideDecimals(a, b *GDDecimal) (*GDDecimal, error) {
+	if b.Unscaled.Sign() == 0 {
+		return nil, DivByZeroErr
+	}
+
+	minScale := max(a.Scale, b.Scale)
+	scale := minScale + GDDecimalDivPrecision
+	num := new(big.Int).Mul(a.Unscaled, pow10(scale+b.Scale-a.Scale))
+	quo, rem := new(big.Int).QuoRem(num, b.Unscaled, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(new(big.Int).Abs(b.Unscaled)) >= 0 {
+		quo.Add(quo, big.NewInt(int64(num.Sign()*b.Unscaled.Sign())))
+	}
+
+	ten, digit := big.NewInt(10), new(big.Int)
+	for scale > minScale {
+		q, r := new(big.Int).QuoRem(quo, ten, digit)
+		if r.Sign() != 0 {
+			break
+		}
+
+		quo, scale = q, scale-1
+	}
+
+	return NewGDDecimal(quo, scale), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
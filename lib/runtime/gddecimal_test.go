@@ -0,0 +1,117 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime_test
+
+import (
+	"gdlang/lib/runtime"
+	"testing"
+)
+
+func TestDecimalFromString(t *testing.T) {
+	for _, test := range []struct {
+		value    string
+		expected string
+		errMsg   string
+	}{
+		{"1.10d", "1.10", ""},
+		{"-0.05", "-0.05", ""},
+		{"1_000.5d", "1000.5", ""},
+		{"1e3d", "1000", ""},
+		{"12.5e-3", "0.0125", ""},
+		{".5", "0.5", ""},
+		{"0x10", "", "error trying to cast `0x10` into a `decimal`"},
+		{"1.2.3", "", "error trying to cast `1.2.3` into a `decimal`"},
+	} {
+		result, err := runtime.NewGDDecimalFromString(test.value)
+		if err != nil {
+			if err.Error() != test.errMsg {
+				t.Errorf("Expected error %q, but got %q", test.errMsg, err.Error())
+			}
+			continue
+		}
+
+		if result.ToString() != test.expected {
+			t.Errorf("Expected %s, but got %s", test.expected, result.ToString())
+		}
+	}
+}
+
+func TestDecimalOperations(t *testing.T) {
+	for _, test := range []struct {
+		op       runtime.ExprOperationType
+		a, b     string
+		expected string
+	}{
+		{runtime.ExprOperationAdd, "0.1", "0.2", "0.3"},
+		{runtime.ExprOperationSubtract, "1.10", "2", "-0.90"},
+		{runtime.ExprOperationMultiply, "1.5", "1.5", "2.25"},
+		{runtime.ExprOperationQuo, "1", "3", "0.3333333333333333"},
+		{runtime.ExprOperationQuo, "-2", "3", "-0.6666666666666667"},
+		{runtime.ExprOperationQuo, "1.00", "8", "0.125"},
+		{runtime.ExprOperationQuo, "9", "3", "3"},
+		{runtime.ExprOperationRem, "-5.5", "2", "-1.5"},
+		{runtime.ExprOperationLess, "0.99", "1", "true"},
+		{runtime.ExprOperationEqual, "2.50", "2.5", "true"},
+	} {
+		a, _ := runtime.NewGDDecimalFromString(test.a)
+		b, _ := runtime.NewGDDecimalFromString(test.b)
+		result, err := runtime.PerformExprOperation(test.op, a, b)
+		if err != nil {
+			t.Errorf("Error while performing operation: %v", err)
+			continue
+		}
+
+		if result.ToString() != test.expected {
+			t.Errorf("Expected %s %s %s = %s, but got %s", test.a, runtime.ExprOperationMap[test.op], test.b, test.expected, result.ToString())
+		}
+	}
+}
+
+func TestBigIntCasts(t *testing.T) {
+	big, err := runtime.NewGDBigIntFromString("340282366920938463463374607431768211457n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		typ      runtime.GDTypable
+		expected string
+	}{
+		{runtime.GDDecimalType, "340282366920938463463374607431768211457"},
+		{runtime.GDFloatType, "3.402823669209385e+38"},
+		{runtime.GDBoolType, "true"},
+	} {
+		result, err := big.CastToType(test.typ, nil)
+		if err != nil {
+			t.Errorf("Error while casting: %v", err)
+			continue
+		}
+
+		if result.ToString() != test.expected {
+			t.Errorf("Expected %s, but got %s", test.expected, result.ToString())
+		}
+	}
+
+	for _, typ := range []runtime.GDTypable{runtime.GDIntType, runtime.GDUInt8Type, runtime.GDUInt64Type} {
+		if _, err := big.CastToType(typ, nil); err == nil {
+			t.Errorf("Expected an overflow error while casting to %s", typ.ToString())
+		}
+	}
+}
@@ -0,0 +1,310 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Arrays, tuples, sets and structs are compared structurally
+func IsComposite(value GDObject) bool {
+	switch value.(type) {
+	case *GDArray, *GDTuple, *GDSet, *GDStruct:
+		return true
+	}
+
+	return false
+}
+
+// Deep structural equality, values of different kinds are not equal.
+// Numbers are compared by value, so `[1, 2] == [1.0, 2.0]` is true
+func Equal(a, b GDObject) bool {
+	a, b = Unwrap(a), Unwrap(b)
+	switch a := a.(type) {
+	case *GDArray:
+		b, isArray := b.(*GDArray)
+		return isArray && equalObjects(a.Objects, b.Objects)
+	case *GDTuple:
+		b, isTuple := b.(*GDTuple)
+		return isTuple && equalObjects(a.Objects, b.Objects)
+	case *GDSet:
+		b, isSet := b.(*GDSet)
+		return isSet && equalSets(a, b)
+	case *GDStruct:
+		b, isStruct := b.(*GDStruct)
+		return isStruct && equalStructs(a, b)
+	case *GDNominalObject:
+		b, isNominal := b.(*GDNominalObject)
+		return isNominal && a.Type.IsSameType(b.Type) && Equal(a.Object, b.Object)
+	case *GDLambda:
+		return a == b
+	}
+
+	kind := primitiveKind(a)
+	if kind != primitiveKind(b) {
+		return false
+	} else if kind == hashNil {
+		return true
+	}
+
+	eq, err := PerformExprOperation(ExprOperationEqual, a, b)
+	return err == nil && eq == GDBool(true)
+}
+
+func equalObjects(a, b []GDObject) bool {
+	return slices.EqualFunc(a, b, Equal)
+}
+
+// Sets are equal when they have the same elements in any order
+func equalSets(a, b *GDSet) bool {
+	if a.Length() != b.Length() {
+		return false
+	}
+
+	for _, object := range a.Objects {
+		contains, err := b.Contains(object)
+		if err != nil || !contains {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalStructs(a, b *GDStruct) bool {
+	if len(a.Type) != len(b.Type) {
+		return false
+	}
+
+	for _, attr := range a.Type {
+		symbolA, err := a.GetAttr(attr.Ident)
+		if err != nil {
+			return false
+		}
+
+		symbolB, err := b.GetAttr(attr.Ident)
+		if err != nil || !Equal(symbolA.Object, symbolB.Object) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Three-way comparison, arrays and tuples are ordered
+// lexicographically and shorter sequences go first
+func Compare(a, b GDObject) (int, error) {
+	a, b = Unwrap(a), Unwrap(b)
+	switch a := a.(type) {
+	case *GDArray:
+		if b, isArray := b.(*GDArray); isArray {
+			return compareObjects(a.Objects, b.Objects)
+		}
+	case *GDTuple:
+		if b, isTuple := b.(*GDTuple); isTuple {
+			return compareObjects(a.Objects, b.Objects)
+		}
+	default:
+		if IsComposite(a) || IsComposite(b) {
+			break
+		}
+
+		less, err := PerformExprOperation(ExprOperationLess, a, b)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case less == GDBool(true):
+			return -1, nil
+		case Equal(a, b):
+			return 0, nil
+		case less == GDBool(false):
+			return 1, nil
+		}
+	}
+
+	return 0, UnsupportedOperationBetweenTypesError(ExprOperationMap[ExprOperationLess], a.GetType().ToString(), b.GetType().ToString())
+}
+
+func compareObjects(a, b []GDObject) (int, error) {
+	for i := range min(len(a), len(b)) {
+		cmp, err := Compare(a[i], b[i])
+		if err != nil || cmp != 0 {
+			return cmp, err
+		}
+	}
+
+	return len(a) - len(b), nil
+}
+
+// Hash of the value, equal values have the same hash.
+// Numbers are hashed by value, and chars as single rune strings
+func Hash(obj GDObject) (GDInt, error) {
+	h := fnv.New64a()
+	err := writeHash(h.Write, obj)
+	if err != nil {
+		return 0, err
+	}
+
+	return GDInt(h.Sum64()), nil
+}
+
+const (
+	hashNil byte = iota
+	hashBool
+	hashNumber
+	hashString
+	hashArray
+	hashTuple
+	hashStruct
+	hashSet
+)
+
+// Kind of the primitive value, the operations between
+// different kinds would cast one value to the other
+func primitiveKind(obj GDObject) byte {
+	switch obj.(type) {
+	case GDNil:
+		return hashNil
+	case GDBool:
+		return hashBool
+	case GDString, GDChar:
+		return hashString
+	}
+
+	if IsNumber(obj) {
+		return hashNumber
+	}
+
+	return math.MaxUint8
+}
+
+func writeHash(write func([]byte) (int, error), obj GDObject) error {
+	writeTag := func(tag byte, size int) {
+		_, _ = write(binary.AppendUvarint([]byte{tag}, uint64(size)))
+	}
+
+	writeFloat := func(f float64) {
+		// Both zeros are equal
+		if f == 0 {
+			f = 0
+		}
+
+		_, _ = write(binary.LittleEndian.AppendUint64(nil, math.Float64bits(f)))
+	}
+
+	writeObjects := func(tag byte, objects []GDObject) error {
+		writeTag(tag, len(objects))
+		for _, item := range objects {
+			err := writeHash(write, item)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	switch obj := Unwrap(obj).(type) {
+	case GDNil:
+		writeTag(hashNil, 0)
+	case GDBool:
+		writeTag(hashBool, 0)
+		if obj {
+			_, _ = write([]byte{1})
+		} else {
+			_, _ = write([]byte{0})
+		}
+	case GDString:
+		writeTag(hashString, len(obj))
+		_, _ = write([]byte(obj))
+	case GDChar:
+		str := obj.ToString()
+		writeTag(hashString, len(str))
+		_, _ = write([]byte(str))
+	case *GDArray:
+		return writeObjects(hashArray, obj.Objects)
+	case *GDTuple:
+		return writeObjects(hashTuple, obj.Objects)
+	case *GDSet:
+		// The hashes of the elements are added up, since the order of the elements doesn't matter
+		var sum uint64
+		for _, item := range obj.Objects {
+			hash, err := Hash(item)
+			if err != nil {
+				return err
+			}
+
+			sum += uint64(hash)
+		}
+
+		writeTag(hashSet, len(obj.Objects))
+		_, _ = write(binary.LittleEndian.AppendUint64(nil, sum))
+	case *GDStruct:
+		// The attributes are hashed by name, since the order of the attributes doesn't matter
+		attrs := slices.Clone(obj.Type)
+		slices.SortFunc(attrs, func(a, b GDStructAttrType) int {
+			return strings.Compare(a.Ident.ToString(), b.Ident.ToString())
+		})
+
+		writeTag(hashStruct, len(attrs))
+		for _, attr := range attrs {
+			symbol, err := obj.GetAttr(attr.Ident)
+			if err != nil {
+				return err
+			}
+
+			err = writeHash(write, GDString(attr.Ident.ToString()))
+			if err != nil {
+				return err
+			}
+
+			err = writeHash(write, symbol.Object)
+			if err != nil {
+				return err
+			}
+		}
+	case *GDNominalObject:
+		return writeHash(write, obj.Object)
+	default:
+		if !IsNumber(obj) {
+			return UnhashableTypeErr(obj.GetType())
+		}
+
+		// Equal numbers of different types are hashed through the same float
+		c, err := ToComplex(obj)
+		if err != nil {
+			return err
+		}
+
+		writeTag(hashNumber, 0)
+		writeFloat(real(c))
+		if imag(c) != 0 {
+			writeFloat(imag(c))
+		}
+	}
+
+	return nil
+}
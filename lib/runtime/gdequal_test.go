@@ -0,0 +1,109 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime_test
+
+import (
+	"gdlang/lib/runtime"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	intArray := func(values ...runtime.GDObject) *runtime.GDArray {
+		return runtime.NewGDArrayWithTypeAndObjects(runtime.NewGDArrayType(runtime.GDAnyType), values)
+	}
+	tuple := func(values ...runtime.GDObject) *runtime.GDTuple {
+		return runtime.NewGDTuple(values...)
+	}
+
+	for i, test := range []struct {
+		a, b  runtime.GDObject
+		equal bool
+	}{
+		{intArray(runtime.GDInt(1), runtime.GDInt(2)), intArray(runtime.GDInt(1), runtime.GDInt(2)), true},
+		{intArray(runtime.GDInt(1), runtime.GDInt(2)), intArray(runtime.GDInt(1)), false},
+		{intArray(runtime.GDInt(1)), intArray(runtime.GDFloat64(1)), true},
+		{intArray(runtime.GDInt(1)), intArray(runtime.GDString("1")), false},
+		{intArray(runtime.GDInt(1)), intArray(runtime.GDBool(true)), false},
+		{intArray(intArray(runtime.GDZNil)), intArray(intArray(runtime.GDZNil)), true},
+		{intArray(), tuple(), false},
+		{tuple(runtime.GDInt(1), runtime.GDString("a")), tuple(runtime.GDInt(1), runtime.GDString("a")), true},
+		{tuple(runtime.GDInt(1), runtime.GDString("a")), tuple(runtime.GDInt(1), runtime.GDString("b")), false},
+		{runtime.GDInt(1), intArray(runtime.GDInt(1)), false},
+	} {
+		if runtime.Equal(test.a, test.b) != test.equal {
+			t.Errorf("Equal(%s, %s) = %v, want %v for test case %d", test.a.ToString(), test.b.ToString(), !test.equal, test.equal, i+1)
+		}
+
+		if !test.equal {
+			continue
+		}
+
+		hashA, err := runtime.Hash(test.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hashB, err := runtime.Hash(test.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hashA != hashB {
+			t.Errorf("Hash(%s) != Hash(%s) for test case %d", test.a.ToString(), test.b.ToString(), i+1)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	array := func(values ...runtime.GDObject) *runtime.GDArray {
+		return runtime.NewGDArrayWithTypeAndObjects(runtime.NewGDArrayType(runtime.GDAnyType), values)
+	}
+
+	for i, test := range []struct {
+		a, b   runtime.GDObject
+		cmp    int
+		errMsg string
+	}{
+		{array(runtime.GDInt(1), runtime.GDInt(2)), array(runtime.GDInt(1), runtime.GDInt(3)), -1, ""},
+		{array(runtime.GDInt(1), runtime.GDInt(2)), array(runtime.GDInt(1)), 1, ""},
+		{array(runtime.GDString("b")), array(runtime.GDString("a"), runtime.GDString("z")), 1, ""},
+		{array(runtime.GDFloat64(1.5)), array(runtime.GDFloat64(1.5)), 0, ""},
+		{array(runtime.GDBool(true)), array(runtime.GDBool(false)), 0, "unsupported operation `<` between `bool` and `bool`"},
+	} {
+		cmp, err := runtime.Compare(test.a, test.b)
+		if err != nil {
+			if err.Error() != test.errMsg {
+				t.Errorf("Expected error %q, but got %q for test case %d", test.errMsg, err.Error(), i+1)
+			}
+			continue
+		}
+
+		if min(max(cmp, -1), 1) != test.cmp {
+			t.Errorf("Compare(%s, %s) = %d, want %d for test case %d", test.a.ToString(), test.b.ToString(), cmp, test.cmp, i+1)
+		}
+	}
+}
+
+func TestHashUnhashable(t *testing.T) {
+	_, err := runtime.Hash(runtime.NewGDLambdaWithType(runtime.NewGDLambdaType(nil, runtime.GDNilType, false), nil, nil))
+	if err == nil || err.Error() != "unhashable type: `() => nil`" {
+		t.Errorf("Expected an unhashable type error, but got %v", err)
+	}
+}
@@ -21,6 +21,9 @@ package runtime
 
 import (
 	"math"
+	"math/big"
+	"math/bits"
+	"slices"
 )
 
 type ExprOperationType byte
@@ -42,6 +45,15 @@ const (
 	ExprOperationAnd                                   // &&
 	ExprOperationOr                                    // ||
 	ExprOperationNot                                   // !
+	ExprOperationAddWrap                               // +%
+	ExprOperationSubtractWrap                          // -%
+	ExprOperationMultiplyWrap                          // *%
+	ExprOperationAddSat                                // +|
+	ExprOperationSubtractSat                           // -|
+	ExprOperationMultiplySat                           // *|
+	ExprOperationUnion                                 // |
+	ExprOperationIntersection                          // &
+	ExprOperationIn                                    // in
 )
 
 var ExprOperationMap = map[ExprOperationType]string{
@@ -61,13 +73,91 @@ var ExprOperationMap = map[ExprOperationType]string{
 	ExprOperationAnd:          "&&",
 	ExprOperationOr:           "||",
 	ExprOperationNot:          "!",
+	ExprOperationAddWrap:      "+%",
+	ExprOperationSubtractWrap: "-%",
+	ExprOperationMultiplyWrap: "*%",
+	ExprOperationAddSat:       "+|",
+	ExprOperationSubtractSat:  "-|",
+	ExprOperationMultiplySat:  "*|",
+	ExprOperationUnion:        "|",
+	ExprOperationIntersection: "&",
+	ExprOperationIn:           "in",
 }
 
 func IsUnaryOperation(op ExprOperationType) bool {
 	return op == ExprOperationUnaryPlus || op == ExprOperationUnaryMinus || op == ExprOperationNot
 }
 
+// Operations that can be overloaded by operator functions for struct types
+func IsOverloadableOperation(op ExprOperationType) bool {
+	switch op {
+	case ExprOperationAnd, ExprOperationOr, ExprOperationIn:
+		return false
+	}
+
+	return !IsOverflowOperation(op)
+}
+
+// The wrapping and saturating operations are only defined for integers
+func IsOverflowOperation(op ExprOperationType) bool {
+	switch op {
+	case ExprOperationAddWrap, ExprOperationSubtractWrap, ExprOperationMultiplyWrap:
+		return true
+	case ExprOperationAddSat, ExprOperationSubtractSat, ExprOperationMultiplySat:
+		return true
+	}
+
+	return false
+}
+
+// Identifier of the operator function that overloads the operation for the given operand types
+// e.g. `(+)({x: int}, {x: int})`
+func OperatorIdent(op ExprOperationType, types ...GDTypable) GDIdent {
+	return NewGDStringIdent(OperatorIdentPrefix(op) + JoinSlice(types, func(typ GDTypable, _ int) string {
+		return typ.ToString()
+	}, ", ") + ")")
+}
+
+// Prefix shared by the identifiers of the operator functions that overload the operation
+func OperatorIdentPrefix(op ExprOperationType) string {
+	return "(" + ExprOperationMap[op] + ")("
+}
+
+func IsComparisonOperation(op ExprOperationType) bool {
+	switch op {
+	case ExprOperationGreater, ExprOperationGreaterEqual, ExprOperationLess, ExprOperationLessEqual, ExprOperationEqual, ExprOperationNotEqual:
+		return true
+	}
+
+	return false
+}
+
+// Operations with nominal objects are performed on the underlying objects,
+// and it is only allowed between objects of the same nominal type.
+// It returns false if none of the operands is a nominal object.
+func unwrapNominalOperands(op ExprOperationType, a, b GDObject) (*GDNominalType, GDObject, GDObject, bool, error) {
+	nA, isNominalA := a.(*GDNominalObject)
+	nB, isNominalB := b.(*GDNominalObject)
+	if !isNominalA && !isNominalB {
+		return nil, nil, nil, false, nil
+	}
+
+	if IsUnaryOperation(op) && isNominalA {
+		return nA.Type, nA.Object, b, true, nil
+	}
+
+	if !isNominalA || !isNominalB || !nA.Type.IsSameType(nB.Type) {
+		return nil, nil, nil, true, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
+	}
+
+	return nA.Type, nA.Object, nB.Object, true, nil
+}
+
 func TypeCheckExprOperation(op ExprOperationType, a, b GDObject) (GDTypable, error) {
+	if op == ExprOperationIn {
+		return typeCheckMembership(a, b)
+	}
+
 	isUnaryOp := IsUnaryOperation(op)
 	if a == GDZNil {
 		return GDNilType, nil
@@ -75,6 +165,27 @@ func TypeCheckExprOperation(op ExprOperationType, a, b GDObject) (GDTypable, err
 		return GDNilType, nil
 	}
 
+	if nominalType, uA, uB, isNominal, err := unwrapNominalOperands(op, a, b); isNominal {
+		if err != nil {
+			return nil, err
+		}
+
+		typ, err := TypeCheckExprOperation(op, uA, uB)
+		if err != nil {
+			return nil, err
+		}
+
+		if IsComparisonOperation(op) {
+			return typ, nil
+		}
+
+		return nominalType, nil
+	}
+
+	if isCompositeOperation(op, a, b) {
+		return typeCheckCompositeOperation(op, a, b)
+	}
+
 	switch {
 	case IsString(a) || IsString(b):
 		switch op {
@@ -85,6 +196,8 @@ func TypeCheckExprOperation(op ExprOperationType, a, b GDObject) (GDTypable, err
 		default:
 			return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
 		}
+	case isDecimalFloatOperation(a, b):
+		return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
 	case IsComplex(a) || IsComplex(b):
 		if isUnaryOp {
 			return GDComplexType, nil
@@ -111,14 +224,23 @@ func TypeCheckExprOperation(op ExprOperationType, a, b GDObject) (GDTypable, err
 		}
 
 		return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
+	case IsDecimal(a) || IsDecimal(b):
+		return bigNumberOperationType(op, GDDecimalType, a, b)
+	case IsBigInt(a) || IsBigInt(b):
+		return bigNumberOperationType(op, GDBigIntType, a, b)
 	case IsInt(a) || IsInt(b):
-		if isUnaryOp {
-			return GDIntType, nil
+		typ, err := intOperationType(op, a, b)
+		if err != nil {
+			return nil, err
+		}
+
+		if isUnaryOp || IsOverflowOperation(op) {
+			return typ, nil
 		}
 
 		switch op {
 		case ExprOperationAdd, ExprOperationSubtract, ExprOperationMultiply, ExprOperationQuo, ExprOperationRem:
-			return GDIntType, nil
+			return typ, nil
 		case ExprOperationGreater, ExprOperationGreaterEqual, ExprOperationLess, ExprOperationLessEqual, ExprOperationEqual, ExprOperationNotEqual:
 			return GDBoolType, nil
 		}
@@ -146,6 +268,10 @@ func TypeCheckExprOperation(op ExprOperationType, a, b GDObject) (GDTypable, err
 }
 
 func PerformExprOperation(op ExprOperationType, a, b GDObject) (GDObject, error) {
+	if op == ExprOperationIn {
+		return performMembership(a, b)
+	}
+
 	isUnaryOp := IsUnaryOperation(op)
 	if a == GDZNil {
 		return GDZNil, nil
@@ -153,6 +279,27 @@ func PerformExprOperation(op ExprOperationType, a, b GDObject) (GDObject, error)
 		return GDZNil, nil
 	}
 
+	if nominalType, uA, uB, isNominal, err := unwrapNominalOperands(op, a, b); isNominal {
+		if err != nil {
+			return nil, err
+		}
+
+		obj, err := PerformExprOperation(op, uA, uB)
+		if err != nil {
+			return nil, err
+		}
+
+		if IsComparisonOperation(op) {
+			return obj, nil
+		}
+
+		return NewGDNominalObject(nominalType, obj), nil
+	}
+
+	if isCompositeOperation(op, a, b) {
+		return performCompositeOperation(op, a, b)
+	}
+
 	switch {
 	case IsString(a) || IsString(b):
 		sA, err := ToString(a)
@@ -166,6 +313,8 @@ func PerformExprOperation(op ExprOperationType, a, b GDObject) (GDObject, error)
 		}
 
 		return performStringOp(op, sA, sB)
+	case isDecimalFloatOperation(a, b):
+		return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
 	case IsComplex(a) || IsComplex(b):
 		nA, err := ToComplex(a)
 		if err != nil {
@@ -198,22 +347,69 @@ func PerformExprOperation(op ExprOperationType, a, b GDObject) (GDObject, error)
 		}
 
 		return performFloatOp(op, nA, nB)
-	case IsInt(a) || IsInt(b):
-		nA, err := ToInt(a)
+	case IsDecimal(a) || IsDecimal(b):
+		dA, err := ToDecimal(a)
 		if err != nil {
 			return nil, err
 		}
 
 		if isUnaryOp {
-			return performIntOp(op, nA, 0)
+			return performDecimalOp(op, dA, dA)
+		}
+
+		dB, err := ToDecimal(b)
+		if err != nil {
+			return nil, err
+		}
+
+		return performDecimalOp(op, dA, dB)
+	case IsBigInt(a) || IsBigInt(b):
+		nA, err := ToBigInt(a)
+		if err != nil {
+			return nil, err
+		}
+
+		if isUnaryOp {
+			return performBigIntOp(op, nA, nA)
+		}
+
+		nB, err := ToBigInt(b)
+		if err != nil {
+			return nil, err
+		}
+
+		return performBigIntOp(op, nA, nB)
+	case IsInt(a) || IsInt(b):
+		typ, err := intOperationType(op, a, b)
+		if err != nil {
+			return nil, err
 		}
 
-		nB, err := ToInt(b)
+		nA, err := ToInt(a)
 		if err != nil {
 			return nil, err
 		}
 
-		return performIntOp(op, nA, nB)
+		var nB GDInt
+		if !isUnaryOp {
+			nB, err = ToInt(b)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if typ == GDUInt64Type {
+			// A negative `int` operand doesn't fit in a `uint64`
+			isNegA := nA < 0 && a.GetType() != GDUInt64Type
+			isNegB := !isUnaryOp && nB < 0 && b.GetType() != GDUInt64Type
+			if isNegA || isNegB {
+				return performSignedUInt64Op(op, uint64ToBigInt(nA, isNegA), uint64ToBigInt(nB, isNegB))
+			}
+
+			return performUInt64Op(op, uint64(nA), uint64(nB))
+		}
+
+		return performIntOp(op, typ, nA, nB)
 	case IsChar(a) || IsChar(b):
 		cA, err := ToString(a)
 		if err != nil {
@@ -285,30 +481,149 @@ func performStringOp(op ExprOperationType, a, b GDString) (GDObject, error) {
 	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
 }
 
-func performIntOp(op ExprOperationType, a, b GDInt) (GDObject, error) {
+// The concatenation of strings with composite values is a string operation
+func isCompositeOperation(op ExprOperationType, a, b GDObject) bool {
+	if op == ExprOperationAdd && (IsString(a) || IsString(b)) {
+		return false
+	}
+
+	return IsComposite(a) || IsComposite(b)
+}
+
+// Composite values can be compared for equality with any value,
+// arrays and tuples can be ordered with values of the same kind
+// and sets can be combined with other sets
+func typeCheckCompositeOperation(op ExprOperationType, a, b GDObject) (GDTypable, error) {
+	switch op {
+	case ExprOperationUnion, ExprOperationIntersection, ExprOperationSubtract:
+		setA, isSetA := a.(*GDSet)
+		setB, isSetB := b.(*GDSet)
+		if isSetA && isSetB {
+			return setOperationType(op, setA, setB)
+		}
+	case ExprOperationEqual, ExprOperationNotEqual:
+		return GDBoolType, nil
+	case ExprOperationGreater, ExprOperationGreaterEqual, ExprOperationLess, ExprOperationLessEqual:
+		_, isArrayA := a.(*GDArray)
+		_, isArrayB := b.(*GDArray)
+		_, isTupleA := a.(*GDTuple)
+		_, isTupleB := b.(*GDTuple)
+		if isArrayA && isArrayB || isTupleA && isTupleB {
+			return GDBoolType, nil
+		}
+	}
+
+	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
+}
+
+func performCompositeOperation(op ExprOperationType, a, b GDObject) (GDObject, error) {
+	switch op {
+	case ExprOperationUnion, ExprOperationIntersection, ExprOperationSubtract:
+		setA, isSetA := a.(*GDSet)
+		setB, isSetB := b.(*GDSet)
+		if isSetA && isSetB {
+			return performSetOp(op, setA, setB)
+		}
+	case ExprOperationEqual:
+		return GDBool(Equal(a, b)), nil
+	case ExprOperationNotEqual:
+		return GDBool(!Equal(a, b)), nil
+	case ExprOperationGreater, ExprOperationGreaterEqual, ExprOperationLess, ExprOperationLessEqual:
+		cmp, err := Compare(a, b)
+		if err != nil {
+			return nil, err
+		}
+
+		return compareOrdered(op, cmp), nil
+	}
+
+	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
+}
+
+// Membership is checked with structural equality in sets and arrays
+func typeCheckMembership(a, b GDObject) (GDTypable, error) {
+	switch b.(type) {
+	case *GDSet, *GDArray:
+		return GDBoolType, nil
+	}
+
+	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[ExprOperationIn], a.GetType().ToString(), b.GetType().ToString())
+}
+
+func performMembership(a, b GDObject) (GDObject, error) {
+	switch b := b.(type) {
+	case *GDSet:
+		contains, err := b.Contains(a)
+		if err != nil {
+			return nil, err
+		}
+
+		return GDBool(contains), nil
+	case *GDArray:
+		return GDBool(slices.ContainsFunc(b.Objects, equalTo(a))), nil
+	}
+
+	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[ExprOperationIn], a.GetType().ToString(), b.GetType().ToString())
+}
+
+// Operations between sized integers are only allowed for the same type,
+// an `int` operand takes the type of the sized integer operand
+func intOperationType(op ExprOperationType, a, b GDObject) (GDTypable, error) {
+	typA := a.GetType()
+	if IsUnaryOperation(op) {
+		if IsSizedIntType(typA) {
+			return typA, nil
+		}
+
+		return GDIntType, nil
+	}
+
+	typB := b.GetType()
+	isSizedA, isSizedB := IsSizedIntType(typA), IsSizedIntType(typB)
+	switch {
+	case isSizedA && isSizedB && typA != typB:
+		return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], typA.ToString(), typB.ToString())
+	case isSizedA:
+		return typA, nil
+	case isSizedB:
+		return typB, nil
+	}
+
+	return GDIntType, nil
+}
+
+// Integer arithmetic is checked, an overflow of the integer type is a runtime error.
+// The wrapping operations, e.g. `a +% b`, wrap around on overflow and
+// the saturating operations, e.g. `a +| b`, clamp to the bounds of the type
+func performIntOp(op ExprOperationType, typ GDTypable, a, b GDInt) (GDObject, error) {
+	var r GDInt
+	var overflow bool
 	switch op {
 	case ExprOperationUnaryPlus:
-		return NewGDIntNumber(+a), nil
+		r = a
 	case ExprOperationUnaryMinus:
-		return NewGDIntNumber(-a), nil
-	case ExprOperationAdd:
-		return NewGDIntNumber(a + b), nil
-	case ExprOperationSubtract:
-		return NewGDIntNumber(a - b), nil
-	case ExprOperationMultiply:
-		return NewGDIntNumber(a * b), nil
+		r, overflow = -a, a == math.MinInt
+	case ExprOperationAdd, ExprOperationAddWrap, ExprOperationAddSat:
+		r = a + b
+		overflow = (a >= 0) == (b >= 0) && (r >= 0) != (a >= 0)
+	case ExprOperationSubtract, ExprOperationSubtractWrap, ExprOperationSubtractSat:
+		r = a - b
+		overflow = (a >= 0) != (b >= 0) && (r >= 0) != (a >= 0)
+	case ExprOperationMultiply, ExprOperationMultiplyWrap, ExprOperationMultiplySat:
+		r = a * b
+		overflow = a != 0 && (r/a != b || (a == -1 && b == math.MinInt))
 	case ExprOperationQuo:
 		if b == 0 {
 			return nil, DivByZeroErr
 		}
 
-		return NewGDIntNumber(a / b), nil
+		r, overflow = a/b, a == math.MinInt && b == -1
 	case ExprOperationRem:
 		if b == 0 {
 			return nil, DivByZeroErr
 		}
 
-		return NewGDIntNumber(a % b), nil
+		r = a % b
 	case ExprOperationGreater:
 		return GDBool(a > b), nil
 	case ExprOperationGreaterEqual:
@@ -321,11 +636,273 @@ func performIntOp(op ExprOperationType, a, b GDInt) (GDObject, error) {
 		return GDBool(a == b), nil
 	case ExprOperationNotEqual:
 		return GDBool(a != b), nil
+	default:
+		return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
+	}
+
+	// Sized integers are computed as an int, so the result
+	// overflows if it changes when wrapped into the sized type
+	obj := NewGDSizedInt(typ, int64(r))
+	if wrapped, _ := ToInt(obj); !overflow && wrapped == r {
+		return obj, nil
+	}
+
+	switch op {
+	case ExprOperationAddWrap, ExprOperationSubtractWrap, ExprOperationMultiplyWrap:
+		return obj, nil
+	case ExprOperationAddSat, ExprOperationSubtractSat, ExprOperationMultiplySat:
+		isPositive := r > 0
+		if overflow {
+			// The sign of an overflowed sum or difference is inverted,
+			// the sign of a product is known from the operands
+			isPositive = r < 0
+			if op == ExprOperationMultiplySat {
+				isPositive = (a < 0) == (b < 0)
+			}
+		}
+
+		minValue, maxValue := intBounds(typ)
+		if isPositive {
+			return NewGDSizedInt(typ, maxValue), nil
+		}
+
+		return NewGDSizedInt(typ, minValue), nil
+	}
+
+	return nil, IntOverflowErr(typ)
+}
+
+// The unsigned 64 bits integers don't fit in an `int`,
+// so the operations are performed as unsigned
+func performUInt64Op(op ExprOperationType, a, b uint64) (GDObject, error) {
+	var r, carry uint64
+	switch op {
+	case ExprOperationUnaryPlus:
+		r = a
+	case ExprOperationUnaryMinus:
+		r, carry = bits.Sub64(0, a, 0)
+	case ExprOperationAdd, ExprOperationAddWrap, ExprOperationAddSat:
+		r, carry = bits.Add64(a, b, 0)
+	case ExprOperationSubtract, ExprOperationSubtractWrap, ExprOperationSubtractSat:
+		r, carry = bits.Sub64(a, b, 0)
+	case ExprOperationMultiply, ExprOperationMultiplyWrap, ExprOperationMultiplySat:
+		carry, r = bits.Mul64(a, b)
+	case ExprOperationQuo:
+		if b == 0 {
+			return nil, DivByZeroErr
+		}
+
+		r = a / b
+	case ExprOperationRem:
+		if b == 0 {
+			return nil, DivByZeroErr
+		}
+
+		r = a % b
+	case ExprOperationGreater:
+		return GDBool(a > b), nil
+	case ExprOperationGreaterEqual:
+		return GDBool(a >= b), nil
+	case ExprOperationLess:
+		return GDBool(a < b), nil
+	case ExprOperationLessEqual:
+		return GDBool(a <= b), nil
+	case ExprOperationEqual:
+		return GDBool(a == b), nil
+	case ExprOperationNotEqual:
+		return GDBool(a != b), nil
+	default:
+		return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], GDUInt64Type.ToString(), GDUInt64Type.ToString())
+	}
+
+	if carry == 0 {
+		return GDUInt64(r), nil
+	}
+
+	switch op {
+	case ExprOperationAddWrap, ExprOperationSubtractWrap, ExprOperationMultiplyWrap:
+		return GDUInt64(r), nil
+	case ExprOperationAddSat, ExprOperationMultiplySat:
+		return GDUInt64(math.MaxUint64), nil
+	case ExprOperationSubtractSat:
+		return GDUInt64(0), nil
+	}
+
+	return nil, IntOverflowErr(GDUInt64Type)
+}
+
+// The operations between a `uint64` and a negative `int` are performed on big integers,
+// so the comparisons are right and the result overflows when it is negative
+func performSignedUInt64Op(op ExprOperationType, a, b *big.Int) (GDObject, error) {
+	bigOp := op
+	switch op {
+	case ExprOperationAddWrap, ExprOperationAddSat:
+		bigOp = ExprOperationAdd
+	case ExprOperationSubtractWrap, ExprOperationSubtractSat:
+		bigOp = ExprOperationSubtract
+	case ExprOperationMultiplyWrap, ExprOperationMultiplySat:
+		bigOp = ExprOperationMultiply
+	}
+
+	obj, err := performBigIntOp(bigOp, a, b)
+	if err != nil || IsComparisonOperation(op) {
+		return obj, err
+	}
+
+	r := obj.(*GDBigInt).Value
+	if r.IsUint64() {
+		return GDUInt64(r.Uint64()), nil
+	}
+
+	switch op {
+	case ExprOperationAddWrap, ExprOperationSubtractWrap, ExprOperationMultiplyWrap:
+		// The low 64 bits of the two's complement
+		return GDUInt64(new(big.Int).And(r, new(big.Int).SetUint64(math.MaxUint64)).Uint64()), nil
+	case ExprOperationAddSat, ExprOperationSubtractSat, ExprOperationMultiplySat:
+		if r.Sign() < 0 {
+			return GDUInt64(0), nil
+		}
+
+		return GDUInt64(math.MaxUint64), nil
+	}
+
+	return nil, IntOverflowErr(GDUInt64Type)
+}
+
+// The `uint64` operands are stored as an `int`, so they are read back as unsigned
+func uint64ToBigInt(value GDInt, isSigned bool) *big.Int {
+	if isSigned {
+		return big.NewInt(int64(value))
+	}
+
+	return new(big.Int).SetUint64(uint64(value))
+}
+
+func intBounds(typ GDTypable) (int64, int64) {
+	switch typ {
+	case GDInt8Type:
+		return math.MinInt8, math.MaxInt8
+	case GDInt16Type:
+		return math.MinInt16, math.MaxInt16
+	case GDInt32Type:
+		return math.MinInt32, math.MaxInt32
+	case GDUInt8Type:
+		return 0, math.MaxUint8
+	case GDUInt16Type:
+		return 0, math.MaxUint16
+	case GDUInt32Type:
+		return 0, math.MaxUint32
+	}
+
+	return math.MinInt64, math.MaxInt64
+}
+
+// A decimal is exact and a float is not, so mixing them
+// needs an explicit cast of one of the operands
+func isDecimalFloatOperation(a, b GDObject) bool {
+	isBinaryFloat := func(obj GDObject) bool { return IsFloat(obj) || IsComplex(obj) }
+	return (IsDecimal(a) && isBinaryFloat(b)) || (isBinaryFloat(a) && IsDecimal(b))
+}
+
+// Big numbers take part in all the arithmetic operations,
+// the other operand is promoted to the big number type
+func bigNumberOperationType(op ExprOperationType, typ GDTypable, a, b GDObject) (GDTypable, error) {
+	switch op {
+	case ExprOperationUnaryPlus, ExprOperationUnaryMinus:
+		return typ, nil
+	case ExprOperationAdd, ExprOperationSubtract, ExprOperationMultiply, ExprOperationQuo, ExprOperationRem:
+		return typ, nil
+	case ExprOperationGreater, ExprOperationGreaterEqual, ExprOperationLess, ExprOperationLessEqual, ExprOperationEqual, ExprOperationNotEqual:
+		return GDBoolType, nil
 	}
 
 	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
 }
 
+func performBigIntOp(op ExprOperationType, a, b *big.Int) (GDObject, error) {
+	switch op {
+	case ExprOperationUnaryPlus:
+		return NewGDBigInt(a), nil
+	case ExprOperationUnaryMinus:
+		return NewGDBigInt(new(big.Int).Neg(a)), nil
+	case ExprOperationAdd:
+		return NewGDBigInt(new(big.Int).Add(a, b)), nil
+	case ExprOperationSubtract:
+		return NewGDBigInt(new(big.Int).Sub(a, b)), nil
+	case ExprOperationMultiply:
+		return NewGDBigInt(new(big.Int).Mul(a, b)), nil
+	case ExprOperationQuo:
+		if b.Sign() == 0 {
+			return nil, DivByZeroErr
+		}
+
+		return NewGDBigInt(new(big.Int).Quo(a, b)), nil
+	case ExprOperationRem:
+		if b.Sign() == 0 {
+			return nil, DivByZeroErr
+		}
+
+		return NewGDBigInt(new(big.Int).Rem(a, b)), nil
+	}
+
+	if IsComparisonOperation(op) {
+		return compareOrdered(op, a.Cmp(b)), nil
+	}
+
+	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], GDBigIntType.ToString(), GDBigIntType.ToString())
+}
+
+func performDecimalOp(op ExprOperationType, a, b *GDDecimal) (GDObject, error) {
+	switch op {
+	case ExprOperationUnaryPlus:
+		return a, nil
+	case ExprOperationUnaryMinus:
+		return NewGDDecimal(new(big.Int).Neg(a.Unscaled), a.Scale), nil
+	case ExprOperationMultiply:
+		return NewGDDecimal(new(big.Int).Mul(a.Unscaled, b.Unscaled), a.Scale+b.Scale), nil
+	case ExprOperationQuo:
+		return divideDecimals(a, b)
+	}
+
+	uA, uB, scale := alignDecimals(a, b)
+	switch op {
+	case ExprOperationAdd:
+		return NewGDDecimal(new(big.Int).Add(uA, uB), scale), nil
+	case ExprOperationSubtract:
+		return NewGDDecimal(new(big.Int).Sub(uA, uB), scale), nil
+	case ExprOperationRem:
+		if uB.Sign() == 0 {
+			return nil, DivByZeroErr
+		}
+
+		return NewGDDecimal(new(big.Int).Rem(uA, uB), scale), nil
+	}
+
+	if IsComparisonOperation(op) {
+		return compareOrdered(op, uA.Cmp(uB)), nil
+	}
+
+	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], GDDecimalType.ToString(), GDDecimalType.ToString())
+}
+
+// Maps the result of a three-way comparison to the comparison operation
+func compareOrdered(op ExprOperationType, cmp int) GDBool {
+	switch op {
+	case ExprOperationGreater:
+		return cmp > 0
+	case ExprOperationGreaterEqual:
+		return cmp >= 0
+	case ExprOperationLess:
+		return cmp < 0
+	case ExprOperationLessEqual:
+		return cmp <= 0
+	case ExprOperationEqual:
+		return cmp == 0
+	}
+
+	return cmp != 0
+}
+
 func performFloatOp(op ExprOperationType, a, b GDFloat64) (GDObject, error) {
 	switch op {
 	case ExprOperationUnaryPlus:
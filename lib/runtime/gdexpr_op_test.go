@@ -208,3 +208,81 @@ func TestWrongTypesAddition(t *testing.T) {
 		t.Errorf("Expected %q, but got %v", "ba", result)
 	}
 }
+
+func TestSizedIntOperations(t *testing.T) {
+	for _, test := range []struct {
+		op       runtime.ExprOperationType
+		a, b     runtime.GDObject
+		expected runtime.GDObject
+		errMsg   string
+	}{
+		{runtime.ExprOperationAdd, runtime.GDInt8(100), runtime.GDInt8(27), runtime.GDInt8(127), ""},
+		{runtime.ExprOperationAdd, runtime.GDInt8(127), runtime.GDInt8(1), nil, "integer overflow of `int8`"},
+		{runtime.ExprOperationAddWrap, runtime.GDInt8(127), runtime.GDInt8(1), runtime.GDInt8(-128), ""},
+		{runtime.ExprOperationAddWrap, runtime.GDUInt8(255), runtime.GDInt(1), runtime.GDUInt8(0), ""},
+		{runtime.ExprOperationSubtractWrap, runtime.GDInt(0), runtime.GDUInt16(1), runtime.GDUInt16(math.MaxUint16), ""},
+		{runtime.ExprOperationMultiplyWrap, runtime.GDInt32(math.MaxInt32), runtime.GDInt32(2), runtime.GDInt32(-2), ""},
+		{runtime.ExprOperationSubtract, runtime.GDUInt8(0), runtime.GDUInt8(1), nil, "integer overflow of `uint8`"},
+		{runtime.ExprOperationSubtractSat, runtime.GDUInt8(0), runtime.GDUInt8(1), runtime.GDUInt8(0), ""},
+		{runtime.ExprOperationAddSat, runtime.GDInt16(math.MaxInt16), runtime.GDInt16(1), runtime.GDInt16(math.MaxInt16), ""},
+		{runtime.ExprOperationMultiplySat, runtime.GDInt16(-300), runtime.GDInt16(300), runtime.GDInt16(math.MinInt16), ""},
+		{runtime.ExprOperationQuo, runtime.GDUInt64(math.MaxUint64), runtime.GDUInt64(2), runtime.GDUInt64(math.MaxUint64 / 2), ""},
+		{runtime.ExprOperationAdd, runtime.GDUInt64(math.MaxUint64), runtime.GDUInt64(1), nil, "integer overflow of `uint64`"},
+		{runtime.ExprOperationMultiplySat, runtime.GDUInt64(math.MaxUint64), runtime.GDUInt64(2), runtime.GDUInt64(math.MaxUint64), ""},
+		{runtime.ExprOperationGreater, runtime.GDUInt64(math.MaxUint64), runtime.GDUInt64(1), runtime.GDBool(true), ""},
+		{runtime.ExprOperationUnaryMinus, runtime.GDUInt8(1), nil, nil, "integer overflow of `uint8`"},
+		{runtime.ExprOperationQuo, runtime.GDInt8(math.MinInt8), runtime.GDInt8(-1), nil, "integer overflow of `int8`"},
+		{runtime.ExprOperationAdd, runtime.GDInt8(1), runtime.GDUInt8(1), nil, "unsupported operation `+` between `int8` and `uint8`"},
+	} {
+		result, err := runtime.PerformExprOperation(test.op, test.a, test.b)
+		if err != nil {
+			if test.errMsg == "" || err.Error() != test.errMsg {
+				t.Errorf("Expected error %q, but got %q", test.errMsg, err.Error())
+			}
+			continue
+		}
+
+		if result != test.expected {
+			t.Errorf("Expected %v (%s), but got %v (%s)", test.expected.ToString(), test.expected.GetType().ToString(), result.ToString(), result.GetType().ToString())
+		}
+	}
+}
+
+func TestIntOverflow(t *testing.T) {
+	for _, test := range []struct {
+		op       runtime.ExprOperationType
+		a, b     runtime.GDObject
+		expected runtime.GDObject
+		errMsg   string
+	}{
+		{runtime.ExprOperationAdd, runtime.GDInt(math.MaxInt), runtime.GDInt(1), nil, "integer overflow of `int`"},
+		{runtime.ExprOperationSubtract, runtime.GDInt(math.MinInt), runtime.GDInt(1), nil, "integer overflow of `int`"},
+		{runtime.ExprOperationMultiply, runtime.GDInt(math.MaxInt / 2), runtime.GDInt(3), nil, "integer overflow of `int`"},
+		{runtime.ExprOperationMultiply, runtime.GDInt(-1), runtime.GDInt(math.MinInt), nil, "integer overflow of `int`"},
+		{runtime.ExprOperationQuo, runtime.GDInt(math.MinInt), runtime.GDInt(-1), nil, "integer overflow of `int`"},
+		{runtime.ExprOperationUnaryMinus, runtime.GDInt(math.MinInt), runtime.GDZNil, nil, "integer overflow of `int`"},
+		{runtime.ExprOperationQuo, runtime.GDInt(1), runtime.GDInt(0), nil, "division by zero"},
+		{runtime.ExprOperationRem, runtime.GDInt(1), runtime.GDInt(0), nil, "division by zero"},
+		{runtime.ExprOperationRem, runtime.GDInt(math.MinInt), runtime.GDInt(-1), runtime.GDInt(0), ""},
+		{runtime.ExprOperationAddWrap, runtime.GDInt(math.MaxInt), runtime.GDInt(1), runtime.GDInt(math.MinInt), ""},
+		{runtime.ExprOperationAddSat, runtime.GDInt(math.MaxInt), runtime.GDInt(1), runtime.GDInt(math.MaxInt), ""},
+		{runtime.ExprOperationSubtractSat, runtime.GDInt(math.MinInt), runtime.GDInt(1), runtime.GDInt(math.MinInt), ""},
+		{runtime.ExprOperationMultiplySat, runtime.GDInt(math.MaxInt / 2), runtime.GDInt(-3), runtime.GDInt(math.MinInt), ""},
+		{runtime.ExprOperationMultiplySat, runtime.GDInt(math.MinInt), runtime.GDInt(-1), runtime.GDInt(math.MaxInt), ""},
+		{runtime.ExprOperationAddWrap, runtime.GDFloat64(1), runtime.GDFloat64(1), nil, "unsupported operation `+%` between `float` and `float`"},
+	} {
+		result, err := runtime.PerformExprOperation(test.op, test.a, test.b)
+		if err != nil {
+			if test.errMsg == "" || err.Error() != test.errMsg {
+				t.Errorf("Expected error %q, but got %q", test.errMsg, err.Error())
+			}
+			continue
+		}
+
+		if test.errMsg != "" {
+			t.Errorf("Expected error %q, but got %v", test.errMsg, result.ToString())
+		} else if result != test.expected {
+			t.Errorf("Expected %v, but got %v", test.expected.ToString(), result.ToString())
+		}
+	}
+}
@@ -67,6 +67,16 @@ func (gd *GDLambdaType) CheckNumberOfArgs(argsLen uint) error {
 	return nil
 }
 
+// Type of the parameter of the argument at the index, the arguments
+// from the last parameter of a variadic function on take its type
+func (gd *GDLambdaType) ArgTypeAtIndex(index int) GDTypable {
+	if last := len(gd.ArgTypes) - 1; index >= last && gd.IsVariadic {
+		return gd.ArgTypes[last].Value
+	}
+
+	return gd.ArgTypes[index].Value
+}
+
 func (gd *GDLambdaType) CheckArgAtIndex(index int, typ GDTypable, stack *GDSymbolStack) error {
 	argTypesCount := len(gd.ArgTypes) - 1
 	var lambdaArg GDLambdaArgType
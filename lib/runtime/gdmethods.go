@@ -0,0 +1,250 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+import (
+	"slices"
+	"strings"
+)
+
+// A native method of a builtin type, it returns the
+// method symbol bound to the receiver object
+type GDNativeMethod func(receiver GDObject) *GDSymbol
+
+// Native methods of the builtin types, by type code and method name
+var nativeMethods = map[GDTypableCode]map[string]GDNativeMethod{
+	GDStringTypeCode: {
+		"len":        strMethod(nil, GDIntType, strLen),
+		"isEmpty":    strMethod(nil, GDBoolType, strIsEmpty),
+		"upper":      strMethod(nil, GDStringType, strTransform(strings.ToUpper)),
+		"lower":      strMethod(nil, GDStringType, strTransform(strings.ToLower)),
+		"trim":       strMethod(nil, GDStringType, strTransform(strings.TrimSpace)),
+		"contains":   strMethod(strArgs("substr"), GDBoolType, strPredicate("substr", strings.Contains)),
+		"startsWith": strMethod(strArgs("prefix"), GDBoolType, strPredicate("prefix", strings.HasPrefix)),
+		"endsWith":   strMethod(strArgs("suffix"), GDBoolType, strPredicate("suffix", strings.HasSuffix)),
+		"indexOf":    strMethod(strArgs("substr"), GDIntType, strIndexOf),
+		"split":      strMethod(strArgs("sep"), NewGDArrayType(GDStringType), strSplit),
+		"replace":    strMethod(strArgs("old", "new"), GDStringType, strReplace),
+	},
+	GDArrayTypeCode: {
+		"len":      iterableLen,
+		"isEmpty":  iterableIsEmpty,
+		"contains": arrayContains,
+		"indexOf":  arrayIndexOf,
+		"join":     arrayJoin,
+	},
+	GDTupleTypeCode: {
+		"len": iterableLen,
+	},
+	GDSetTypeCode: {
+		"len":      iterableLen,
+		"isEmpty":  iterableIsEmpty,
+		"contains": setContains,
+	},
+}
+
+// Returns the native methods of the object as an attributable object,
+// false when the type of the object has no native methods
+func NativeMethodsOf(obj GDObject) (GDAttributable, bool) {
+	obj = Unwrap(obj)
+	if _, hasMethods := nativeMethods[obj.GetType().GetCode()]; !hasMethods {
+		return nil, false
+	}
+
+	return &GDNativeMethods{obj}, true
+}
+
+// The native methods of a builtin object, the methods are
+// bound to the receiver when they are accessed
+type GDNativeMethods struct {
+	Receiver GDObject
+}
+
+func (gd *GDNativeMethods) GetStack() *GDSymbolStack { return nil }
+
+func (gd *GDNativeMethods) GetAttr(ident GDIdent) (*GDSymbol, error) {
+	typ := gd.Receiver.GetType()
+	method, ok := nativeMethods[typ.GetCode()][ident.ToString()]
+	if !ok {
+		return nil, MethodNotFoundErr(typ, ident.ToString())
+	}
+
+	return method(gd.Receiver), nil
+}
+
+func (gd *GDNativeMethods) SetAttr(ident GDIdent, _ GDObject) (*GDSymbol, error) {
+	return nil, SetMethodErr(ident.ToString())
+}
+
+// Builds the symbol of a native method
+func nativeMethod(args GDLambdaArgTypes, returnType GDTypable, cb func(args GDLambdaArgs) (GDObject, error)) *GDSymbol {
+	typ := NewGDLambdaType(args, returnType, false)
+	lambda := NewGDLambdaWithType(typ, nil, func(_ *GDSymbolStack, args GDLambdaArgs) (GDObject, error) {
+		return cb(args)
+	})
+
+	return NewGDSymbol(true, true, typ, lambda)
+}
+
+// The type of the elements of a collection, untyped collections take any element
+func elementType(receiver GDObject) GDTypable {
+	var subType GDTypable
+	switch typ := receiver.GetType().(type) {
+	case *GDArrayType:
+		subType = typ.SubType
+	case *GDSetType:
+		subType = typ.SubType
+	}
+
+	if subType == nil || IsUntypedType(subType) {
+		return GDAnyType
+	}
+
+	return subType
+}
+
+// String methods
+
+func strArgs(names ...string) GDLambdaArgTypes {
+	args := make(GDLambdaArgTypes, len(names))
+	for i, name := range names {
+		args[i] = GDLambdaArgType{Key: NewStrRefType(name), Value: GDStringType}
+	}
+
+	return args
+}
+
+func strArg(args GDLambdaArgs, name string) string {
+	return args.Get(NewStrRefType(name)).ToString()
+}
+
+func strMethod(args GDLambdaArgTypes, returnType GDTypable, opFunc func(str GDString, args GDLambdaArgs) (GDObject, error)) GDNativeMethod {
+	return func(receiver GDObject) *GDSymbol {
+		return nativeMethod(args, returnType, func(args GDLambdaArgs) (GDObject, error) {
+			return opFunc(receiver.(GDString), args)
+		})
+	}
+}
+
+func strLen(str GDString, _ GDLambdaArgs) (GDObject, error) {
+	return NewGDIntNumber(GDInt(str.Length())), nil
+}
+
+func strIsEmpty(str GDString, _ GDLambdaArgs) (GDObject, error) {
+	return GDBool(str.IsEmpty()), nil
+}
+
+func strTransform(transform func(s string) string) func(str GDString, args GDLambdaArgs) (GDObject, error) {
+	return func(str GDString, _ GDLambdaArgs) (GDObject, error) {
+		return GDString(transform(string(str))), nil
+	}
+}
+
+func strPredicate(name string, predicate func(s, arg string) bool) func(str GDString, args GDLambdaArgs) (GDObject, error) {
+	return func(str GDString, args GDLambdaArgs) (GDObject, error) {
+		return GDBool(predicate(string(str), strArg(args, name))), nil
+	}
+}
+
+// Index by rune of the first occurrence of the substring, or -1
+func strIndexOf(str GDString, args GDLambdaArgs) (GDObject, error) {
+	index := strings.Index(string(str), strArg(args, "substr"))
+	if index < 0 {
+		return NewGDIntNumber(-1), nil
+	}
+
+	return NewGDIntNumber(GDInt(GDString(str[:index]).Length())), nil
+}
+
+func strSplit(str GDString, args GDLambdaArgs) (GDObject, error) {
+	parts := strings.Split(string(str), strArg(args, "sep"))
+	objects := make([]GDObject, len(parts))
+	for i, part := range parts {
+		objects[i] = GDString(part)
+	}
+
+	return NewGDArrayWithTypeAndObjects(NewGDArrayType(GDStringType), objects), nil
+}
+
+func strReplace(str GDString, args GDLambdaArgs) (GDObject, error) {
+	return GDString(strings.ReplaceAll(string(str), strArg(args, "old"), strArg(args, "new"))), nil
+}
+
+// Collection methods
+
+type lengthable interface{ Length() int }
+
+func iterableLen(receiver GDObject) *GDSymbol {
+	return nativeMethod(nil, GDIntType, func(_ GDLambdaArgs) (GDObject, error) {
+		return NewGDIntNumber(GDInt(receiver.(lengthable).Length())), nil
+	})
+}
+
+func iterableIsEmpty(receiver GDObject) *GDSymbol {
+	return nativeMethod(nil, GDBoolType, func(_ GDLambdaArgs) (GDObject, error) {
+		return GDBool(receiver.(lengthable).Length() == 0), nil
+	})
+}
+
+func elementArgs(receiver GDObject) GDLambdaArgTypes {
+	return GDLambdaArgTypes{{Key: NewStrRefType("value"), Value: elementType(receiver)}}
+}
+
+func elementArg(args GDLambdaArgs) GDObject {
+	return args.Get(NewStrRefType("value"))
+}
+
+func arrayContains(receiver GDObject) *GDSymbol {
+	return nativeMethod(elementArgs(receiver), GDBoolType, func(args GDLambdaArgs) (GDObject, error) {
+		value := elementArg(args)
+		return GDBool(slices.ContainsFunc(receiver.(*GDArray).Objects, func(obj GDObject) bool {
+			return Equal(obj, value)
+		})), nil
+	})
+}
+
+// Index of the first element equal to the value, or -1
+func arrayIndexOf(receiver GDObject) *GDSymbol {
+	return nativeMethod(elementArgs(receiver), GDIntType, func(args GDLambdaArgs) (GDObject, error) {
+		value := elementArg(args)
+		return NewGDIntNumber(GDInt(slices.IndexFunc(receiver.(*GDArray).Objects, func(obj GDObject) bool {
+			return Equal(obj, value)
+		}))), nil
+	})
+}
+
+func arrayJoin(receiver GDObject) *GDSymbol {
+	return nativeMethod(strArgs("sep"), GDStringType, func(args GDLambdaArgs) (GDObject, error) {
+		return GDString(JoinSlice(receiver.(*GDArray).Objects, func(obj GDObject, _ int) string {
+			return obj.ToString()
+		}, strArg(args, "sep"))), nil
+	})
+}
+
+func setContains(receiver GDObject) *GDSymbol {
+	return nativeMethod(elementArgs(receiver), GDBoolType, func(args GDLambdaArgs) (GDObject, error) {
+		contains, err := receiver.(*GDSet).Contains(elementArg(args))
+		if err != nil {
+			return nil, err
+		}
+
+		return GDBool(contains), nil
+	})
+}
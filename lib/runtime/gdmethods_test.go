@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime_test
+
+import (
+	"gdlang/lib/runtime"
+	"testing"
+)
+
+func callMethod(t *testing.T, receiver runtime.GDObject, name string, args ...runtime.GDObject) runtime.GDObject {
+	methods, ok := runtime.NativeMethodsOf(receiver)
+	if !ok {
+		t.Fatal("Expected native methods for", receiver.GetType().ToString())
+	}
+
+	symbol, err := methods.GetAttr(runtime.NewGDStringIdent(name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := symbol.Object.(*runtime.GDLambda).Call(runtime.NewGDArray(args...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func TestNativeMethodsBindReceiver(t *testing.T) {
+	str := runtime.GDString("héllo")
+	if got := callMethod(t, str, "upper"); got != runtime.GDString("HÉLLO") {
+		t.Error("Expected HÉLLO, got", got.ToString())
+	}
+
+	if got := callMethod(t, str, "len"); got.ToString() != "5" {
+		t.Error("Expected 5, got", got.ToString())
+	}
+
+	arr := runtime.NewGDArrayWithTypeAndObjects(runtime.NewGDArrayType(runtime.GDIntType), []runtime.GDObject{runtime.GDInt(1)})
+	lenMethod, _ := runtime.NativeMethodsOf(arr)
+	symbol, err := lenMethod.GetAttr(runtime.NewGDStringIdent("len"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The method sees the mutations of the receiver after it was bound
+	arr.Objects = append(arr.Objects, runtime.GDInt(2))
+	got, err := symbol.Object.(*runtime.GDLambda).Call(runtime.NewGDArray())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ToString() != "2" {
+		t.Error("Expected 2, got", got.ToString())
+	}
+}
+
+func TestNativeMethodsErrors(t *testing.T) {
+	if _, ok := runtime.NativeMethodsOf(runtime.GDBool(true)); ok {
+		t.Error("Expected no native methods for bool")
+	}
+
+	methods, _ := runtime.NativeMethodsOf(runtime.GDString("abc"))
+	if _, err := methods.GetAttr(runtime.NewGDStringIdent("reverse")); err == nil {
+		t.Error("Expected an error getting an unknown method")
+	}
+
+	if _, err := methods.SetAttr(runtime.NewGDStringIdent("len"), runtime.GDZNil); err == nil {
+		t.Error("Expected an error setting a method")
+	}
+}
@@ -0,0 +1,37 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+// An object of a nominal type, it wraps an object of the underlying type
+type GDNominalObject struct {
+	Type   *GDNominalType
+	Object GDObject
+}
+
+func (gd *GDNominalObject) GetType() GDTypable    { return gd.Type }
+func (gd *GDNominalObject) GetSubType() GDTypable { return nil }
+func (gd *GDNominalObject) ToString() string      { return gd.Object.ToString() }
+func (gd *GDNominalObject) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return CastObject(gd, typ, stack)
+}
+
+func NewGDNominalObject(typ *GDNominalType, obj GDObject) *GDNominalObject {
+	return &GDNominalObject{typ, obj}
+}
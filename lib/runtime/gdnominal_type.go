@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+import "hash/fnv"
+
+// A nominal type is a distinct type with the same representation as its underlying type,
+// it is declared with `type Ident = Type` and it requires an explicit cast (`as`)
+// to be converted from and to the underlying type.
+type GDNominalType struct {
+	Ident GDIdent
+	// Identifies the declaration, so the nominal types with the same
+	// name declared in different packages or scopes are different
+	Decl uint32
+	Type GDTypable
+}
+
+func (t *GDNominalType) GetCode() GDTypableCode { return GDNominalTypeCode }
+func (t *GDNominalType) ToString() string       { return t.Ident.ToString() }
+
+// Nominal types are equal only when they come from the same declaration
+func (t *GDNominalType) IsSameType(other *GDNominalType) bool {
+	return t == other || (t.Decl == other.Decl && t.Ident.GetRawValue() == other.Ident.GetRawValue())
+}
+
+func NewGDNominalType(ident GDIdent, decl uint32, typ GDTypable) *GDNominalType {
+	return &GDNominalType{ident, decl, typ}
+}
+
+// Identifier of the declaration of a nominal type from its position, e.g. `pkg/main.gd:3:6`
+func NominalDeclOf(position string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(position))
+
+	return hash.Sum32()
+}
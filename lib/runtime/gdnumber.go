@@ -21,6 +21,7 @@ package runtime
 
 import (
 	"math"
+	"math/big"
 	"math/bits"
 	"strconv"
 )
@@ -52,14 +53,9 @@ func NewGDNumber(number any) (GDObject, error) {
 	return nil, nil
 }
 
+// Int objects are not compacted into a smaller size,
+// since sized integers are types on their own
 func NewGDIntNumber(value GDInt) GDObject {
-	bitSize := IntBitsLen(int(value))
-	switch {
-	case bitSize < 8:
-		return GDInt8(value)
-	case bitSize < 16:
-		return GDInt16(value)
-	}
 	return value
 }
 
@@ -111,13 +107,15 @@ func (gd GDInt) ToString() string      { return strconv.FormatInt(int64(gd), 10)
 func (gd GDInt) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDSizedInt(typ, int64(gd)), nil
+		}
+
 		switch typ {
 		case GDIntType:
 			return gd, nil
-		case GDInt8Type:
-			return GDInt8(int8(gd)), nil
-		case GDInt16Type:
-			return GDInt16(int16(gd)), nil
+		case GDBigIntType, GDDecimalType:
+			return NewGDBigInt(big.NewInt(int64(gd))).CastToType(typ, stack)
 		case GDFloatType:
 			return NewGDFloatNumber(GDFloat64(gd)), nil
 		case GDFloat32Type:
@@ -142,82 +140,207 @@ func (gd GDInt) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error
 	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
 }
 
-type GDInt8 int8
+// Sized integers
 
-func (gd GDInt8) GetType() GDTypable    { return GDIntType }
-func (gd GDInt8) GetSubType() GDTypable { return GDInt8Type }
-func (gd GDInt8) ToString() string      { return strconv.FormatInt(int64(gd), 10) }
-func (gd GDInt8) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+// Creates a sized integer of the given type, the value
+// wraps around when it doesn't fit in the size of the type
+func NewGDSizedInt(typ GDTypable, value int64) GDObject {
+	switch typ {
+	case GDInt8Type:
+		return GDInt8(value)
+	case GDInt16Type:
+		return GDInt16(value)
+	case GDInt32Type:
+		return GDInt32(value)
+	case GDInt64Type:
+		return GDInt64(value)
+	case GDUInt8Type:
+		return GDUInt8(value)
+	case GDUInt16Type:
+		return GDUInt16(value)
+	case GDUInt32Type:
+		return GDUInt32(value)
+	case GDUInt64Type:
+		return GDUInt64(value)
+	}
+
+	return GDInt(value)
+}
+
+// Unlike casts between numbers, parsing fails if the value doesn't fit in the size of the type
+func NewGDSizedIntFromString(typ GDTypable, number string) (GDObject, error) {
+	if IsUnsignedIntType(typ) {
+		uintValue, err := strconv.ParseUint(number, 0, sizedIntBitsLen(typ))
+		if err != nil {
+			return nil, InvalidCastingLitErr(number, typ)
+		}
+		return NewGDSizedInt(typ, int64(uintValue)), nil
+	}
+
+	intValue, err := strconv.ParseInt(number, 0, sizedIntBitsLen(typ))
+	if err != nil {
+		return nil, InvalidCastingLitErr(number, typ)
+	}
+	return NewGDSizedInt(typ, intValue), nil
+}
+
+func IsSizedIntType(typ GDTypable) bool {
+	switch typ {
+	case GDInt8Type, GDInt16Type, GDInt32Type, GDInt64Type:
+		return true
+	}
+
+	return IsUnsignedIntType(typ)
+}
+
+func IsUnsignedIntType(typ GDTypable) bool {
+	switch typ {
+	case GDUInt8Type, GDUInt16Type, GDUInt32Type, GDUInt64Type:
+		return true
+	}
+
+	return false
+}
+
+func sizedIntBitsLen(typ GDTypable) int {
+	switch typ {
+	case GDInt8Type, GDUInt8Type:
+		return 8
+	case GDInt16Type, GDUInt16Type:
+		return 16
+	case GDInt32Type, GDUInt32Type:
+		return 32
+	}
+
+	return 64
+}
+
+// The conversion of a sized integer into another integer type wraps around
+func castSizedInt(obj GDObject, value int64, fValue float64, typ GDTypable) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDSizedInt(typ, value), nil
+		}
+
 		switch typ {
 		case GDIntType:
-			return NewGDIntNumber(GDInt(int(gd))), nil
-		case GDInt8Type:
-			return gd, nil
-		case GDInt16Type:
-			return GDInt16(gd), nil
+			return GDInt(value), nil
+		case GDBigIntType, GDDecimalType:
+			bigValue := big.NewInt(value)
+			if uintValue, isUInt64 := obj.(GDUInt64); isUInt64 {
+				bigValue = new(big.Int).SetUint64(uint64(uintValue))
+			}
+
+			return NewGDBigInt(bigValue).CastToType(typ, nil)
 		case GDFloatType:
-			return NewGDFloatNumber(GDFloat64(gd)), nil
+			return NewGDFloatNumber(GDFloat64(fValue)), nil
 		case GDFloat32Type:
-			return GDFloat32(gd), nil
+			return GDFloat32(fValue), nil
 		case GDFloat64Type:
-			return GDFloat64(gd), nil
+			return GDFloat64(fValue), nil
 		case GDComplexType:
-			return NewGDComplexNumber(GDComplex128(complex(float64(gd), 0))), nil
+			return NewGDComplexNumber(GDComplex128(complex(fValue, 0))), nil
 		case GDComplex64Type:
-			return GDComplex64(complex(float32(gd), 0)), nil
+			return GDComplex64(complex(float32(fValue), 0)), nil
 		case GDComplex128Type:
-			return GDComplex128(complex(float64(gd), 0)), nil
+			return GDComplex128(complex(fValue, 0)), nil
 		case GDStringType:
-			return GDString(gd.ToString()), nil
+			return GDString(obj.ToString()), nil
 		case GDCharType:
-			return GDChar(gd), nil
+			return GDChar(rune(value)), nil
 		case GDBoolType:
-			return GDBool(gd != 0), nil
+			return GDBool(value != 0), nil
 		}
 	}
 
-	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
+	return nil, InvalidCastingWrongTypeErr(typ, obj.GetType())
+}
+
+// Floats are converted into the shortest decimal that converts back into the same float
+func castFloatToBig(value float64, bitSize int, typ GDTypable) (GDObject, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, InvalidCastingLitErr(strconv.FormatFloat(value, 'g', -1, 64), typ)
+	}
+
+	decimal, err := NewGDDecimalFromFloat(value, bitSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return decimal.CastToType(typ, nil)
+}
+
+type GDInt8 int8
+
+func (gd GDInt8) GetType() GDTypable    { return GDInt8Type }
+func (gd GDInt8) GetSubType() GDTypable { return nil }
+func (gd GDInt8) ToString() string      { return strconv.FormatInt(int64(gd), 10) }
+func (gd GDInt8) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
 }
 
 type GDInt16 int16
 
-func (gd GDInt16) GetType() GDTypable    { return GDIntType }
-func (gd GDInt16) GetSubType() GDTypable { return GDInt16Type }
+func (gd GDInt16) GetType() GDTypable    { return GDInt16Type }
+func (gd GDInt16) GetSubType() GDTypable { return nil }
 func (gd GDInt16) ToString() string      { return strconv.FormatInt(int64(gd), 10) }
 func (gd GDInt16) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
-	switch typ := typ.(type) {
-	case GDType:
-		switch typ {
-		case GDIntType:
-			return NewGDIntNumber(GDInt(int(gd))), nil
-		case GDInt8Type:
-			return GDInt8(int8(gd)), nil
-		case GDInt16Type:
-			return gd, nil
-		case GDFloatType:
-			return NewGDFloatNumber(GDFloat64(gd)), nil
-		case GDFloat32Type:
-			return GDFloat32(gd), nil
-		case GDFloat64Type:
-			return GDFloat64(gd), nil
-		case GDComplexType:
-			return NewGDComplexNumber(GDComplex128(complex(float64(gd), 0))), nil
-		case GDComplex64Type:
-			return GDComplex64(complex(float32(gd), 0)), nil
-		case GDComplex128Type:
-			return GDComplex128(complex(float64(gd), 0)), nil
-		case GDStringType:
-			return GDString(gd.ToString()), nil
-		case GDCharType:
-			return GDChar(gd), nil
-		case GDBoolType:
-			return GDBool(gd != 0), nil
-		}
-	}
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
+}
 
-	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
+type GDInt32 int32
+
+func (gd GDInt32) GetType() GDTypable    { return GDInt32Type }
+func (gd GDInt32) GetSubType() GDTypable { return nil }
+func (gd GDInt32) ToString() string      { return strconv.FormatInt(int64(gd), 10) }
+func (gd GDInt32) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
+}
+
+type GDInt64 int64
+
+func (gd GDInt64) GetType() GDTypable    { return GDInt64Type }
+func (gd GDInt64) GetSubType() GDTypable { return nil }
+func (gd GDInt64) ToString() string      { return strconv.FormatInt(int64(gd), 10) }
+func (gd GDInt64) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
+}
+
+type GDUInt8 uint8
+
+func (gd GDUInt8) GetType() GDTypable    { return GDUInt8Type }
+func (gd GDUInt8) GetSubType() GDTypable { return nil }
+func (gd GDUInt8) ToString() string      { return strconv.FormatUint(uint64(gd), 10) }
+func (gd GDUInt8) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
+}
+
+type GDUInt16 uint16
+
+func (gd GDUInt16) GetType() GDTypable    { return GDUInt16Type }
+func (gd GDUInt16) GetSubType() GDTypable { return nil }
+func (gd GDUInt16) ToString() string      { return strconv.FormatUint(uint64(gd), 10) }
+func (gd GDUInt16) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
+}
+
+type GDUInt32 uint32
+
+func (gd GDUInt32) GetType() GDTypable    { return GDUInt32Type }
+func (gd GDUInt32) GetSubType() GDTypable { return nil }
+func (gd GDUInt32) ToString() string      { return strconv.FormatUint(uint64(gd), 10) }
+func (gd GDUInt32) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
+}
+
+type GDUInt64 uint64
+
+func (gd GDUInt64) GetType() GDTypable    { return GDUInt64Type }
+func (gd GDUInt64) GetSubType() GDTypable { return nil }
+func (gd GDUInt64) ToString() string      { return strconv.FormatUint(uint64(gd), 10) }
+func (gd GDUInt64) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return castSizedInt(gd, int64(gd), float64(gd), typ)
 }
 
 // Float
@@ -230,13 +353,15 @@ func (gd GDFloat32) ToString() string      { return strconv.FormatFloat(float64(
 func (gd GDFloat32) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDSizedInt(typ, int64(gd)), nil
+		}
+
 		switch typ {
 		case GDIntType:
 			return NewGDIntNumber(GDInt(int(gd))), nil
-		case GDInt8Type:
-			return GDInt8(int8(gd)), nil
-		case GDInt16Type:
-			return GDInt16(int16(gd)), nil
+		case GDBigIntType, GDDecimalType:
+			return castFloatToBig(float64(gd), 32, typ)
 		case GDFloatType:
 			return NewGDFloatNumber(GDFloat64(gd)), nil
 		case GDFloat32Type:
@@ -269,13 +394,15 @@ func (gd GDFloat64) ToString() string      { return strconv.FormatFloat(float64(
 func (gd GDFloat64) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDSizedInt(typ, int64(gd)), nil
+		}
+
 		switch typ {
 		case GDIntType:
 			return NewGDIntNumber(GDInt(int(gd))), nil
-		case GDInt8Type:
-			return GDInt8(int8(gd)), nil
-		case GDInt16Type:
-			return GDInt16(int16(gd)), nil
+		case GDBigIntType, GDDecimalType:
+			return castFloatToBig(float64(gd), 64, typ)
 		case GDFloatType:
 			return NewGDFloatNumber(gd), nil
 		case GDFloat32Type:
@@ -310,13 +437,15 @@ func (gd GDComplex64) ToString() string      { return strconv.FormatComplex(comp
 func (gd GDComplex64) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDSizedInt(typ, int64(real(gd))), nil
+		}
+
 		switch typ {
 		case GDIntType:
 			return NewGDIntNumber(GDInt(real(gd))), nil
-		case GDInt8Type:
-			return GDInt8(int8(real(gd))), nil
-		case GDInt16Type:
-			return GDInt16(int16(real(gd))), nil
+		case GDBigIntType, GDDecimalType:
+			return castFloatToBig(float64(real(gd)), 32, typ)
 		case GDFloatType:
 			return NewGDFloatNumber(GDFloat64(real(gd))), nil
 		case GDFloat32Type:
@@ -347,13 +476,15 @@ func (gd GDComplex128) ToString() string      { return strconv.FormatComplex(com
 func (gd GDComplex128) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	switch typ := typ.(type) {
 	case GDType:
+		if IsSizedIntType(typ) {
+			return NewGDSizedInt(typ, int64(real(gd))), nil
+		}
+
 		switch typ {
 		case GDIntType:
 			return NewGDIntNumber(GDInt(real(gd))), nil
-		case GDInt8Type:
-			return GDInt8(int8(real(gd))), nil
-		case GDInt16Type:
-			return GDInt16(int16(real(gd))), nil
+		case GDBigIntType, GDDecimalType:
+			return castFloatToBig(float64(real(gd)), 64, typ)
 		case GDFloatType:
 			return NewGDFloatNumber(GDFloat64(real(gd))), nil
 		case GDFloat32Type:
@@ -51,8 +51,8 @@ func TestSubTypeNumberKind(t *testing.T) {
 		value                  runtime.GDObject
 		expectedValue          any
 	}{
-		{reflect.Int8, runtime.NewGDIntNumber(math.MaxInt8), runtime.GDInt8(math.MaxInt8)},
-		{reflect.Int16, runtime.NewGDIntNumber(math.MaxInt16), runtime.GDInt16(math.MaxInt16)},
+		{reflect.Int, runtime.NewGDIntNumber(math.MaxInt8), runtime.GDInt(math.MaxInt8)},
+		{reflect.Int, runtime.NewGDIntNumber(math.MaxInt16), runtime.GDInt(math.MaxInt16)},
 		{reflect.Float32, runtime.NewGDFloatNumber(math.MaxInt8), runtime.GDFloat32(math.MaxInt8)},
 		{reflect.Float64, runtime.NewGDFloatNumber(math.MaxFloat32), runtime.GDFloat64(math.MaxFloat32)},
 	} {
@@ -107,9 +107,9 @@ func TestNumberKind(t *testing.T) {
 		{runtime.NewGDFloatNumber(math.MaxFloat32), reflect.Float64},
 		{runtime.NewGDFloatNumber(math.MaxFloat64), reflect.Float64},
 		{runtime.NewGDFloatNumber(-10.0), reflect.Float32},
-		{runtime.NewGDIntNumber(0), reflect.Int8},
-		{runtime.NewGDIntNumber(10), reflect.Int8},
-		{runtime.NewGDIntNumber(-10), reflect.Int8},
+		{runtime.NewGDIntNumber(0), reflect.Int},
+		{runtime.NewGDIntNumber(10), reflect.Int},
+		{runtime.NewGDIntNumber(-10), reflect.Int},
 		{runtime.NewGDFloatNumber(-2.71828), reflect.Float32},
 	} {
 		numberKind := reflect.TypeOf(test.number).Kind()
@@ -124,7 +124,7 @@ func TestIntOverflows(t *testing.T) {
 		number       runtime.GDObject
 		expectedType reflect.Kind
 	}{
-		{runtime.NewGDIntNumber(math.MaxInt8 + 1), reflect.Int16},
+		{runtime.NewGDIntNumber(math.MaxInt8 + 1), reflect.Int},
 		{runtime.NewGDIntNumber(math.MaxInt16 + 1), reflect.Int},
 		{runtime.NewGDIntNumber(math.MaxInt32 + 1), reflect.Int},
 	} {
@@ -213,3 +213,53 @@ func TestNumberIntFromString(t *testing.T) {
 		}
 	}
 }
+
+func TestSizedIntWrap(t *testing.T) {
+	for _, test := range []struct {
+		typ      runtime.GDTypable
+		value    int64
+		expected runtime.GDObject
+	}{
+		{runtime.GDInt8Type, math.MaxInt8 + 1, runtime.GDInt8(math.MinInt8)},
+		{runtime.GDInt16Type, math.MaxInt16 + 1, runtime.GDInt16(math.MinInt16)},
+		{runtime.GDInt32Type, math.MaxInt32 + 1, runtime.GDInt32(math.MinInt32)},
+		{runtime.GDInt64Type, math.MaxInt64, runtime.GDInt64(math.MaxInt64)},
+		{runtime.GDUInt8Type, 256, runtime.GDUInt8(0)},
+		{runtime.GDUInt8Type, -1, runtime.GDUInt8(math.MaxUint8)},
+		{runtime.GDUInt16Type, -1, runtime.GDUInt16(math.MaxUint16)},
+		{runtime.GDUInt32Type, -1, runtime.GDUInt32(math.MaxUint32)},
+		{runtime.GDUInt64Type, -1, runtime.GDUInt64(math.MaxUint64)},
+		{runtime.GDByteType, 300, runtime.GDUInt8(44)},
+	} {
+		result := runtime.NewGDSizedInt(test.typ, test.value)
+		if result != test.expected {
+			t.Errorf("Expected %v (%s), but got %v (%s)", test.expected.ToString(), test.expected.GetType().ToString(), result.ToString(), result.GetType().ToString())
+		}
+	}
+}
+
+func TestSizedIntFromString(t *testing.T) {
+	for _, test := range []struct {
+		typ      runtime.GDTypable
+		value    string
+		expected runtime.GDObject
+		errMsg   string
+	}{
+		{runtime.GDInt8Type, "127", runtime.GDInt8(127), ""},
+		{runtime.GDInt8Type, "128", nil, "error trying to cast `128` into a `int8`"},
+		{runtime.GDUInt8Type, "-1", nil, "error trying to cast `-1` into a `uint8`"},
+		{runtime.GDUInt64Type, "18446744073709551615", runtime.GDUInt64(math.MaxUint64), ""},
+	} {
+		result, err := runtime.NewGDSizedIntFromString(test.typ, test.value)
+		if err != nil {
+			if err.Error() != test.errMsg {
+				t.Errorf("Expected error %q, but got %q", test.errMsg, err.Error())
+			}
+			continue
+		}
+
+		if result != test.expected {
+			t.Errorf("Expected %v, but got %v", test.expected.ToString(), result.ToString())
+		}
+	}
+}
@@ -19,23 +19,42 @@
 
 package runtime
 
+import "math/big"
+
 // Comparators
 
 func IsNumber(value any) bool {
-	return IsInt(value) || IsFloat(value) || IsComplex(value)
+	return IsInt(value) || IsBigInt(value) || IsDecimal(value) || IsFloat(value) || IsComplex(value)
 }
 
 func IsInt(value any) bool {
 	switch value.(type) {
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		return true
-	case GDInt, GDInt8, GDInt16:
+	case GDInt, GDInt8, GDInt16, GDInt32, GDInt64:
+		return true
+	case GDUInt8, GDUInt16, GDUInt32, GDUInt64:
 		return true
 	}
 
 	return false
 }
 
+func IsSizedInt(value any) bool {
+	obj, isObj := value.(GDObject)
+	return isObj && IsSizedIntType(obj.GetType())
+}
+
+func IsBigInt(value any) bool {
+	_, isBigInt := value.(*GDBigInt)
+	return isBigInt
+}
+
+func IsDecimal(value any) bool {
+	_, isDecimal := value.(*GDDecimal)
+	return isDecimal
+}
+
 func IsFloat(value any) bool {
 	switch value.(type) {
 	case float32, float64:
@@ -66,6 +85,18 @@ func ToInt(value any) (GDInt, error) {
 		return GDInt(value), nil
 	case GDInt16:
 		return GDInt(value), nil
+	case GDInt32:
+		return GDInt(value), nil
+	case GDInt64:
+		return GDInt(value), nil
+	case GDUInt8:
+		return GDInt(value), nil
+	case GDUInt16:
+		return GDInt(value), nil
+	case GDUInt32:
+		return GDInt(value), nil
+	case GDUInt64:
+		return GDInt(value), nil
 	case GDFloat32:
 		return GDInt(value), nil
 	case GDFloat64:
@@ -74,6 +105,12 @@ func ToInt(value any) (GDInt, error) {
 		return GDInt(real(value)), nil
 	case GDComplex128:
 		return GDInt(real(value)), nil
+	case *GDBigInt:
+		intValue, err := bigIntToInt64(value.Value, GDIntType)
+		return GDInt(intValue), err
+	case *GDDecimal:
+		intValue, err := bigIntToInt64(value.Truncate(), GDIntType)
+		return GDInt(intValue), err
 	case GDObject:
 		return GDInt(0), InvalidCastingWrongTypeErr(GDIntType, value.GetType())
 	default:
@@ -89,6 +126,18 @@ func ToFloat(value any) (GDFloat64, error) {
 		return GDFloat64(value), nil
 	case GDInt16:
 		return GDFloat64(value), nil
+	case GDInt32:
+		return GDFloat64(value), nil
+	case GDInt64:
+		return GDFloat64(value), nil
+	case GDUInt8:
+		return GDFloat64(value), nil
+	case GDUInt16:
+		return GDFloat64(value), nil
+	case GDUInt32:
+		return GDFloat64(value), nil
+	case GDUInt64:
+		return GDFloat64(value), nil
 	case GDFloat32:
 		return GDFloat64(value), nil
 	case GDFloat64:
@@ -97,6 +146,11 @@ func ToFloat(value any) (GDFloat64, error) {
 		return GDFloat64(real(value)), nil
 	case GDComplex128:
 		return GDFloat64(real(value)), nil
+	case *GDBigInt:
+		f, _ := new(big.Float).SetInt(value.Value).Float64()
+		return GDFloat64(f), nil
+	case *GDDecimal:
+		return GDFloat64(value.Float64()), nil
 	case GDObject:
 		return GDFloat64(0), InvalidCastingWrongTypeErr(GDFloatType, value.GetType())
 	default:
@@ -112,6 +166,18 @@ func ToComplex(value any) (GDComplex128, error) {
 		return GDComplex128(complex(float64(value), 0)), nil
 	case GDInt16:
 		return GDComplex128(complex(float64(value), 0)), nil
+	case GDInt32:
+		return GDComplex128(complex(float64(value), 0)), nil
+	case GDInt64:
+		return GDComplex128(complex(float64(value), 0)), nil
+	case GDUInt8:
+		return GDComplex128(complex(float64(value), 0)), nil
+	case GDUInt16:
+		return GDComplex128(complex(float64(value), 0)), nil
+	case GDUInt32:
+		return GDComplex128(complex(float64(value), 0)), nil
+	case GDUInt64:
+		return GDComplex128(complex(float64(value), 0)), nil
 	case GDFloat32:
 		return GDComplex128(complex(value, 0)), nil
 	case GDFloat64:
@@ -120,9 +186,52 @@ func ToComplex(value any) (GDComplex128, error) {
 		return GDComplex128(value), nil
 	case GDComplex128:
 		return value, nil
+	case *GDBigInt, *GDDecimal:
+		f, err := ToFloat(value)
+		return GDComplex128(complex(f, 0)), err
 	case GDObject:
 		return GDComplex128(0), InvalidCastingWrongTypeErr(GDComplexType, value.GetType())
 	default:
 		return GDComplex128(0), InvalidCastingExpectedTypeErr(GDComplexType)
 	}
 }
+
+func ToBigInt(value any) (*big.Int, error) {
+	switch value := value.(type) {
+	case GDUInt64:
+		return new(big.Int).SetUint64(uint64(value)), nil
+	case *GDBigInt:
+		return value.Value, nil
+	case *GDDecimal:
+		return value.Truncate(), nil
+	case GDObject:
+		if IsInt(value) {
+			intValue, err := ToInt(value)
+			return big.NewInt(int64(intValue)), err
+		}
+
+		return nil, InvalidCastingWrongTypeErr(GDBigIntType, value.GetType())
+	default:
+		return nil, InvalidCastingExpectedTypeErr(GDBigIntType)
+	}
+}
+
+func ToDecimal(value any) (*GDDecimal, error) {
+	switch value := value.(type) {
+	case *GDDecimal:
+		return value, nil
+	case GDObject:
+		if IsInt(value) || IsBigInt(value) {
+			bigValue, err := ToBigInt(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewGDDecimal(bigValue, 0), nil
+		}
+
+		return nil, InvalidCastingWrongTypeErr(GDDecimalType, value.GetType())
+	default:
+		return nil, InvalidCastingExpectedTypeErr(GDDecimalType)
+	}
+}
@@ -19,12 +19,28 @@
 
 package runtime
 
+import "math/big"
+
 func ZObjectForType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	return zObjectForType(typ, stack, nil)
+}
+
+// The expanding type refs are tracked, so the zero value
+// of a recursive reference is nil instead of an infinite object
+func zObjectForType(typ GDTypable, stack *GDSymbolStack, expanding map[string]bool) (GDObject, error) {
 	switch typ.GetCode() {
 	case GDUntypedTypeCode:
 		return GDZUntyped, nil
 	case GDIntTypeCode:
 		return GDZInt, nil
+	case GDInt8TypeCode, GDInt16TypeCode, GDInt32TypeCode, GDInt64TypeCode:
+		return NewGDSizedInt(typ, 0), nil
+	case GDUInt8TypeCode, GDUInt16TypeCode, GDUInt32TypeCode, GDUInt64TypeCode:
+		return NewGDSizedInt(typ, 0), nil
+	case GDBigIntTypeCode:
+		return NewGDBigInt(new(big.Int)), nil
+	case GDDecimalTypeCode:
+		return NewGDDecimal(new(big.Int), 0), nil
 	case GDFloatTypeCode:
 		return GDZFloat, nil
 	case GDNilTypeCode:
@@ -40,12 +56,32 @@ func ZObjectForType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 	case GDStructTypeCode:
 		return NewGDStruct(typ.(GDStructType), stack)
 	case GDTypeRefTypeCode:
+		refIdent := typ.ToString()
+		if expanding[refIdent] {
+			return GDZNil, nil
+		}
+
 		symbol, err := stack.GetSymbol(typ.(GDIdent))
 		if err != nil {
 			return nil, err
 		}
 
-		return ZObjectForType(symbol.Type, stack)
+		if expanding == nil {
+			expanding = make(map[string]bool)
+		}
+		expanding[refIdent] = true
+		defer delete(expanding, refIdent)
+
+		return zObjectForType(symbol.Type, stack, expanding)
+	case GDNominalTypeCode:
+		nominalType := typ.(*GDNominalType)
+
+		obj, err := zObjectForType(nominalType.Type, stack, expanding)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGDNominalObject(nominalType, obj), nil
 	case GDCharTypeCode:
 		return GDZChar, nil
 	case GDTupleTypeCode:
@@ -53,7 +89,7 @@ func ZObjectForType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 
 		objs := make([]GDObject, len(tupleType))
 		for i, field := range tupleType {
-			obj, err := ZObjectForType(field, stack)
+			obj, err := zObjectForType(field, stack, expanding)
 			if err != nil {
 				return nil, err
 			}
@@ -64,11 +100,13 @@ func ZObjectForType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
 		return &GDTuple{typ.(GDTupleType), objs}, nil
 	case GDArrayTypeCode:
 		return NewGDArrayWithTypeAndObjects(typ.(*GDArrayType), []GDObject{}), nil
+	case GDSetTypeCode:
+		return newGDSet(typ.(*GDSetType)), nil
 	case GDUnionTypeCode:
 		objs := make([]GDObject, 0)
 		unionType := typ.(GDUnionType)
 		for _, field := range unionType {
-			obj, err := ZObjectForType(field, stack)
+			obj, err := zObjectForType(field, stack, expanding)
 			if err != nil {
 				return nil, err
 			}
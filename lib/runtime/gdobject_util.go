@@ -30,6 +30,52 @@ func Unwrap(value GDObject) GDObject {
 	return value
 }
 
+// Unwraps the object, and the underlying object of a nominal object, so the attributes
+// and the elements of nominal structs and collections can be read without a cast
+func UnwrapNominal(value GDObject) GDObject {
+	value = Unwrap(value)
+	if nominal, isNominal := value.(*GDNominalObject); isNominal {
+		return UnwrapNominal(nominal.Object)
+	}
+
+	return value
+}
+
+// Casts an object to a type, ident types are resolved using the stack.
+// Nominal objects are converted to the underlying type before casting,
+// and objects casted to a nominal type are wrapped after casting to the underlying type.
+func CastObject(obj GDObject, typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	typ, err := UnwrapIdentType(typ, stack)
+	if err != nil {
+		return nil, err
+	}
+
+	obj = Unwrap(obj)
+	nominalObj, isNominalObj := obj.(*GDNominalObject)
+	if nominalType, isNominalType := typ.(*GDNominalType); isNominalType {
+		if isNominalObj {
+			if nominalObj.Type.IsSameType(nominalType) {
+				return obj, nil
+			}
+
+			obj = nominalObj.Object
+		}
+
+		castObj, err := CastObject(obj, nominalType.Type, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGDNominalObject(nominalType, castObj), nil
+	}
+
+	if isNominalObj {
+		return CastObject(nominalObj.Object, typ, stack)
+	}
+
+	return obj.CastToType(typ, stack)
+}
+
 func EqualObjects(a, b GDObject) bool {
 	switch a := a.(type) {
 	case *GDTuple:
@@ -80,6 +126,11 @@ func TypeCoercion(obj GDObject, typ GDTypable, stack *GDSymbolStack) (GDObject,
 			obj.GDArrayType = typ
 			return obj, nil
 		}
+	case *GDSet:
+		if typ, ok := typ.(*GDSetType); ok {
+			obj.GDSetType = typ
+			return obj, nil
+		}
 	case *GDStruct:
 		if typ, ok := typ.(GDStructType); ok {
 			obj.Type = typ
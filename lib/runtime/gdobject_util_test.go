@@ -95,8 +95,8 @@ func TestCastObject(t *testing.T) {
 	defer stack.Dispose()
 
 	userStruct, err := runtime.NewGDStruct(runtime.GDStructType{
-		{runtime.NewGDStringIdent("name"), runtime.GDStringType},
-		{runtime.NewGDStringIdent("age"), runtime.GDIntType},
+		{Ident: runtime.NewGDStringIdent("name"), Type: runtime.GDStringType},
+		{Ident: runtime.NewGDStringIdent("age"), Type: runtime.GDIntType},
 	}, stack)
 	if err != nil {
 		t.Fatalf("Error creating user struct: %v", err)
@@ -119,7 +119,9 @@ func TestCastObject(t *testing.T) {
 		{runtime.GDString("1.0"), runtime.GDComplex64Type, ""}, // Complex64, Complex128 are ComplexType (Both are compatible)
 		{runtime.GDString(runtime.GDComplex128(complex(math.MaxFloat64, math.MaxFloat64)).ToString()), runtime.GDComplex128Type, ""},
 		{runtime.GDString("1.0"), runtime.GDIntType, "error trying to cast `1.0` into a `int`"},
-		{runtime.GDString("1.0"), runtime.GDInt8Type, "error trying to cast `1.0` into a `int`"}, // Internally it is an int8
+		{runtime.GDString("1.0"), runtime.GDInt8Type, "error trying to cast `1.0` into a `int8`"},
+		{runtime.GDString("256"), runtime.GDUInt8Type, "error trying to cast `256` into a `uint8`"},
+		{runtime.GDString("255"), runtime.GDUInt8Type, ""},
 		{runtime.NewGDArray(runtime.GDString("hola")), runtime.GDStringType, ""},
 		{runtime.NewGDArray(runtime.GDString("hola")), runtime.GDIntType, "error while casting `[string]` to `int`"},
 		// Char cases
@@ -193,15 +195,15 @@ func TestCastObject(t *testing.T) {
 		{runtime.NewGDTuple(runtime.GDChar('h')), runtime.NewGDTupleType(runtime.GDStringType), ""},
 		// Struct cases
 		{userStruct, runtime.GDStructType{
-			{runtime.NewGDStringIdent("name"), runtime.GDStringType},
-			{runtime.NewGDStringIdent("age"), runtime.GDIntType},
+			{Ident: runtime.NewGDStringIdent("name"), Type: runtime.GDStringType},
+			{Ident: runtime.NewGDStringIdent("age"), Type: runtime.GDIntType},
 		}, ""},
 		{userStruct, runtime.GDStructType{
-			{runtime.NewGDStringIdent("name"), runtime.GDStringType},
+			{Ident: runtime.NewGDStringIdent("name"), Type: runtime.GDStringType},
 		}, "attribute `age`, not found"},
 		{userStruct, runtime.GDStructType{
-			{runtime.NewGDStringIdent("name"), runtime.GDStringType},
-			{runtime.NewGDStringIdent("age"), runtime.GDStringType},
+			{Ident: runtime.NewGDStringIdent("name"), Type: runtime.GDStringType},
+			{Ident: runtime.NewGDStringIdent("age"), Type: runtime.GDStringType},
 		}, ""},
 	}
 
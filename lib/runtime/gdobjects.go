@@ -22,7 +22,7 @@ package runtime
 var (
 	GDZNil     = GDNil(0)
 	GDZAny     = GDAny(1)
-	GDZInt     = GDInt8(0)
+	GDZInt     = GDInt(0)
 	GDZFloat   = GDFloat32(0.0)
 	GDZComplex = GDComplex64(0)
 	GDZBool    = GDBool(false)
@@ -19,6 +19,11 @@
 
 package runtime
 
+import (
+	"slices"
+	"strings"
+)
+
 type GDPackageMode byte
 
 const (
@@ -82,6 +87,27 @@ func (p *GDPackage[T]) add(ident GDIdent, memberType GDMemberType, value T) erro
 	return nil
 }
 
+// Public members of the package sorted by name
+func (p *GDPackage[T]) PublicMembers() []GDIdent {
+	idents := make([]GDIdent, 0, len(p.Members))
+	for key, member := range p.Members {
+		if name, isName := key.(string); isName && member.Type == GDMemberPublic {
+			idents = append(idents, NewGDStringIdent(name))
+		}
+	}
+
+	slices.SortFunc(idents, func(a, b GDIdent) int {
+		return strings.Compare(a.ToString(), b.ToString())
+	})
+
+	return idents
+}
+
 func NewGDPackage[T any](name GDIdent, path string, typ GDPackageMode) *GDPackage[T] {
 	return &GDPackage[T]{name, path, typ, make(GDMembers[T])}
 }
+
+// Ident of a member of a package imported with an alias, e.g. `h.get` for `use http as h`
+func NewGDPackageMemberIdent(alias GDIdent, member GDIdent) GDIdent {
+	return NewGDStringIdent(alias.ToString() + "." + member.ToString())
+}
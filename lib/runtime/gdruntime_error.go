@@ -36,12 +36,15 @@ const (
 	InvalidCharConversionCode
 	NoFunctionCallbackErrCode
 	RuntimeErrorCode
+	IntOverflowCode
+	UnhashableTypeCode
 )
 
 var (
 	DivByZeroErr          = NewGDRuntimeErr(DivByZeroCode, "division by zero")
 	IndexOutOfBoundsErr   = NewGDRuntimeErr(IndexOutOfBoundsCode, "index out of bounds")
 	NoFunctionCallbackErr = NewGDRuntimeErr(NoFunctionCallbackErrCode, "no function callback")
+	SetIndexAssignmentErr = NewGDRuntimeErr(UnsupportedOperationCode, "the elements of a set can't be assigned by index")
 )
 
 type GDRuntimeErr struct {
@@ -58,6 +61,14 @@ func NewGDRuntimeErr(code int, msg string) GDRuntimeErr {
 	return GDRuntimeErr{code, msg, nil}
 }
 
+func IntOverflowErr(typ GDTypable) GDRuntimeErr {
+	return NewGDRuntimeErr(IntOverflowCode, Sprintf("integer overflow of `%@`", typ))
+}
+
+func UnhashableTypeErr(typ GDTypable) GDRuntimeErr {
+	return NewGDRuntimeErr(UnhashableTypeCode, Sprintf("unhashable type: `%@`", typ))
+}
+
 func InvalidCallableTypeErr(got GDTypable) GDRuntimeErr {
 	return NewGDRuntimeErr(IncompatibleTypeCode, Sprintf("invalid callable type: `%@`", got))
 }
@@ -118,6 +129,30 @@ func AttributeNotFoundErr(name string) GDRuntimeErr {
 	return NewGDRuntimeErr(AttrNotFoundCode, Sprintf("attribute `%@`, not found", name))
 }
 
+func PrivateAttributeErr(name string) GDRuntimeErr {
+	return NewGDRuntimeErr(AttrNotFoundCode, Sprintf("attribute `%@` is not public outside its package", name))
+}
+
+func CyclicTypeAliasErr(name string) GDRuntimeErr {
+	return NewGDRuntimeErr(UnsupportedTypeCode, Sprintf("the type alias `%@` refers to itself without a struct, collection or union in between", name))
+}
+
+func AttributeModifiersCastErr(name string) GDRuntimeErr {
+	return NewGDRuntimeErr(IncompatibleTypeCode, Sprintf("can't change the `pub` or `const` modifiers of the attribute `%@` outside its package", name))
+}
+
+func SetConstAttributeErr(name string) GDRuntimeErr {
+	return NewGDRuntimeErr(SetObjectWrongTypeErrCode, Sprintf("can't set the constant attribute `%@`", name))
+}
+
+func MethodNotFoundErr(typ GDTypable, name string) GDRuntimeErr {
+	return NewGDRuntimeErr(AttrNotFoundCode, Sprintf("type `%@` has no method `%@`", typ, name))
+}
+
+func SetMethodErr(name string) GDRuntimeErr {
+	return NewGDRuntimeErr(UnsupportedOperationCode, Sprintf("can't set the method `%@` of a builtin type", name))
+}
+
 func SetConstObjectErr() GDRuntimeErr {
 	return NewGDRuntimeErr(SetObjectWrongTypeErrCode, "can't set a constant object")
 }
@@ -133,3 +168,15 @@ func DuplicatedObjectCreationErr(key any) GDRuntimeErr {
 func MissingNumberOfArgumentsErr(expected, got uint) GDRuntimeErr {
 	return NewGDRuntimeErr(FuncMissingArgsCode, Sprintf("missing number of arguments: expected `%@` but got `%@`", expected, got))
 }
+
+func InvalidOperatorFuncArgsErr(operation string, expected, got uint) GDRuntimeErr {
+	return NewGDRuntimeErr(FuncMissingArgsCode, Sprintf("operator function `%@` expects `%@` arguments but got `%@`", operation, expected, got))
+}
+
+func InvalidOperatorFuncTypesErr(operation string) GDRuntimeErr {
+	return NewGDRuntimeErr(InvalidArgumentTypeCode, Sprintf("operator function `%@` requires at least one argument of a struct type", operation))
+}
+
+func AmbiguousOperatorFuncErr(operation string, a, b GDIdent) GDRuntimeErr {
+	return NewGDRuntimeErr(InvalidArgumentTypeCode, Sprintf("ambiguous operation `%@`, it is overloaded by both `%@` and `%@`", operation, a.ToString(), b.ToString()))
+}
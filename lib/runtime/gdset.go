@@ -0,0 +1,359 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+import "slices"
+
+// Sets keep the elements in insertion order, the elements are
+// indexed by their hash and compared with structural equality
+type GDSet struct {
+	Objects []GDObject
+	buckets map[GDInt][]GDObject
+	*GDSetType
+}
+
+func (gd *GDSet) GetType() GDTypable    { return gd.GDSetType }
+func (gd *GDSet) GetSubType() GDTypable { return nil }
+func (gd *GDSet) ToString() string {
+	vals := JoinSlice(gd.Objects, func(object GDObject, _ int) string {
+		return ObjectToStringForInternalData(object)
+	}, ", ")
+
+	return Sprintf("{|%@|}", vals)
+}
+func (gd *GDSet) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, error) {
+	switch typ := typ.(type) {
+	case GDType:
+		switch typ {
+		case GDStringType:
+			return GDString(gd.ToString()), nil
+		}
+	case *GDSetType:
+		objects, err := castObjects(gd.Objects, typ.SubType, typ, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		// Different elements might be equal after the cast, e.g. `1` and `1.0`
+		return NewGDSetWithTypeAndObjects(typ, objects)
+	case *GDArrayType:
+		objects, err := castObjects(gd.Objects, typ.SubType, typ, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGDArrayWithTypeAndObjects(typ, objects), nil
+	}
+
+	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
+}
+
+func (gd *GDSet) Length() int   { return len(gd.Objects) }
+func (gd *GDSet) IsEmpty() bool { return len(gd.Objects) == 0 }
+func (gd *GDSet) Get(index int) (GDObject, error) {
+	if err := gd.checkIndex(index); err != nil {
+		return nil, err
+	}
+
+	return copySetMember(gd.Objects[index])
+}
+func (gd *GDSet) GetObjects() []GDObject { return gd.Objects }
+
+func (gd *GDSet) Dispose() {
+	gd.Objects = nil
+	gd.buckets = make(map[GDInt][]GDObject)
+}
+func (gd *GDSet) AddObject(object GDObject, stack *GDSymbolStack) error {
+	err := CanBeAssign(gd.SubType, object.GetType(), stack)
+	if err != nil {
+		return err
+	}
+
+	_, err = gd.add(object)
+
+	return err
+}
+func (gd *GDSet) AddObjects(objects []GDObject, stack *GDSymbolStack) error {
+	for _, object := range objects {
+		if err := gd.AddObject(object, stack); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+func (gd *GDSet) Remove(index int) (GDObject, error) {
+	if err := gd.checkIndex(index); err != nil {
+		return nil, err
+	}
+
+	obj := gd.Objects[index]
+	_, err := gd.RemoveObject(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// The elements of a set are added and removed by value
+func (gd *GDSet) Set(index int, object GDObject, stack *GDSymbolStack) error {
+	return SetIndexAssignmentErr
+}
+
+func (gd *GDSet) Contains(object GDObject) (bool, error) {
+	hash, err := Hash(object)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(gd.buckets[hash], equalTo(object)), nil
+}
+
+// Removes the object by value, it returns false if the object is not in the set
+func (gd *GDSet) RemoveObject(object GDObject) (bool, error) {
+	hash, err := Hash(object)
+	if err != nil {
+		return false, err
+	}
+
+	bucket := gd.buckets[hash]
+	i := slices.IndexFunc(bucket, equalTo(object))
+	if i == -1 {
+		return false, nil
+	}
+
+	if len(bucket) == 1 {
+		delete(gd.buckets, hash)
+	} else {
+		gd.buckets[hash] = slices.Delete(bucket, i, i+1)
+	}
+
+	// There is only one element equal to the object
+	gd.Objects = slices.DeleteFunc(gd.Objects, equalTo(object))
+
+	return true, nil
+}
+
+// Adds a copy of the object if there is not an equal object in the set yet
+func (gd *GDSet) add(object GDObject) (bool, error) {
+	hash, err := Hash(object)
+	if err != nil {
+		return false, err
+	}
+
+	bucket := gd.buckets[hash]
+	if slices.ContainsFunc(bucket, equalTo(object)) {
+		return false, nil
+	}
+
+	object, err = copySetMember(object)
+	if err != nil {
+		return false, err
+	}
+
+	gd.buckets[hash] = append(bucket, object)
+	gd.Objects = append(gd.Objects, object)
+
+	return true, nil
+}
+
+// Keeps the objects that are, or are not, in the other set
+func (gd *GDSet) filter(typ *GDSetType, other *GDSet, inOther bool) (*GDSet, error) {
+	set := newGDSet(typ)
+	for _, object := range gd.Objects {
+		contains, err := other.Contains(object)
+		if err != nil {
+			return nil, err
+		}
+
+		if contains != inOther {
+			continue
+		}
+
+		_, err = set.add(object)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
+
+func (gd *GDSet) checkIndex(index int) error {
+	if index < 0 || index >= len(gd.Objects) {
+		return IndexOutOfBoundsErr
+	}
+	return nil
+}
+
+// The members are indexed by their hash, so the arrays, tuples, sets and structs
+// are copied when they are added to the set and when they are read from it.
+// Otherwise a change of the object would leave it in the wrong bucket
+func copySetMember(object GDObject) (GDObject, error) {
+	copyObjects := func(objects []GDObject) ([]GDObject, error) {
+		copies := make([]GDObject, len(objects))
+		for i, obj := range objects {
+			copyObj, err := copySetMember(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			copies[i] = copyObj
+		}
+
+		return copies, nil
+	}
+
+	switch obj := Unwrap(object).(type) {
+	case *GDArray:
+		objects, err := copyObjects(obj.Objects)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGDArrayWithTypeAndObjects(obj.GDArrayType, objects), nil
+	case *GDTuple:
+		objects, err := copyObjects(obj.Objects)
+		if err != nil {
+			return nil, err
+		}
+
+		return &GDTuple{obj.GDTupleType, objects}, nil
+	case *GDSet:
+		// The members of the new set are copied when they are added
+		return NewGDSetWithTypeAndObjects(obj.GDSetType, obj.Objects)
+	case *GDStruct:
+		structStack := obj.stack.Parent.NewSymbolStack(StructCtx)
+		for _, attr := range obj.Type {
+			symbol, err := obj.GetAttr(attr.Ident)
+			if err != nil {
+				return nil, err
+			}
+
+			attrObj, err := copySetMember(symbol.Object)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = structStack.AddSymbol(attr.Ident, symbol.IsPub, symbol.IsConst, symbol.Type, attrObj)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return &GDStruct{obj.Type, structStack}, nil
+	case *GDNominalObject:
+		nominalObj, err := copySetMember(obj.Object)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGDNominalObject(obj.Type, nominalObj), nil
+	}
+
+	return object, nil
+}
+
+func equalTo(object GDObject) func(GDObject) bool {
+	return func(item GDObject) bool { return Equal(item, object) }
+}
+
+// Casts the objects to the subtype of the collection type
+func castObjects(objects []GDObject, subType, typ GDTypable, stack *GDSymbolStack) ([]GDObject, error) {
+	castObjs := make([]GDObject, len(objects))
+	for i, obj := range objects {
+		castObj, err := obj.CastToType(subType, stack)
+		if err != nil {
+			return nil, TypeCastingWrongTypeWithHierarchyError(typ, obj.GetType(), err)
+		}
+
+		castObjs[i] = castObj
+	}
+
+	return castObjs, nil
+}
+
+// Sets can be combined when their elements have the same type,
+// an empty set without a type takes the type of the other set
+func setOperationType(op ExprOperationType, a, b *GDSet) (*GDSetType, error) {
+	switch {
+	case IsUntypedType(b.SubType):
+		return a.GDSetType, nil
+	case IsUntypedType(a.SubType):
+		return b.GDSetType, nil
+	case a.SubType.ToString() == b.SubType.ToString():
+		return a.GDSetType, nil
+	}
+
+	return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
+}
+
+// Union (|), intersection (&) and difference (-) of sets
+func performSetOp(op ExprOperationType, a, b *GDSet) (GDObject, error) {
+	typ, err := setOperationType(op, a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	var set *GDSet
+	switch op {
+	case ExprOperationUnion:
+		set, err = NewGDSetWithTypeAndObjects(typ, slices.Concat(a.Objects, b.Objects))
+	case ExprOperationIntersection:
+		set, err = a.filter(typ, b, true)
+	case ExprOperationSubtract:
+		set, err = a.filter(typ, b, false)
+	default:
+		return nil, UnsupportedOperationBetweenTypesError(ExprOperationMap[op], a.GetType().ToString(), b.GetType().ToString())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func newGDSet(setType *GDSetType) *GDSet {
+	return &GDSet{
+		Objects:   make([]GDObject, 0),
+		buckets:   make(map[GDInt][]GDObject),
+		GDSetType: setType,
+	}
+}
+
+func NewGDEmptySet() *GDSet { return newGDSet(NewGDEmptySetType()) }
+
+func NewGDSetWithType(subType GDTypable) *GDSet { return newGDSet(NewGDSetType(subType)) }
+
+// Only used when the type of the set is pre-computed,
+// the repeated objects are discarded.
+func NewGDSetWithTypeAndObjects(setType *GDSetType, objects []GDObject) (*GDSet, error) {
+	set := newGDSet(setType)
+	for _, object := range objects {
+		_, err := set.add(object)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime_test
+
+import (
+	"gdlang/lib/runtime"
+	"testing"
+)
+
+func newIntSet(t *testing.T, values ...int) *runtime.GDSet {
+	objects := make([]runtime.GDObject, len(values))
+	for i, value := range values {
+		objects[i] = runtime.GDInt(value)
+	}
+
+	set, err := runtime.NewGDSetWithTypeAndObjects(runtime.NewGDSetType(runtime.GDIntType), objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return set
+}
+
+func TestSetDiscardsRepeatedObjects(t *testing.T) {
+	set := newIntSet(t, 3, 1, 3, 2, 1)
+	if set.ToString() != "{|3, 1, 2|}" {
+		t.Error("Wrong set string representation, got", set.ToString())
+	}
+
+	err := set.AddObject(runtime.GDFloat64(2), nil)
+	if err == nil {
+		t.Error("Expected an error adding a float to a set of ints")
+	}
+
+	err = set.AddObject(runtime.GDInt(1), nil)
+	if err != nil || set.Length() != 3 {
+		t.Errorf("Expected the set to keep 3 elements, got %s", set.ToString())
+	}
+}
+
+func TestSetRemoveObject(t *testing.T) {
+	set := newIntSet(t, 1, 2, 3)
+
+	removed, err := set.RemoveObject(runtime.GDInt(2))
+	if err != nil || !removed {
+		t.Fatal("Expected the object to be removed")
+	}
+
+	removed, err = set.RemoveObject(runtime.GDInt(2))
+	if err != nil || removed {
+		t.Fatal("Expected the object to be already removed")
+	}
+
+	contains, err := set.Contains(runtime.GDInt(2))
+	if err != nil || contains {
+		t.Error("Expected the set to not contain the removed object")
+	}
+
+	if set.ToString() != "{|1, 3|}" {
+		t.Error("Wrong set string representation, got", set.ToString())
+	}
+
+	err = set.Set(0, runtime.GDInt(4), nil)
+	if err != runtime.SetIndexAssignmentErr {
+		t.Error("Expected an index assignment error, got", err)
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	a, b := newIntSet(t, 1, 2, 3), newIntSet(t, 3, 4)
+
+	for i, test := range []struct {
+		op       runtime.ExprOperationType
+		expected string
+	}{
+		{runtime.ExprOperationUnion, "{|1, 2, 3, 4|}"},
+		{runtime.ExprOperationIntersection, "{|3|}"},
+		{runtime.ExprOperationSubtract, "{|1, 2|}"},
+		{runtime.ExprOperationEqual, "false"},
+	} {
+		typ, err := runtime.TypeCheckExprOperation(test.op, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		obj, err := runtime.PerformExprOperation(test.op, a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if obj.ToString() != test.expected || obj.GetType().ToString() != typ.ToString() {
+			t.Errorf("Expected %s of type %s, but got %s of type %s for test case %d", test.expected, typ.ToString(), obj.ToString(), obj.GetType().ToString(), i+1)
+		}
+	}
+
+	in, err := runtime.PerformExprOperation(runtime.ExprOperationIn, runtime.GDFloat64(4), b)
+	if err != nil || in != runtime.GDBool(true) {
+		t.Error("Expected 4.0 to be in the set, got", in, err)
+	}
+}
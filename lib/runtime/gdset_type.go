@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package runtime
+
+type GDSetType struct {
+	SubType GDTypable // Internal set subtype
+}
+
+func (t *GDSetType) GetCode() GDTypableCode { return GDSetTypeCode }
+
+func (t *GDSetType) ToString() string {
+	if t.SubType != nil {
+		return "set<" + t.SubType.ToString() + ">"
+	}
+
+	return GDTypeCodeMap[GDSetTypeCode]
+}
+
+func (t *GDSetType) GetTypes() ([]GDTypable, bool) {
+	return []GDTypable{t.SubType}, true
+}
+
+func (t *GDSetType) GetIterableType() GDTypable {
+	return t.SubType
+}
+
+func NewGDSetType(subType GDTypable) *GDSetType { return &GDSetType{SubType: subType} }
+
+func NewGDEmptySetType() *GDSetType { return &GDSetType{SubType: GDUntypedType} }
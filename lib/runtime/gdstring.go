@@ -19,7 +19,11 @@
 
 package runtime
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+	"unsafe"
+)
 
 var charScapeMap = strings.NewReplacer(
 	"\\a", "\a",
@@ -49,13 +53,18 @@ func (gd GDString) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, er
 		return gd, nil
 	case GDBoolType:
 		return NewGDBoolFromString(string(gd))
-	case GDIntType, GDInt8Type, GDInt16Type:
+	case GDIntType:
 		return NewGDIntNumberFromString(string(gd))
+	case GDInt8Type, GDInt16Type, GDInt32Type, GDInt64Type:
+		return NewGDSizedIntFromString(typ, string(gd))
+	case GDUInt8Type, GDUInt16Type, GDUInt32Type, GDUInt64Type:
+		return NewGDSizedIntFromString(typ, string(gd))
+	case GDBigIntType:
+		return NewGDBigIntFromString(string(gd))
+	case GDDecimalType:
+		return NewGDDecimalFromString(string(gd))
 	case GDCharType:
-		if len(gd) != 1 {
-			return nil, InvalidCastingLitErr(gd.ToString(), GDCharType)
-		}
-		return GDChar(gd[0]), nil
+		return GDCharFromString(string(gd))
 	case GDFloatType, GDFloat32Type, GDFloat64Type:
 		return NewGDFloatNumberFromString(string(gd))
 	case GDComplexType, GDComplex64Type, GDComplex128Type:
@@ -65,20 +74,39 @@ func (gd GDString) CastToType(typ GDTypable, stack *GDSymbolStack) (GDObject, er
 	return nil, InvalidCastingWrongTypeErr(typ, gd.GetType())
 }
 
+// Strings are indexed, iterated and measured by rune,
+// use Bytes and Graphemes for the other views of the string
+
+func (gd GDString) Runes() []rune       { return []rune(gd) }
+func (gd GDString) Bytes() []byte       { return []byte(gd) }
+func (gd GDString) Graphemes() []string { return SplitGraphemes(string(gd)) }
+
 // Iterable interface
 
-func (gd GDString) Length() int   { return len(gd) }
+func (gd GDString) Length() int   { return utf8.RuneCountInString(string(gd)) }
 func (gd GDString) IsEmpty() bool { return len(gd) == 0 }
+
+// The runes are walked up to the index, use a
+// GDStringCursor to read consecutive indexes
 func (gd GDString) Get(index int) (GDObject, error) {
-	if err := gd.checkIndex(index); err != nil {
-		return nil, err
+	if index < 0 {
+		return nil, IndexOutOfBoundsErr
+	}
+
+	i := 0
+	for _, r := range string(gd) {
+		if i == index {
+			return GDChar(r), nil
+		}
+		i++
 	}
 
-	return GDChar(gd[index]), nil
+	return nil, IndexOutOfBoundsErr
 }
 func (gd GDString) GetObjects() []GDObject {
-	objects := make([]GDObject, len(gd))
-	for i, c := range gd {
+	runes := gd.Runes()
+	objects := make([]GDObject, len(runes))
+	for i, c := range runes {
 		objects[i] = GDChar(c)
 	}
 	return objects
@@ -86,9 +114,56 @@ func (gd GDString) GetObjects() []GDObject {
 func (gd GDString) GetTypes() ([]GDTypable, bool) { return []GDTypable{GDCharType}, true }
 func (gd GDString) GetIterableType() GDTypable    { return GDCharType }
 
-func (gd GDString) checkIndex(index int) error {
-	if index < 0 || index >= len(gd) {
-		return IndexOutOfBoundsErr
+// Reads the runes of a string by index, the length and whether the string is ASCII
+// are computed once, and the runes of the other strings are walked from the previous
+// index, so reading the indexes in order, as a for-in loop does, is linear.
+type GDStringCursor struct {
+	str     string
+	isASCII bool
+	length  int
+	// Rune index and byte offset of the last rune read
+	runeIdx, byteOff int
+}
+
+func (c *GDStringCursor) Length(gd GDString) int {
+	c.reset(gd)
+	return c.length
+}
+
+func (c *GDStringCursor) Get(gd GDString, index int) (GDObject, error) {
+	c.reset(gd)
+	if index < 0 || index >= c.length {
+		return nil, IndexOutOfBoundsErr
 	}
-	return nil
+
+	// Fast path for ASCII strings, where bytes and runes are the same
+	if c.isASCII {
+		return GDChar(c.str[index]), nil
+	}
+
+	if index < c.runeIdx {
+		c.runeIdx, c.byteOff = 0, 0
+	}
+
+	for ; c.runeIdx < index; c.runeIdx++ {
+		_, size := utf8.DecodeRuneInString(c.str[c.byteOff:])
+		c.byteOff += size
+	}
+
+	r, _ := utf8.DecodeRuneInString(c.str[c.byteOff:])
+
+	return GDChar(r), nil
+}
+
+// Strings are compared by their data, so a string is only measured once
+func (c *GDStringCursor) reset(gd GDString) {
+	str := string(gd)
+	if len(str) == len(c.str) && unsafe.StringData(str) == unsafe.StringData(c.str) {
+		return
+	}
+
+	c.str = str
+	c.length = utf8.RuneCountInString(str)
+	c.isASCII = c.length == len(str)
+	c.runeIdx, c.byteOff = 0, 0
 }
@@ -31,3 +31,101 @@ func TestSimpleString(t *testing.T) {
 		t.Error("Wrong value")
 	}
 }
+
+func TestStringRuneSemantics(t *testing.T) {
+	str := runtime.GDString("héllo")
+
+	if str.Length() != 5 {
+		t.Errorf("Expected length 5 but got %d", str.Length())
+	}
+
+	char, err := str.Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if char != runtime.GDChar('é') {
+		t.Errorf("Expected 'é' but got %q", char.ToString())
+	}
+
+	if _, err := str.Get(5); err == nil {
+		t.Error("Expected an index out of bounds error")
+	}
+
+	objs := str.GetObjects()
+	if len(objs) != 5 || objs[4] != runtime.GDChar('o') {
+		t.Errorf("Expected 5 chars ending with 'o' but got %v", objs)
+	}
+
+	if len(str.Bytes()) != 6 {
+		t.Errorf("Expected 6 bytes but got %d", len(str.Bytes()))
+	}
+}
+
+func TestStringCursor(t *testing.T) {
+	for _, str := range []runtime.GDString{"hello", "héllo wörld"} {
+		cursor := &runtime.GDStringCursor{}
+		runes := []rune(str)
+
+		if cursor.Length(str) != len(runes) {
+			t.Errorf("Expected length %d but got %d", len(runes), cursor.Length(str))
+		}
+
+		// Consecutive indexes, then backwards
+		indexes := []int{0, 1, 2, 3, 4, 4, 2, 0, len(runes) - 1}
+		for _, i := range indexes {
+			char, err := cursor.Get(str, i)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if char != runtime.GDChar(runes[i]) {
+				t.Errorf("Expected %q at %d of %q but got %q", runes[i], i, str, char.ToString())
+			}
+		}
+
+		for _, i := range []int{-1, len(runes)} {
+			if _, err := cursor.Get(str, i); err == nil {
+				t.Errorf("Expected an index out of bounds error at %d of %q", i, str)
+			}
+		}
+	}
+
+	// The cursor moves to another string
+	cursor := &runtime.GDStringCursor{}
+	cursor.Get("héllo", 3)
+	char, err := cursor.Get("wörld", 1)
+	if err != nil || char != runtime.GDChar('ö') {
+		t.Errorf("Expected 'ö' but got %v, %v", char, err)
+	}
+}
+
+func TestStringCastToChar(t *testing.T) {
+	for i, test := range []struct {
+		value runtime.GDString
+		want  runtime.GDChar
+		isErr bool
+	}{
+		{"a", 'a', false},
+		{"é", 'é', false},
+		{"世", '世', false},
+		{"", 0, true},
+		{"ab", 0, true},
+	} {
+		obj, err := test.value.CastToType(runtime.GDCharType, nil)
+		if test.isErr {
+			if err == nil {
+				t.Errorf("Expected an error for test case %d", i+1)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if obj != test.want {
+			t.Errorf("Expected %q but got %q for test case %d", test.want, obj.ToString(), i+1)
+		}
+	}
+}
@@ -19,6 +19,8 @@
 
 package runtime
 
+import "unicode"
+
 func IsString(value any) bool {
 	switch value := value.(type) {
 	case string:
@@ -38,3 +40,74 @@ func ToString(value any) (GDString, error) {
 		return "", InvalidCastingExpectedTypeErr(GDStringType)
 	}
 }
+
+const (
+	zeroWidthJoiner        = '\u200D'
+	regionalIndicatorFirst = '\U0001F1E6'
+	regionalIndicatorLast  = '\U0001F1FF'
+	emojiModifierFirst     = '\U0001F3FB'
+	emojiModifierLast      = '\U0001F3FF'
+)
+
+// Splits a string into user-perceived characters, an approximation of the
+// extended grapheme clusters that covers combining marks, variation selectors,
+// emoji modifiers, zero width joiner sequences, flags and CRLF.
+func SplitGraphemes(value string) []string {
+	graphemes := make([]string, 0, len(value))
+
+	start := 0
+	var prev rune = -1
+	regionalIndicators := 0
+	for i, c := range value {
+		if prev != -1 && !isGraphemeBreak(prev, c, regionalIndicators) {
+			prev = c
+			if isRegionalIndicator(c) {
+				regionalIndicators++
+			}
+			continue
+		}
+
+		if i > start {
+			graphemes = append(graphemes, value[start:i])
+		}
+
+		start = i
+		prev = c
+		regionalIndicators = 0
+		if isRegionalIndicator(c) {
+			regionalIndicators = 1
+		}
+	}
+
+	if start < len(value) {
+		graphemes = append(graphemes, value[start:])
+	}
+
+	return graphemes
+}
+
+func isGraphemeBreak(prev, next rune, regionalIndicators int) bool {
+	switch {
+	case prev == '\r' && next == '\n':
+		return false
+	case prev == zeroWidthJoiner:
+		return false
+	case isGraphemeExtend(next):
+		return false
+	// Flags are pairs of regional indicators
+	case isRegionalIndicator(prev) && isRegionalIndicator(next):
+		return regionalIndicators%2 == 0
+	}
+
+	return true
+}
+
+func isGraphemeExtend(c rune) bool {
+	return c == zeroWidthJoiner ||
+		unicode.In(c, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector) ||
+		(c >= emojiModifierFirst && c <= emojiModifierLast)
+}
+
+func isRegionalIndicator(c rune) bool {
+	return c >= regionalIndicatorFirst && c <= regionalIndicatorLast
+}
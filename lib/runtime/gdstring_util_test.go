@@ -48,3 +48,35 @@ func TestIsStringWithDifferentTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestSplitGraphemes(t *testing.T) {
+	for i, test := range []struct {
+		value string
+		want  []string
+	}{
+		{"", []string{}},
+		{"abc", []string{"a", "b", "c"}},
+		{"héllo", []string{"h", "é", "l", "l", "o"}},
+		// e followed by a combining acute accent
+		{"éa", []string{"é", "a"}},
+		{"a\r\nb", []string{"a", "\r\n", "b"}},
+		// Flags are pairs of regional indicators
+		{"🇪🇸🇫🇷", []string{"🇪🇸", "🇫🇷"}},
+		// Emoji modifier and zero width joiner sequence
+		{"👍🏽👨‍👩‍👧", []string{"👍🏽", "👨‍👩‍👧"}},
+		{"❤️!", []string{"❤️", "!"}},
+	} {
+		graphemes := runtime.SplitGraphemes(test.value)
+		if len(graphemes) != len(test.want) {
+			t.Errorf("SplitGraphemes(%q) = %q, want %q for test case %d", test.value, graphemes, test.want, i+1)
+			continue
+		}
+
+		for j, grapheme := range graphemes {
+			if grapheme != test.want[j] {
+				t.Errorf("SplitGraphemes(%q) = %q, want %q for test case %d", test.value, graphemes, test.want, i+1)
+				break
+			}
+		}
+	}
+}
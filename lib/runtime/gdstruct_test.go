@@ -27,7 +27,7 @@ import (
 
 func TestStructWithNilInitialization(t *testing.T) {
 	stack := runtime.NewRootGDSymbolStack()
-	sType := runtime.NewGDStructType(runtime.GDStructAttrType{attr1Ident, runtime.GDStringType})
+	sType := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: attr1Ident, Type: runtime.GDStringType})
 	structObj, err := runtime.NewGDStruct(sType, stack)
 	if err != nil {
 		t.Errorf("Error creating struct: %s", err.Error())
@@ -45,7 +45,7 @@ func TestStructWithNilInitialization(t *testing.T) {
 
 func TestChangeTheValueOfAnAttribute(t *testing.T) {
 	stack := runtime.NewRootGDSymbolStack()
-	sType := runtime.NewGDStructType(runtime.GDStructAttrType{attr1Ident, runtime.GDStringType})
+	sType := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: attr1Ident, Type: runtime.GDStringType})
 
 	structObj, err := runtime.NewGDStruct(sType, stack)
 	if err != nil {
@@ -70,7 +70,7 @@ func TestChangeTheValueOfAnAttribute(t *testing.T) {
 func TestReturnedObjectFromStructAreCopies(t *testing.T) {
 	stack := runtime.NewRootGDSymbolStack()
 
-	sType := runtime.NewGDStructType(runtime.GDStructAttrType{attr1Ident, runtime.GDStringType})
+	sType := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: attr1Ident, Type: runtime.GDStringType})
 
 	structObj, err := runtime.NewGDStruct(sType, stack)
 	if err != nil {
@@ -109,7 +109,7 @@ func TestReturnedObjectFromStructAreCopies(t *testing.T) {
 func TestAddAttributeWithSameName(t *testing.T) {
 	stack := runtime.NewRootGDSymbolStack()
 
-	sType := runtime.NewGDStructType(runtime.GDStructAttrType{attr1Ident, runtime.GDStringType}, runtime.GDStructAttrType{attr1Ident, runtime.GDIntType})
+	sType := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: attr1Ident, Type: runtime.GDStringType}, runtime.GDStructAttrType{Ident: attr1Ident, Type: runtime.GDIntType})
 
 	_, err := runtime.NewGDStruct(sType, stack)
 	if err == nil {
@@ -124,7 +124,7 @@ func TestAddAttributeWithSameName(t *testing.T) {
 func TestSetAttrWithDifferentType(t *testing.T) {
 	stack := runtime.NewRootGDSymbolStack()
 
-	sType := runtime.NewGDStructType(runtime.GDStructAttrType{attr1Ident, runtime.GDStringType})
+	sType := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: attr1Ident, Type: runtime.GDStringType})
 
 	structObj, err := runtime.NewGDStruct(sType, stack)
 	if err != nil {
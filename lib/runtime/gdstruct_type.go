@@ -22,10 +22,33 @@ package runtime
 type GDStructAttrType struct {
 	Ident GDIdent
 	Type  GDTypable
+	// Modifiers are only checked at compile time,
+	// they are ignored when comparing struct types
+	IsPub   bool
+	IsConst bool
+	// Path of the package that declares the attribute,
+	// an attribute that is not public is only accessible from this package.
+	// Attributes without a package, such as the ones from struct literals, are always accessible.
+	PkgPath string
 }
 
 func (t GDStructAttrType) GetCode() GDTypableCode { return GDNilTypeCode }
-func (t GDStructAttrType) ToString() string       { return t.Ident.ToString() + ": " + t.Type.ToString() }
+func (t GDStructAttrType) ToString() string {
+	modifiers := ""
+	if t.IsPub {
+		modifiers += "pub "
+	}
+
+	if t.IsConst {
+		modifiers += "const "
+	}
+
+	return modifiers + t.Ident.ToString() + ": " + t.Type.ToString()
+}
+
+func (t GDStructAttrType) IsAccessibleFrom(pkgPath string) bool {
+	return t.IsPub || t.PkgPath == "" || t.PkgPath == pkgPath
+}
 
 type GDStructType []GDStructAttrType
 
@@ -38,13 +61,22 @@ func (t GDStructType) ToString() string {
 }
 
 func (t GDStructType) GetAttrType(ident GDIdent) (GDTypable, error) {
+	attr, err := t.GetAttr(ident)
+	if err != nil {
+		return nil, err
+	}
+
+	return attr.Type, nil
+}
+
+func (t GDStructType) GetAttr(ident GDIdent) (GDStructAttrType, error) {
 	for _, attr := range t {
 		if attr.Ident.GetRawValue() == ident.GetRawValue() {
-			return attr.Type, nil
+			return attr, nil
 		}
 	}
 
-	return nil, AttributeNotFoundErr(ident.ToString())
+	return GDStructAttrType{}, AttributeNotFoundErr(ident.ToString())
 }
 
 // An empty struct type is a struct type with no attributes
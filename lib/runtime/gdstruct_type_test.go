@@ -27,8 +27,8 @@ import (
 
 func TestSimpleStructType(t *testing.T) {
 	stack := runtime.NewRootGDSymbolStack()
-	subStructTypeWithInt := runtime.NewGDStructType(runtime.GDStructAttrType{bParamIdent, runtime.GDIntType})
-	subStructTypeWithArray := runtime.NewGDStructType(runtime.GDStructAttrType{bParamIdent, runtime.NewGDArrayType(runtime.GDIntType)})
+	subStructTypeWithInt := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: bParamIdent, Type: runtime.GDIntType})
+	subStructTypeWithArray := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: bParamIdent, Type: runtime.NewGDArrayType(runtime.GDIntType)})
 
 	for _, test := range []struct {
 		attrs    []runtime.GDStructAttrType
@@ -36,15 +36,15 @@ func TestSimpleStructType(t *testing.T) {
 		errMsg   string
 	}{
 		{[]runtime.GDStructAttrType{}, "{}", ""},
-		{[]runtime.GDStructAttrType{{aParamIdent, runtime.GDIntType}}, "{a: int}", ""},
-		{[]runtime.GDStructAttrType{{aParamIdent, runtime.GDIntType}, {bParamIdent, runtime.GDStringType}}, "{a: int, b: string}", ""},
-		{[]runtime.GDStructAttrType{{aParamIdent, runtime.GDIntType}, {bParamIdent, runtime.GDStringType}, {cParamIdent, runtime.GDBoolType}}, "{a: int, b: string, c: bool}", ""},
+		{[]runtime.GDStructAttrType{{Ident: aParamIdent, Type: runtime.GDIntType}}, "{a: int}", ""},
+		{[]runtime.GDStructAttrType{{Ident: aParamIdent, Type: runtime.GDIntType}, {Ident: bParamIdent, Type: runtime.GDStringType}}, "{a: int, b: string}", ""},
+		{[]runtime.GDStructAttrType{{Ident: aParamIdent, Type: runtime.GDIntType}, {Ident: bParamIdent, Type: runtime.GDStringType}, {Ident: cParamIdent, Type: runtime.GDBoolType}}, "{a: int, b: string, c: bool}", ""},
 		// Struct with nested struct
-		{[]runtime.GDStructAttrType{{aParamIdent, subStructTypeWithInt}}, "{a: {b: int}}", ""},
+		{[]runtime.GDStructAttrType{{Ident: aParamIdent, Type: subStructTypeWithInt}}, "{a: {b: int}}", ""},
 		// Struct with nested struct and array
-		{[]runtime.GDStructAttrType{{aParamIdent, subStructTypeWithArray}}, "{a: {b: [int]}}", ""},
+		{[]runtime.GDStructAttrType{{Ident: aParamIdent, Type: subStructTypeWithArray}}, "{a: {b: [int]}}", ""},
 		// Wrong types
-		{[]runtime.GDStructAttrType{{aParamIdent, runtime.NewStrRefType("none")}}, "", "object `none` was not found"},
+		{[]runtime.GDStructAttrType{{Ident: aParamIdent, Type: runtime.NewStrRefType("none")}}, "", "object `none` was not found"},
 	} {
 		structType := runtime.NewGDStructType(test.attrs...)
 		err := runtime.CheckType(structType, stack)
@@ -70,3 +70,25 @@ func TestSimpleStructType(t *testing.T) {
 		}
 	}
 }
+
+func TestStructAttrModifiers(t *testing.T) {
+	for _, test := range []struct {
+		attr       runtime.GDStructAttrType
+		expected   string
+		accessible bool
+	}{
+		{runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDIntType}, "a: int", true},
+		{runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDIntType, PkgPath: "/pkg"}, "a: int", false},
+		{runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDIntType, PkgPath: "/main"}, "a: int", true},
+		{runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDIntType, IsPub: true, PkgPath: "/pkg"}, "pub a: int", true},
+		{runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDIntType, IsPub: true, IsConst: true, PkgPath: "/pkg"}, "pub const a: int", true},
+	} {
+		if test.attr.ToString() != test.expected {
+			t.Errorf("Expected %s but got %s", test.expected, test.attr.ToString())
+		}
+
+		if test.attr.IsAccessibleFrom("/main") != test.accessible {
+			t.Errorf("Expected accessibility of %s from /main to be %t", test.attr.ToString(), test.accessible)
+		}
+	}
+}
@@ -29,7 +29,9 @@ const (
 	GDTupleTypeCode
 	GDLambdaTypeCode
 	GDArrayTypeCode
+	GDSetTypeCode
 	GDStructTypeCode
+	GDNominalTypeCode
 
 	// Internal Types
 	GDUnionTypeCode
@@ -42,7 +44,15 @@ const (
 	// from lowest to highest precision
 	GDInt8TypeCode
 	GDInt16TypeCode
+	GDInt32TypeCode
+	GDInt64TypeCode
+	GDUInt8TypeCode
+	GDUInt16TypeCode
+	GDUInt32TypeCode
+	GDUInt64TypeCode
 	GDIntTypeCode
+	GDBigIntTypeCode
+	GDDecimalTypeCode
 	GDFloat32TypeCode
 	GDFloat64TypeCode
 	GDFloatTypeCode
@@ -58,10 +68,12 @@ var GDTypeCodeMap = [...]string{
 	GDCharTypeCode:   "char",
 	GDStringTypeCode: "string",
 
-	GDTupleTypeCode:  "tuple",
-	GDLambdaTypeCode: "func",
-	GDArrayTypeCode:  "array",
-	GDStructTypeCode: "struct",
+	GDTupleTypeCode:   "tuple",
+	GDLambdaTypeCode:  "func",
+	GDArrayTypeCode:   "array",
+	GDSetTypeCode:     "set",
+	GDStructTypeCode:  "struct",
+	GDNominalTypeCode: "nominal",
 
 	// Internal Types
 	GDUnionTypeCode:      "unionType",
@@ -74,7 +86,15 @@ var GDTypeCodeMap = [...]string{
 	// Number types
 	GDInt8TypeCode:       "int8",
 	GDInt16TypeCode:      "int16",
+	GDInt32TypeCode:      "int32",
+	GDInt64TypeCode:      "int64",
+	GDUInt8TypeCode:      "uint8",
+	GDUInt16TypeCode:     "uint16",
+	GDUInt32TypeCode:     "uint32",
+	GDUInt64TypeCode:     "uint64",
 	GDIntTypeCode:        "int",
+	GDBigIntTypeCode:     "bigint",
+	GDDecimalTypeCode:    "decimal",
 	GDFloat32TypeCode:    "float32",
 	GDFloat64TypeCode:    "float64",
 	GDFloatTypeCode:      "float",
@@ -96,12 +116,25 @@ var (
 	GDComplexType = GDType(GDComplexTypeCode)
 	GDStringType  = GDType(GDStringTypeCode)
 
+	// Sized integer types, they wrap around on overflow
+	GDInt8Type   = GDType(GDInt8TypeCode)
+	GDInt16Type  = GDType(GDInt16TypeCode)
+	GDInt32Type  = GDType(GDInt32TypeCode)
+	GDInt64Type  = GDType(GDInt64TypeCode)
+	GDUInt8Type  = GDType(GDUInt8TypeCode)
+	GDUInt16Type = GDType(GDUInt16TypeCode)
+	GDUInt32Type = GDType(GDUInt32TypeCode)
+	GDUInt64Type = GDType(GDUInt64TypeCode)
+	GDByteType   = GDUInt8Type
+
+	// Arbitrary-precision types
+	GDBigIntType  = GDType(GDBigIntTypeCode)
+	GDDecimalType = GDType(GDDecimalTypeCode)
+
 	// Internal Types
 	GDUntypedType = GDType(GDUntypedTypeCode)
 
 	// Sub-Types
-	GDInt8Type       = GDType(GDInt8TypeCode)
-	GDInt16Type      = GDType(GDInt16TypeCode)
 	GDFloat32Type    = GDType(GDFloat32TypeCode)
 	GDFloat64Type    = GDType(GDFloat64TypeCode)
 	GDComplex64Type  = GDType(GDComplex64TypeCode)
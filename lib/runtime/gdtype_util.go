@@ -83,6 +83,8 @@ func IsUntypedType(typ GDTypable) bool {
 	switch typ := typ.(type) {
 	case *GDArrayType:
 		return IsUntypedType(typ.SubType)
+	case *GDSetType:
+		return IsUntypedType(typ.SubType)
 	case GDTupleType:
 		for _, t := range typ {
 			if IsUntypedType(t) {
@@ -97,7 +99,7 @@ func IsUntypedType(typ GDTypable) bool {
 }
 
 func CanBeAssign(left, right GDTypable, stack *GDSymbolStack) error {
-	_, err := determineTypeCompatibility(left, right, true, stack)
+	_, err := determineTypeCompatibility(left, right, true, stack, nil)
 	if err != nil {
 		if err, isGDErr := err.(GDRuntimeErr); isGDErr {
 			switch err.Code {
@@ -113,7 +115,7 @@ func CanBeAssign(left, right GDTypable, stack *GDSymbolStack) error {
 }
 
 func EqualTypes(left, right GDTypable, stack *GDSymbolStack) error {
-	_, err := determineTypeCompatibility(left, right, false, stack)
+	_, err := determineTypeCompatibility(left, right, false, stack, nil)
 	if err != nil {
 		return TypesAreNotEqualErr(left, right)
 	}
@@ -129,7 +131,7 @@ func CheckType(gdType GDTypable, stack *GDSymbolStack) error {
 // Rules:
 // - Untyped is considered a weak unknown type, however, it can mutate to any other more strong type.
 func InferType(toType, fromType GDTypable, stack *GDSymbolStack) (GDTypable, error) {
-	typ, err := determineTypeCompatibility(toType, fromType, true, stack)
+	typ, err := determineTypeCompatibility(toType, fromType, true, stack, nil)
 	if err != nil {
 		if err, isGDErr := err.(GDRuntimeErr); isGDErr {
 			switch err.Code {
@@ -144,7 +146,34 @@ func InferType(toType, fromType GDTypable, stack *GDSymbolStack) (GDTypable, err
 	return typ, nil
 }
 
-func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded bool, stack *GDSymbolStack) (GDTypable, error) {
+// Pairs of types that are being compared, identified by their string representation
+type typeVisit struct {
+	toType, fromType   string
+	isAssignmentNeeded bool
+}
+
+type typeVisits map[typeVisit]bool
+
+func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded bool, stack *GDSymbolStack, visits typeVisits) (GDTypable, error) {
+	// Recursive types are compared coinductively, a pair of types that is already
+	// being compared is assumed to be compatible, otherwise the comparison never ends.
+	// The pair is only marked while it is compared, so a pair that failed under
+	// an alternative of a union is not taken as compatible under the next one.
+	_, isToTypeRef := toType.(GDIdent)
+	_, isFromTypeRef := fromType.(GDIdent)
+	if isToTypeRef || isFromTypeRef {
+		visit := typeVisit{toType.ToString(), fromType.ToString(), isAssignmentNeeded}
+		if visits[visit] {
+			return toType, nil
+		}
+
+		if visits == nil {
+			visits = make(typeVisits)
+		}
+		visits[visit] = true
+		defer delete(visits, visit)
+	}
+
 	fromType, err := UnwrapIdentType(fromType, stack)
 	if err != nil {
 		return nil, err
@@ -158,7 +187,7 @@ func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded b
 			return nil, err
 		}
 
-		_, err = determineTypeCompatibility(symbol.Type, fromType, isAssignmentNeeded, stack)
+		_, err = determineTypeCompatibility(symbol.Type, fromType, isAssignmentNeeded, stack, visits)
 		if err != nil {
 			return nil, err
 		}
@@ -166,28 +195,38 @@ func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded b
 		return toType, nil
 	case *GDArrayType:
 		if fromType, ok := fromType.(*GDArrayType); ok {
-			typ, err := determineTypeCompatibility(toType.SubType, fromType.SubType, isAssignmentNeeded, stack)
+			typ, err := determineTypeCompatibility(toType.SubType, fromType.SubType, isAssignmentNeeded, stack, visits)
 			if err != nil {
 				return nil, err
 			}
 
 			return NewGDArrayType(typ), nil
 		}
+	case *GDSetType:
+		if fromType, ok := fromType.(*GDSetType); ok {
+			typ, err := determineTypeCompatibility(toType.SubType, fromType.SubType, isAssignmentNeeded, stack, visits)
+			if err != nil {
+				return nil, err
+			}
+
+			return NewGDSetType(typ), nil
+		}
 	// Union types do not have untyped types
 	case GDUnionType:
 		if fromTypeUnion, isUnion := fromType.(GDUnionType); isUnion {
-			if len(toType) != len(fromTypeUnion) {
+			// A union can be assigned to a wider union
+			if !isAssignmentNeeded && len(toType) != len(fromTypeUnion) {
 				return nil, WrongTypesErr(toType, fromType)
 			}
 
 			for _, cType := range fromTypeUnion {
-				if !toType.ContainsType(cType, stack) {
+				if !toType.containsType(cType, isAssignmentNeeded, stack, visits) {
 					return nil, WrongTypesErr(toType, fromType)
 				}
 			}
 
 			return toType, nil
-		} else if toType.ContainsType(fromType, stack) {
+		} else if toType.containsType(fromType, isAssignmentNeeded, stack, visits) {
 			return toType, nil
 		}
 	case GDTupleType:
@@ -197,7 +236,7 @@ func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded b
 			}
 
 			for i, tType := range toType {
-				typ, err := determineTypeCompatibility(tType, fromTypeTuple[i], isAssignmentNeeded, stack)
+				typ, err := determineTypeCompatibility(tType, fromTypeTuple[i], isAssignmentNeeded, stack, visits)
 				if err != nil {
 					return nil, err
 				}
@@ -219,17 +258,19 @@ func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded b
 
 			structAttrTypes := make([]GDStructAttrType, len(toType))
 			for i, fromAttr := range fromType {
-				attrType, err := toType.GetAttrType(fromAttr.Ident)
+				toAttr, err := toType.GetAttr(fromAttr.Ident)
 				if err != nil {
 					return nil, WrongTypesErr(toType, fromType)
 				}
 
-				typ, err := determineTypeCompatibility(attrType, fromAttr.Type, isAssignmentNeeded, stack)
+				typ, err := determineTypeCompatibility(toAttr.Type, fromAttr.Type, isAssignmentNeeded, stack, visits)
 				if err != nil {
 					return nil, err
 				}
 
-				structAttrTypes[i] = GDStructAttrType{Ident: fromAttr.Ident, Type: typ}
+				// The modifiers of the attribute are kept
+				toAttr.Type = typ
+				structAttrTypes[i] = toAttr
 			}
 
 			return NewGDStructType(structAttrTypes...), nil
@@ -246,12 +287,12 @@ func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded b
 				return nil, WrongTypesErr(toType, fromType)
 			}
 
-			if err := EqualTypes(toType.ReturnType, fromType.ReturnType, stack); err != nil {
+			if _, err := determineTypeCompatibility(toType.ReturnType, fromType.ReturnType, false, stack, visits); err != nil {
 				return nil, WrongTypesErr(toType, fromType)
 			}
 
 			for i, argType := range toType.ArgTypes {
-				if err := EqualTypes(argType.Value, fromType.ArgTypes[i].Value, stack); err != nil {
+				if _, err := determineTypeCompatibility(argType.Value, fromType.ArgTypes[i].Value, false, stack, visits); err != nil {
 					return nil, WrongTypesErr(toType, fromType)
 				}
 			}
@@ -264,7 +305,7 @@ func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded b
 	case GDUnionType:
 		cTypes := make([]GDTypable, len(fromType))
 		for i, cType := range fromType {
-			typ, err := determineTypeCompatibility(toType, cType, isAssignmentNeeded, stack)
+			typ, err := determineTypeCompatibility(toType, cType, isAssignmentNeeded, stack, visits)
 			if err != nil {
 				return nil, err
 			}
@@ -296,6 +337,15 @@ func determineTypeCompatibility(toType, fromType GDTypable, isAssignmentNeeded b
 		}
 	}
 
+	// Nominal types are distinct, even when the underlying types are equal
+	if toType, isNominal := toType.(*GDNominalType); isNominal {
+		if fromType, isNominal := fromType.(*GDNominalType); isNominal && toType.IsSameType(fromType) {
+			return toType, nil
+		}
+
+		return nil, WrongTypesErr(toType, fromType)
+	}
+
 	// Are them equal?
 	if toType.GetCode() == fromType.GetCode() {
 		return toType, nil
@@ -145,6 +145,13 @@ func TestCanBeAssigned(t *testing.T) {
 		{runtime.NewGDArrayType(runtime.GDStringType), runtime.GDAnyType, nil, "expected `[string]` but got `any`"},
 		{runtime.NewGDArrayType(runtime.GDStringType), runtime.GDStringType, nil, "expected `[string]` but got `string`"},
 		{runtime.NewGDTupleType(runtime.GDStringType), runtime.GDNilType, nil, ""},
+		{userIdType, userIdType, nil, ""},
+		{userIdType, runtime.GDIntType, nil, "expected `UserId` but got `int`"},
+		{runtime.GDIntType, userIdType, nil, "expected `int` but got `UserId`"},
+		{userIdType, orderIdType, nil, "expected `UserId` but got `OrderId`"},
+		{runtime.GDAnyType, userIdType, nil, ""},
+		{runtime.NewGDUnionType(runtime.GDIntType, runtime.GDStringType, runtime.GDBoolType), runtime.NewGDUnionType(runtime.GDIntType, runtime.GDStringType), nil, ""},
+		{runtime.NewGDUnionType(runtime.GDIntType, runtime.GDStringType), runtime.NewGDUnionType(runtime.GDIntType, runtime.GDBoolType), nil, "expected `(int | string)` but got `(int | bool)`"},
 	}
 
 	TypeTests(t, tests, func(t *testing.T, test TypeTest) error {
@@ -183,8 +190,8 @@ func TestCanBeAppendedTo(t *testing.T) {
 func TestTypeInference(t *testing.T) {
 	stack := runtime.NewGDSymbolStack()
 
-	structType1 := runtime.NewGDStructType(runtime.GDStructAttrType{aParamIdent, runtime.GDIntType})
-	structType2 := runtime.NewGDStructType(runtime.GDStructAttrType{bParamIdent, runtime.GDIntType})
+	structType1 := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDIntType})
+	structType2 := runtime.NewGDStructType(runtime.GDStructAttrType{Ident: bParamIdent, Type: runtime.GDIntType})
 	func1 := runtime.NewGDLambdaType(runtime.GDLambdaArgTypes{}, runtime.GDAnyType, false)
 	func2 := runtime.NewGDLambdaType(runtime.GDLambdaArgTypes{}, runtime.GDStringType, false)
 	func3 := runtime.NewGDLambdaType(runtime.GDLambdaArgTypes{}, runtime.GDIntType, false)
@@ -287,7 +294,7 @@ func TestTypeInference(t *testing.T) {
 		// set a: struct = untyped
 		{structType1, runtime.GDUntypedType, structType1, ""},
 		// set a: {a: untyped} = {a: int}
-		{runtime.NewGDStructType(runtime.GDStructAttrType{runtime.NewGDStringIdent("a"), runtime.GDUntypedType}), runtime.NewGDStructType(runtime.GDStructAttrType{runtime.NewGDStringIdent("a"), runtime.GDIntType}), runtime.NewGDStructType(runtime.GDStructAttrType{runtime.NewGDStringIdent("a"), runtime.GDIntType}), ""},
+		{runtime.NewGDStructType(runtime.GDStructAttrType{Ident: runtime.NewGDStringIdent("a"), Type: runtime.GDUntypedType}), runtime.NewGDStructType(runtime.GDStructAttrType{Ident: runtime.NewGDStringIdent("a"), Type: runtime.GDIntType}), runtime.NewGDStructType(runtime.GDStructAttrType{Ident: runtime.NewGDStringIdent("a"), Type: runtime.GDIntType}), ""},
 		// set a: struct = {untyped}
 		{structType1, runtime.NewGDStructType(), structType1, ""},
 		// set a: struct{a: int} = struct{b: int}
@@ -360,3 +367,48 @@ func TypeTests(t *testing.T, tests []TypeTest, callback func(t *testing.T, test
 		}
 	}
 }
+
+// Recursive types
+
+func TestRecursiveTypes(t *testing.T) {
+	stack := runtime.NewGDSymbolStack()
+
+	// typealias A = {next: A}
+	// typealias C = {next: C}
+	// typealias D = {b: B, v: int}
+	// typealias B = {d: D}
+	for _, alias := range []struct {
+		ident string
+		typ   runtime.GDTypable
+	}{
+		{"A", runtime.NewGDStructType(runtime.GDStructAttrType{Ident: NewGDStringIdentType("next"), Type: runtime.NewStrRefType("A")})},
+		{"C", runtime.NewGDStructType(runtime.GDStructAttrType{Ident: NewGDStringIdentType("next"), Type: runtime.NewStrRefType("C")})},
+		{"D", runtime.NewGDStructType(runtime.GDStructAttrType{Ident: bParamIdent, Type: runtime.NewStrRefType("B")}, runtime.GDStructAttrType{Ident: NewGDStringIdentType("v"), Type: runtime.GDIntType})},
+		{"B", runtime.NewGDStructType(runtime.GDStructAttrType{Ident: NewGDStringIdentType("d"), Type: runtime.NewStrRefType("D")})},
+	} {
+		_, err := stack.AddSymbol(NewGDStringIdentType(alias.ident), true, true, alias.typ, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []TypeTest{
+		{runtime.NewStrRefType("A"), runtime.NewStrRefType("A"), nil, ""},
+		{runtime.NewStrRefType("A"), runtime.NewStrRefType("C"), nil, ""},
+		{runtime.NewStrRefType("D"), runtime.NewStrRefType("D"), nil, ""},
+		{runtime.NewStrRefType("A"), runtime.NewStrRefType("D"), nil, "expected `A` but got `D`"},
+	}
+
+	TypeTests(t, tests, func(t *testing.T, test TypeTest) error {
+		return runtime.CanBeAssign(test.toType, test.fromType, stack)
+	})
+
+	obj, err := runtime.ZObjectForType(runtime.NewGDTupleType(runtime.NewStrRefType("A"), runtime.NewStrRefType("A")), stack)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.ToString() != "({next: nil}, {next: nil})" {
+		t.Errorf("Expected zero value ({next: nil}, {next: nil}) but got %s", obj.ToString())
+	}
+}
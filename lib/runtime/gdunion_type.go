@@ -26,8 +26,13 @@ func (t GDUnionType) GetCode() GDTypableCode {
 }
 
 func (t GDUnionType) ContainsType(typ GDTypable, stack *GDSymbolStack) bool {
+	return t.containsType(typ, false, stack, nil)
+}
+
+// With isAssignmentNeeded, it checks if the type can be assigned to any of the union types
+func (t GDUnionType) containsType(typ GDTypable, isAssignmentNeeded bool, stack *GDSymbolStack, visits typeVisits) bool {
 	for _, existingTyp := range t {
-		if err := EqualTypes(existingTyp, typ, stack); err == nil {
+		if _, err := determineTypeCompatibility(existingTyp, typ, isAssignmentNeeded, stack, visits); err == nil {
 			return true
 		}
 	}
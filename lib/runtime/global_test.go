@@ -32,8 +32,12 @@ var (
 	attr1Ident  = NewGDStringIdentType("attr1")
 
 	// Structs
-	structWithAttrAAsInt    = runtime.NewGDStructType(runtime.GDStructAttrType{aParamIdent, runtime.GDIntType})
-	structWithAttrAAsString = runtime.NewGDStructType(runtime.GDStructAttrType{aParamIdent, runtime.GDStringType})
-	structWithAttrsAStrBInt = runtime.NewGDStructType(runtime.GDStructAttrType{aParamIdent, runtime.GDStringType}, runtime.GDStructAttrType{bParamIdent, runtime.GDIntType})
-	structWithAttrsBIntAStr = runtime.NewGDStructType(runtime.GDStructAttrType{bParamIdent, runtime.GDIntType}, runtime.GDStructAttrType{aParamIdent, runtime.GDStringType})
+	structWithAttrAAsInt    = runtime.NewGDStructType(runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDIntType})
+	structWithAttrAAsString = runtime.NewGDStructType(runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDStringType})
+	structWithAttrsAStrBInt = runtime.NewGDStructType(runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDStringType}, runtime.GDStructAttrType{Ident: bParamIdent, Type: runtime.GDIntType})
+	structWithAttrsBIntAStr = runtime.NewGDStructType(runtime.GDStructAttrType{Ident: bParamIdent, Type: runtime.GDIntType}, runtime.GDStructAttrType{Ident: aParamIdent, Type: runtime.GDStringType})
+
+	// Nominal types
+	userIdType  = runtime.NewGDNominalType(NewGDStringIdentType("UserId"), 1, runtime.GDIntType)
+	orderIdType = runtime.NewGDNominalType(NewGDStringIdentType("OrderId"), 2, runtime.GDIntType)
 )
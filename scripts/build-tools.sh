@@ -22,7 +22,7 @@ rm -rf $binary_path
 mkdir -p $binary_path
 
 # List of tools
-tools=("gdc" "gdcvm" "gdvm")
+tools=("gdc" "gdcvm" "gdvm" "gdlsp" "gdfmt" "gdrepl" "gdasm")
 
 for tool in "${tools[@]}"; do
     ./scripts/build-gd-tool.sh "$compiler" "$tool" "$build_mode" "$os" "$arch" "$version" "$build_number" $binary_path
@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"flag"
+	"gdlang/src/comn"
+	"gdlang/src/gd/asm"
+	"gdlang/src/gd/ir"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	version     = "dev"
+	buildNumber = "0"
+	arch        = "host"
+)
+
+var (
+	outputPath  = flag.String("o", "", "output path of the binary file, defaults to the `.gdbin` next to the assembly file")
+	showVersion = flag.Bool("version", false, "prints the GDLang assembler version")
+)
+
+// Assembles the listing printed by `gdvm -dis` back into a binary file
+func main() {
+	flag.Parse()
+
+	if *showVersion {
+		versionMsg := comn.NewMarkdown("GDLang assembler `version`: " + version + " `build`: " + buildNumber + " `arch`: " + arch)
+		println(versionMsg.Stylize())
+		os.Exit(0)
+	}
+
+	comn.PrettyPrintErrors = false
+
+	if flag.NArg() != 1 {
+		print("expected the path of the assembly file, e.g. `gdasm hello.gdasm`")
+		os.Exit(1)
+	}
+
+	path := flag.Arg(0)
+	src, err := os.ReadFile(path)
+	if err != nil {
+		print(err.Error())
+		os.Exit(1)
+	}
+
+	code, err := asm.Assemble(path, src)
+	if err != nil {
+		print(err.Error())
+		os.Exit(1)
+	}
+
+	if *outputPath == "" {
+		*outputPath = strings.TrimSuffix(path, filepath.Ext(path)) + ".gdbin"
+	}
+
+	bin := ir.NewGDBin(code, "gdasm "+version)
+	bin.Metadata["source"] = filepath.Base(path)
+
+	err = ir.WriteGDBin(*outputPath, bin)
+	if err != nil {
+		print(err.Error())
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
@@ -36,6 +36,7 @@ var (
 var (
 	pkgPath     = flag.String("pkg", "", "path to the main package")
 	outputPath  = flag.String("o", "", "output path for the compiled files")
+	embedMap    = flag.Bool("embed-map", false, "embeds the source map in the binary file")
 	showVersion = flag.Bool("version", false, "prints the GDLang Compiler version")
 )
 
@@ -49,6 +50,8 @@ func main() {
 	}
 
 	c := compiler.NewGDCompiler()
+	c.Version = version
+	c.EmbedSourceMap = *embedMap
 	defer c.Dispose()
 
 	err := c.Compile(*pkgPath)
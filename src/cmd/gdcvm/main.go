@@ -71,6 +71,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	vmProc.SrcMap = c.Ctx.SrcMap
+
 	err = vmProc.Run()
 	if err != nil {
 		print(err.Error())
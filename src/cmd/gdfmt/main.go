@@ -0,0 +1,191 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"gdlang/src/comn"
+	"gdlang/src/gd/format"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var (
+	version     = "dev"
+	buildNumber = "0"
+	arch        = "host"
+)
+
+var (
+	write       = flag.Bool("w", false, "writes the result to the source files instead of the standard output")
+	check       = flag.Bool("check", false, "lists the files that are not formatted and fails if there is any")
+	showVersion = flag.Bool("version", false, "prints the GDLang formatter version")
+)
+
+// Formats the given files and the `.gd` files of the given folders,
+// or the standard input when no path is given
+func main() {
+	flag.Parse()
+
+	if *showVersion {
+		versionMsg := comn.NewMarkdown("GDLang formatter `version`: " + version + " `build`: " + buildNumber + " `arch`: " + arch)
+		println(versionMsg.Stylize())
+		os.Exit(0)
+	}
+
+	comn.PrettyPrintErrors = false
+
+	if *write && *check {
+		print("the flags -w and -check can't be used together")
+		os.Exit(1)
+	}
+
+	if flag.NArg() == 0 {
+		err := formatStdin()
+		if err != nil {
+			print(err.Error())
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	isFormatted := true
+	for _, arg := range flag.Args() {
+		files, err := sourceFiles(arg)
+		if err != nil {
+			print(err.Error())
+			os.Exit(1)
+		}
+
+		for _, file := range files {
+			isFileFormatted, err := formatFile(file)
+			if err != nil {
+				print(err.Error())
+				os.Exit(1)
+			}
+
+			isFormatted = isFormatted && isFileFormatted
+		}
+	}
+
+	if *check && !isFormatted {
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func formatStdin() error {
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	res, err := format.Source("<stdin>", src)
+	if err != nil {
+		return err
+	}
+
+	if *check {
+		if !bytes.Equal(src, res) {
+			fmt.Println("<stdin>")
+			os.Exit(1)
+		}
+
+		return nil
+	}
+
+	_, err = os.Stdout.Write(res)
+	return err
+}
+
+// Formats the file, and reports whether it was already formatted
+func formatFile(path string) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := format.Source(path, src)
+	if err != nil {
+		return false, err
+	}
+
+	isFormatted := bytes.Equal(src, res)
+	switch {
+	case *check:
+		if !isFormatted {
+			fmt.Println(path)
+		}
+	case *write:
+		if !isFormatted {
+			info, err := os.Stat(path)
+			if err != nil {
+				return false, err
+			}
+
+			return false, os.WriteFile(path, res, info.Mode().Perm())
+		}
+	default:
+		_, err = os.Stdout.Write(res)
+	}
+
+	return isFormatted, err
+}
+
+// The path itself when it is a file, or the `.gd` files found in the folder and its subfolders
+func sourceFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() && filepath.Ext(path) == ".gd" {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no source files found in " + path)
+	}
+
+	return files, nil
+}
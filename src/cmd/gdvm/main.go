@@ -22,7 +22,11 @@ package main
 import (
 	"flag"
 	"gdlang/src/comn"
+	"gdlang/src/dap"
+	"gdlang/src/gd/ir"
 	"gdlang/src/vm"
+	"io"
+	"net"
 	"os"
 )
 
@@ -34,6 +38,10 @@ var (
 
 var (
 	gdbin       = flag.String("gdbin", "", "path to the GDLang binary file")
+	srcMap      = flag.String("map", "", "path to the source map file, defaults to the `.gdmap` next to the binary file")
+	debug       = flag.Bool("debug", false, "runs the program with the command-line debugger, it requires the source map")
+	disasm      = flag.Bool("dis", false, "prints the disassembly of the binary file instead of running it, with the source positions if the source map is found")
+	dapAddr     = flag.String("dap", "", "serves the Debug Adapter Protocol on an address, e.g. `:4711`, or on `stdio`")
 	showVersion = flag.Bool("version", false, "prints the GDLang VM version")
 )
 
@@ -46,19 +54,69 @@ func main() {
 		os.Exit(0)
 	}
 
-	bytes, err := os.ReadFile(*gdbin)
+	if *dapAddr != "" {
+		err := serveDAP(*dapAddr)
+		if err != nil {
+			print(err.Error())
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	bin, err := ir.ReadGDBin(*gdbin)
 	if err != nil {
 		print(err.Error())
 		os.Exit(1)
 	}
 
 	vmProc := vm.NewGDVMProc()
-	err = vmProc.Init(bytes)
+	err = vmProc.Init(bin.Code)
 	if err != nil {
 		print(err.Error())
 		os.Exit(1)
 	}
 
+	// The source map is optional, it is only used to print the stack traces,
+	// the one embedded in the binary file is used when the flag is not set
+	vmProc.SrcMap = bin.SrcMap
+
+	mapPath := *srcMap
+	if mapPath == "" && bin.SrcMap == nil {
+		mapPath = ir.GDSourceMapPathOf(*gdbin)
+		if _, err := os.Stat(mapPath); err != nil {
+			mapPath = ""
+		}
+	}
+
+	if mapPath != "" {
+		err = vmProc.LoadSourceMap(mapPath)
+		if err != nil {
+			print(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if *disasm {
+		listing, err := vm.NewGDVMDisassembler(bin.Code, vmProc.SrcMap).Disassemble()
+		if err != nil {
+			print(err.Error())
+			os.Exit(1)
+		}
+
+		os.Stdout.WriteString(listing)
+		os.Exit(0)
+	}
+
+	if *debug {
+		if vmProc.SrcMap == nil {
+			print("the debugger requires the source map of the binary file, use the `-map` flag to set it")
+			os.Exit(1)
+		}
+
+		vm.NewGDVMDebugger(vmProc, vmProc.SrcMap, os.Stdin, os.Stdout)
+	}
+
 	err = vmProc.Run()
 	if err != nil {
 		print(err.Error())
@@ -67,3 +125,42 @@ func main() {
 
 	os.Exit(0)
 }
+
+// Serves a single debug session, the program can be set
+// with the flags or by the launch request of the client
+func serveDAP(addr string) error {
+	var program *dap.GDDAPProgram
+	if *gdbin != "" {
+		var err error
+		program, err = dap.LoadProgram(*gdbin, *srcMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	var in io.Reader = os.Stdin
+	var out io.Writer = os.Stdout
+	if addr != "stdio" {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		defer listener.Close()
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+
+		in, out = conn, conn
+	}
+
+	session := dap.NewGDDAPSession(in, out, program)
+	err := session.RedirectOutput()
+	if err != nil {
+		return err
+	}
+
+	return session.Serve()
+}
@@ -53,6 +53,7 @@ const (
 	DuplicatedPublicObjectErrMsg         = "an object `%s` was already declared in the package `%s`"
 	MisplacedBreakErrMsg                 = "`break` statement is not allowed here, it can only be used inside a control flow statement"
 	NilAccessExceptionErrMsg             = "a `nil` was encountered while dereferencing an object"
+	PackageAliasAsValueErrMsg            = "package alias `%s` can only be used to access the public objects of the package"
 )
 
 const (
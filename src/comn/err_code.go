@@ -59,6 +59,6 @@ const (
 	PublicObjectNotFoundErrCode
 
 	// Use directive can only be used at the header of a file example:
-	// use io::read{*}
+	// use io {read}
 	UseOnlyAtHeaderErrCode
 )
@@ -43,6 +43,10 @@ type ForCallback func(endLabel runtime.GDIdent, stack ir.GDIRStackNode) error
 type GDCompiler struct {
 	Ctx  *ir.GDIRContext
 	Root *ir.GDIRBlock
+	// Version written in the header of the binary file
+	Version string
+	// Embeds the source map in the binary file
+	EmbedSourceMap bool
 	tools.GDIdentGen
 	GDCmpEvaluator
 	GDCompExpressionEvaluator
@@ -76,42 +80,48 @@ func (c *GDCompiler) Compile(mainPkg string) error {
 		}
 	}
 
-	ident := c.DeriveIdent(c.MainEntry)
-
-	mainObj := runtime.NewGDIdObject(ident, runtime.GDZNil)
-	irMain := ir.NewGDIRObject(mainObj, nil)
+	// All the globals are initialized at this point,
+	// the initializers of the packages are called before main
+	for _, initEntry := range c.InitEntries {
+		c.callEntry(initEntry)
+	}
 
-	inst, _ := ir.NewGDIRCall(irMain, ir.NewGDIRIterableObject(runtime.NewGDArrayType(runtime.GDAnyType), []ir.GDIRNode{}), nil)
-	c.Root.AddNode(inst)
+	c.callEntry(c.MainEntry)
 
 	return nil
 }
 
-func (c *GDCompiler) writeBytecode(outputFile string) error {
+// Calls a function without arguments from the root block
+func (c *GDCompiler) callEntry(entry ast.Node) {
+	ident := c.DeriveIdent(entry)
+
+	entryObj := runtime.NewGDIdObject(ident, runtime.GDZNil)
+	irEntry := ir.NewGDIRObject(entryObj, nil)
+
+	inst, _ := ir.NewGDIRCall(irEntry, ir.NewGDIRIterableObject(runtime.NewGDArrayType(runtime.GDAnyType), []ir.GDIRNode{}), nil)
+	c.Root.AddNode(inst)
+}
+
+// The bytecode is built once, since building it resolves the labels of the context
+func (c *GDCompiler) buildBytecode() ([]byte, error) {
 	buffer := &bytes.Buffer{}
 
 	err := c.Root.BuildBytecode(buffer, c.Ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return err
-	}
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			panic("error closing file: " + err.Error())
-		}
-	}(file)
+	return buffer.Bytes(), nil
+}
 
-	_, err = file.Write(buffer.Bytes())
-	if err != nil {
-		return err
+func (c *GDCompiler) writeBytecode(outputFile, pkgName string, code []byte) error {
+	bin := ir.NewGDBin(code, c.Version)
+	bin.Metadata["package"] = pkgName
+	if c.EmbedSourceMap {
+		bin.SrcMap = c.Ctx.SrcMap
 	}
 
-	return nil
+	return ir.WriteGDBin(outputFile, bin)
 }
 
 func (c *GDCompiler) writeSourceMap(outputFile string) error {
@@ -143,6 +153,17 @@ func (c *GDCompiler) EvalIdent(i *ast.NodeIdent, stack ir.GDIRStackNode) (ir.GDI
 }
 
 func (c *GDCompiler) EvalLambda(l *ast.NodeLambda, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
+	lambda, reg, err := c.evalLambda(l, stack)
+	if err != nil {
+		return nil, err
+	}
+
+	stack.AddNode(lambda)
+
+	return reg, nil
+}
+
+func (c *GDCompiler) evalLambda(l *ast.NodeLambda, stack ir.GDIRStackNode) (*ir.GDIRLambda, ir.GDIRNode, error) {
 	lambdaType, ok := c.DeriveType(l).(*runtime.GDLambdaType)
 	if !ok {
 		panic("A lambda type must be defined")
@@ -152,14 +173,12 @@ func (c *GDCompiler) EvalLambda(l *ast.NodeLambda, stack ir.GDIRStackNode) (ir.G
 
 	block, err := c.evalBlock(l.Block, lambda)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	lambda.GDIRBlock = block.(*ir.GDIRBlock)
 
-	stack.AddNode(lambda)
-
-	return reg, nil
+	return lambda, reg, nil
 }
 
 func (c *GDCompiler) EvalExprOp(e *ast.NodeExprOperation, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
@@ -176,6 +195,24 @@ func (c *GDCompiler) EvalExprOp(e *ast.NodeExprOperation, stack ir.GDIRStackNode
 		}
 	}
 
+	// The operation is overloaded by an operator function,
+	// so it is called with the operands as arguments
+	if e.InferredIdent() != nil {
+		args := []ir.GDIRNode{l}
+		if r != nil {
+			args = append(args, r)
+		}
+
+		ident := c.DeriveIdent(e)
+		operatorFunc := ir.NewGDIRObject(runtime.NewGDIdObject(ident, runtime.GDZNil), e)
+		argsNode := ir.NewGDIRIterableObject(runtime.NewGDArrayType(runtime.GDAnyType), args)
+
+		inst, reg := ir.NewGDIRCall(operatorFunc, argsNode, e)
+		stack.AddNode(inst)
+
+		return reg, nil
+	}
+
 	inst, reg := ir.NewGDIROp(e.Op, l, r, e)
 
 	stack.AddNode(inst)
@@ -195,16 +232,19 @@ func (c *GDCompiler) EvalExpEllipsis(e *ast.NodeEllipsisExpr, stack ir.GDIRStack
 }
 
 func (c *GDCompiler) EvalFunc(f *ast.NodeFunc, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
-	lambda, err := c.EvalLambda(f.NodeLambda, stack)
+	lambda, reg, err := c.evalLambda(f.NodeLambda, stack)
 	if err != nil {
 		return nil, err
 	}
 
+	lambda.SetFunc(f)
+	stack.AddNode(lambda)
+
 	ident := c.DeriveIdent(f)
 	typ := c.DeriveType(f)
 
 	disc := ir.NewGDIRDiscoverable(f.IsPub, true, ident, f)
-	irFunc := ir.NewGDIRSet(disc, typ, lambda, f)
+	irFunc := ir.NewGDIRSet(disc, typ, reg, f)
 
 	stack.AddNode(irFunc)
 
@@ -223,6 +263,10 @@ func (c *GDCompiler) EvalArray(a *ast.NodeArray, stack ir.GDIRStackNode) (ir.GDI
 	return c.collectNodes(a.InferredType(), a.Nodes, stack)
 }
 
+func (c *GDCompiler) EvalSetLiteral(s *ast.NodeSetLiteral, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
+	return c.collectNodes(s.InferredType(), s.Nodes, stack)
+}
+
 func (c *GDCompiler) EvalReturn(r *ast.NodeReturn, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
 	if r.Expr == nil {
 		inst, reg := ir.NewGDIRRet(ir.NewGDIRObject(runtime.GDZNil, r), r)
@@ -284,6 +328,11 @@ func (c *GDCompiler) EvalCallExpr(call *ast.NodeCallExpr, stack ir.GDIRStackNode
 }
 
 func (c *GDCompiler) EvalSafeDotExpr(s *ast.NodeSafeDotExpr, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
+	// Objects of a package alias are accessed by their own idents
+	if s.InferredPackageMember {
+		return ir.NewGDIRIdentObject(c.DeriveIdent(s), s.InferredObject(), s), nil
+	}
+
 	expr, err := c.EvalNode(s.Expr, stack)
 	if err != nil {
 		return nil, err
@@ -554,6 +603,23 @@ func (c *GDCompiler) EvalForIf(f *ast.NodeForIf, stack ir.GDIRStackNode) (ir.GDI
 	return c.evalFor(f, stack, nil, nil, nil)
 }
 
+func (c *GDCompiler) EvalComprehension(comp *ast.NodeComprehension, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
+	ident := c.DeriveIdent(comp)
+	typ := c.DeriveType(comp)
+
+	// A fresh collection is set as the accumulator of the loop
+	disc := ir.NewGDIRDiscoverable(false, false, ident, comp)
+	collection := ir.NewGDIRIterableObject(typ, []ir.GDIRNode{})
+	stack.AddNode(ir.NewGDIRSet(disc, typ, collection, comp))
+
+	_, err := c.EvalForIn(comp.ForIn, stack)
+	if err != nil {
+		return nil, err
+	}
+
+	return ir.NewGDIRIdentObject(ident, comp.InferredObject(), comp), nil
+}
+
 func (c *GDCompiler) EvalCollectableOp(collectable *ast.NodeMutCollectionOp, stack ir.GDIRStackNode) (ir.GDIRNode, error) {
 	exprLObj, err := c.EvalNode(collectable.L, stack)
 	if err != nil {
@@ -589,7 +655,8 @@ func (c *GDCompiler) EvalCastExpr(cast *ast.NodeCastExpr, stack ir.GDIRStackNode
 	switch obj := exprObj.(type) {
 	case *ir.GDIRObject:
 		switch obj.Obj.(type) {
-		case *runtime.GDIdObject:
+		// Collections are built at runtime, only the objects known by the static check are folded
+		case *runtime.GDIdObject, []ir.GDIRNode, ir.GDIRNode:
 			inst, reg := ir.NewGDIRCastObject(cast.Type, exprObj, cast)
 			stack.AddNode(inst)
 
@@ -617,7 +684,12 @@ func (c *GDCompiler) EvalPackage(p *ast.NodePackage, stack ir.GDIRStackNode) (ir
 			importNodes[i] = runtime.NewGDStringIdent(identNode.Lit)
 		}
 
-		irUse := ir.NewGDIRUse(p.InferredMode, ident, importNodes)
+		var alias runtime.GDIdent
+		if p.Alias != nil {
+			alias = runtime.NewGDStringIdent(p.Alias.Lit)
+		}
+
+		irUse := ir.NewGDIRUse(p.InferredMode, ident, importNodes, alias)
 		stack.AddNode(irUse)
 
 		return irUse, nil
@@ -742,7 +814,7 @@ func (c *GDCompiler) collectNodes(typ runtime.GDTypable, nodes []ast.Node, stack
 }
 
 func NewGDCompiler() *GDCompiler {
-	byteCode := &GDCompiler{Ctx: ir.NewGDIRContext(), Root: ir.NewGDIRBlock(), PackageDependenciesAnalyzer: analysis.NewPackageDependenciesAnalyzer(), GDIdentGen: tools.NewGDIdentStringGen()}
+	byteCode := &GDCompiler{Ctx: ir.NewGDIRContext(), Version: "dev", Root: ir.NewGDIRBlock(), PackageDependenciesAnalyzer: analysis.NewPackageDependenciesAnalyzer(), GDIdentGen: tools.NewGDIdentStringGen()}
 	byteCode.GDCompExpressionEvaluator = GDCompExpressionEvaluator{Evaluator: byteCode}
 
 	return byteCode
@@ -23,6 +23,7 @@
 package compiler
 
 import (
+	"gdlang/src/vm"
 	"os"
 	"path"
 )
@@ -30,16 +31,21 @@ import (
 func (c *GDCompiler) GenerateArtifacts(pkgName, outputPath string) error {
 	// IR, Bytecode and SourceMap writing process
 
-	// IR assembly
+	code, err := c.buildBytecode()
+	if err != nil {
+		return err
+	}
+
+	// Assembly, the listing of the bytecode that `gdasm` assembles back
 	asmPath := path.Join(outputPath, pkgName+".gdasm")
-	err := c.writeAsm(asmPath)
+	err = c.writeAsm(asmPath, code)
 	if err != nil {
 		return err
 	}
 
 	// Bytecode
 	bytecodePath := path.Join(outputPath, pkgName+".gdbin")
-	err = c.writeBytecode(bytecodePath)
+	err = c.writeBytecode(bytecodePath, pkgName, code)
 	if err != nil {
 		return err
 	}
@@ -54,14 +60,19 @@ func (c *GDCompiler) GenerateArtifacts(pkgName, outputPath string) error {
 	return nil
 }
 
-func (c *GDCompiler) writeAsm(outputFile string) error {
+func (c *GDCompiler) writeAsm(outputFile string, code []byte) error {
+	listing, err := vm.NewGDVMDisassembler(code, c.Ctx.SrcMap).Disassemble()
+	if err != nil {
+		return err
+	}
+
 	file, err := os.Create(outputFile)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	_, err = file.Write([]byte(c.Root.BuildAssembly("")))
+	_, err = file.Write([]byte(listing))
 	if err != nil {
 		return err
 	}
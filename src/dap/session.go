@@ -0,0 +1,485 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/ir"
+	"gdlang/src/vm"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// The VM runs a single thread
+const mainThreadId = 1
+
+// Program run by a debug session
+type GDDAPProgram struct {
+	Bytecode []byte
+	SrcMap   *ir.GDSourceMap
+}
+
+// Loads a binary file and its source map, which defaults to the
+// one embedded in the binary, or to the `.gdmap` file next to it
+func LoadProgram(gdbinPath, mapPath string) (*GDDAPProgram, error) {
+	bin, err := ir.ReadGDBin(gdbinPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if mapPath == "" && bin.SrcMap != nil {
+		return &GDDAPProgram{bin.Code, bin.SrcMap}, nil
+	}
+
+	if mapPath == "" {
+		mapPath = ir.GDSourceMapPathOf(gdbinPath)
+	}
+
+	srcMap, err := ir.ReadGDSourceMap(mapPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GDDAPProgram{bin.Code, srcMap}, nil
+}
+
+// Resumes a stopped program with a step, or quits it
+type resumeAction struct {
+	mode vm.GDVMStepMode
+	quit bool
+}
+
+// Debug session of a client, the program runs in its own goroutine
+// and it is blocked by the debug hook while it is stopped
+type GDDAPSession struct {
+	in  *bufio.Reader
+	out io.Writer
+	// Messages are written by the session and by the program
+	writeMu sync.Mutex
+	seq     int
+
+	// Output of the process while the output of the program is redirected
+	stdout     *os.File
+	outputDone chan struct{}
+
+	program *GDDAPProgram
+	proc    *vm.GDVMProc
+	stepper *vm.GDVMStepper
+	// Lines of the breakpoints by source path
+	breakpoints map[string][]int
+
+	resume  chan resumeAction
+	done    chan struct{}
+	started bool
+	quit    atomic.Bool
+	stateMu sync.Mutex
+	stopped bool
+	// Frames and references of the variables, valid while the program is stopped
+	frames    []vm.GDVMFrameState
+	variables *variableRefs
+}
+
+// Handles the requests until the client disconnects
+func (s *GDDAPSession) Serve() error {
+	for {
+		content, err := ReadMessage(s.in)
+		if errors.Is(err, io.EOF) {
+			s.stop()
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var req Request
+		err = json.Unmarshal(content, &req)
+		if err != nil {
+			return err
+		}
+
+		body, err := s.handle(&req)
+		if err != nil {
+			s.respond(&req, false, err.Error(), nil)
+			continue
+		}
+
+		s.respond(&req, true, "", body)
+
+		// Some requests are followed by events or end the session
+		switch req.Command {
+		case "launch":
+			s.sendEvent("initialized", nil)
+		case "disconnect":
+			return nil
+		}
+	}
+}
+
+func (s *GDDAPSession) handle(req *Request) (any, error) {
+	switch req.Command {
+	case "initialize":
+		return Capabilities{SupportsConfigurationDoneRequest: true}, nil
+	case "launch":
+		var args LaunchArguments
+		err := s.decode(req, &args)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, s.launch(args)
+	case "setBreakpoints":
+		var args SetBreakpointsArguments
+		err := s.decode(req, &args)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.setBreakpoints(args)
+	case "configurationDone":
+		if s.proc == nil {
+			return nil, errors.New("the program was not launched")
+		}
+
+		s.started = true
+		go s.run()
+
+		return nil, nil
+	case "threads":
+		return map[string]any{"threads": []Thread{{mainThreadId, "main"}}}, nil
+	case "stackTrace":
+		return s.stackTrace()
+	case "scopes":
+		var args ScopesArguments
+		err := s.decode(req, &args)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.scopes(args)
+	case "variables":
+		var args VariablesArguments
+		err := s.decode(req, &args)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.variablesOf(args)
+	case "continue":
+		return map[string]any{"allThreadsContinued": true}, s.resumeWith(vm.StepContinue)
+	case "next":
+		return nil, s.resumeWith(vm.StepOver)
+	case "stepIn":
+		return nil, s.resumeWith(vm.StepIn)
+	case "stepOut":
+		return nil, s.resumeWith(vm.StepOut)
+	case "pause":
+		if s.stepper != nil {
+			s.stepper.Pause()
+		}
+
+		return nil, nil
+	case "disconnect", "terminate":
+		s.stop()
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unsupported request `%s`", req.Command)
+}
+
+func (s *GDDAPSession) decode(req *Request, args any) error {
+	if len(req.Arguments) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(req.Arguments, args)
+}
+
+func (s *GDDAPSession) launch(args LaunchArguments) error {
+	if args.Program != "" {
+		program, err := LoadProgram(args.Program, args.Map)
+		if err != nil {
+			return err
+		}
+
+		s.program = program
+	}
+
+	if s.program == nil {
+		return errors.New("the `program` to debug is missing")
+	}
+
+	s.proc = vm.NewGDVMProc()
+	err := s.proc.Init(s.program.Bytecode)
+	if err != nil {
+		return err
+	}
+
+	s.proc.SrcMap = s.program.SrcMap
+	s.proc.Debugger = s
+	s.stepper = vm.NewGDVMStepper(s.proc, s.program.SrcMap)
+	if !args.StopOnEntry {
+		s.stepper.Step(vm.StepContinue)
+	}
+
+	return nil
+}
+
+func (s *GDDAPSession) setBreakpoints(args SetBreakpointsArguments) (any, error) {
+	if s.stepper == nil {
+		return nil, errors.New("the program was not launched")
+	}
+
+	// The breakpoints of the source are replaced
+	path := args.Source.Path
+	for _, line := range s.breakpoints[path] {
+		s.stepper.ClearBreakpoint(path, line)
+	}
+
+	lines := make([]int, 0, len(args.Breakpoints))
+	breakpoints := make([]Breakpoint, len(args.Breakpoints))
+	for i, bp := range args.Breakpoints {
+		breakpoints[i] = Breakpoint{Verified: s.stepper.AddBreakpoint(path, bp.Line), Line: bp.Line}
+		if breakpoints[i].Verified {
+			lines = append(lines, bp.Line)
+		} else {
+			breakpoints[i].Message = "no code at this line"
+		}
+	}
+	s.breakpoints[path] = lines
+
+	return map[string]any{"breakpoints": breakpoints}, nil
+}
+
+// Runs the program until it ends or the client disconnects
+func (s *GDDAPSession) run() {
+	defer close(s.done)
+
+	exitCode := 0
+	err := s.proc.Run()
+	s.restoreOutput()
+	if err != nil {
+		exitCode = 1
+		s.sendEvent("output", map[string]any{"category": "stderr", "output": err.Error()})
+	}
+
+	s.sendEvent("exited", map[string]any{"exitCode": exitCode})
+	s.sendEvent("terminated", nil)
+}
+
+// Debug hook of the program, it blocks the program while it is stopped
+func (s *GDDAPSession) OnInst(_ *runtime.GDSymbolStack) error {
+	if s.quit.Load() {
+		return vm.DebuggerQuitErr
+	}
+
+	_, reason, stop := s.stepper.Next()
+	if !stop {
+		return nil
+	}
+
+	s.stateMu.Lock()
+	s.stopped = true
+	s.frames = s.proc.FrameStates()
+	s.variables = newVariableRefs(s.stepper)
+	s.stateMu.Unlock()
+
+	s.sendEvent("stopped", map[string]any{"reason": reason, "threadId": mainThreadId, "allThreadsStopped": true})
+
+	action := <-s.resume
+	if action.quit {
+		return vm.DebuggerQuitErr
+	}
+
+	s.stepper.Step(action.mode)
+
+	return nil
+}
+
+func (s *GDDAPSession) resumeWith(mode vm.GDVMStepMode) error {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	if !s.stopped {
+		return errors.New("the program is not stopped")
+	}
+
+	s.stopped = false
+	s.resume <- resumeAction{mode: mode}
+
+	return nil
+}
+
+// Quits the program and waits until it ends
+func (s *GDDAPSession) stop() {
+	if s.proc == nil || s.quit.Swap(true) {
+		return
+	}
+
+	s.stateMu.Lock()
+	if s.stopped {
+		s.stopped = false
+		s.resume <- resumeAction{quit: true}
+	}
+	s.stateMu.Unlock()
+
+	if s.started {
+		<-s.done
+	}
+}
+
+// Frames of the stopped program, while the program is running they are not available
+func (s *GDDAPSession) stoppedFrames() ([]vm.GDVMFrameState, *variableRefs, error) {
+	s.stateMu.Lock()
+	defer s.stateMu.Unlock()
+
+	if !s.stopped {
+		return nil, nil, errors.New("the program is not stopped")
+	}
+
+	return s.frames, s.variables, nil
+}
+
+func (s *GDDAPSession) stackTrace() (any, error) {
+	frames, _, err := s.stoppedFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	stackFrames := make([]StackFrame, len(frames))
+	for i, frame := range frames {
+		stackFrames[i] = StackFrame{Id: i, Name: frame.Name}
+		if pos, ok := s.program.SrcMap.Lookup(int(frame.Off)); ok {
+			stackFrames[i].Source = &Source{Name: filepath.Base(pos.File), Path: pos.File}
+			stackFrames[i].Line, stackFrames[i].Column = pos.Line, pos.Col
+		}
+	}
+
+	return map[string]any{"stackFrames": stackFrames, "totalFrames": len(stackFrames)}, nil
+}
+
+func (s *GDDAPSession) scopes(args ScopesArguments) (any, error) {
+	frames, refs, err := s.stoppedFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	if args.FrameId < 0 || args.FrameId >= len(frames) {
+		return nil, fmt.Errorf("invalid frame %d", args.FrameId)
+	}
+
+	frame := frames[args.FrameId]
+
+	return map[string]any{"scopes": []Scope{
+		{Name: "Locals", VariablesReference: refs.addLocals(frame)},
+		{Name: "Globals", VariablesReference: refs.addGlobals(frame)},
+	}}, nil
+}
+
+func (s *GDDAPSession) variablesOf(args VariablesArguments) (any, error) {
+	_, refs, err := s.stoppedFrames()
+	if err != nil {
+		return nil, err
+	}
+
+	variables, ok := refs.get(args.VariablesReference)
+	if !ok {
+		return nil, fmt.Errorf("invalid variables reference %d", args.VariablesReference)
+	}
+
+	return map[string]any{"variables": variables}, nil
+}
+
+// Redirects the output of the program to output events,
+// so it doesn't mix with the messages on stdio
+func (s *GDDAPSession) RedirectOutput() error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+
+	s.stdout, s.outputDone = os.Stdout, make(chan struct{})
+	os.Stdout = w
+
+	go func() {
+		defer close(s.outputDone)
+
+		buffer := make([]byte, 4096)
+		for {
+			n, err := r.Read(buffer)
+			if n > 0 {
+				s.sendEvent("output", map[string]any{"category": "stdout", "output": string(buffer[:n])})
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Restores the output and waits until the output of the program is sent
+func (s *GDDAPSession) restoreOutput() {
+	if s.outputDone == nil {
+		return
+	}
+
+	w := os.Stdout
+	os.Stdout = s.stdout
+	_ = w.Close()
+	<-s.outputDone
+}
+
+func (s *GDDAPSession) respond(req *Request, success bool, message string, body any) {
+	s.write(func(seq int) any {
+		return Response{seq, "response", req.Seq, success, req.Command, message, body}
+	})
+}
+
+func (s *GDDAPSession) sendEvent(event string, body any) {
+	s.write(func(seq int) any { return Event{seq, "event", event, body} })
+}
+
+func (s *GDDAPSession) write(msg func(seq int) any) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.seq++
+	_ = WriteMessage(s.out, msg(s.seq))
+}
+
+// Creates a session with the client, the program can also be set by the launch request
+func NewGDDAPSession(in io.Reader, out io.Writer, program *GDDAPProgram) *GDDAPSession {
+	return &GDDAPSession{
+		in:          bufio.NewReader(in),
+		out:         out,
+		program:     program,
+		breakpoints: make(map[string][]int),
+		resume:      make(chan resumeAction),
+		done:        make(chan struct{}),
+	}
+}
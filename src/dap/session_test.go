@@ -0,0 +1,351 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dap_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"gdlang/src/compiler"
+	"gdlang/src/dap"
+	"gdlang/src/test_helper"
+	"io"
+	"testing"
+)
+
+const debugSrc = `typealias Point = {x: int, y: int}
+
+func norm(p: Point) => int {
+	set n = p.x * p.x + p.y * p.y
+	return n
+}
+
+pub func main() {
+	set p: Point = {x: 3, y: 4}
+	set xs = [1, 2]
+	print(norm(p), xs)
+}`
+
+type message struct {
+	Type       string          `json:"type"`
+	Event      string          `json:"event"`
+	Command    string          `json:"command"`
+	RequestSeq int             `json:"request_seq"`
+	Success    bool            `json:"success"`
+	Message    string          `json:"message"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// Scripted client of a session
+type testClient struct {
+	t       *testing.T
+	session *dap.GDDAPSession
+	in      *bufio.Reader
+	out     io.Writer
+	seq     int
+	events  []message
+}
+
+func (c *testClient) read() message {
+	content, err := dap.ReadMessage(c.in)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	var msg message
+	err = json.Unmarshal(content, &msg)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	return msg
+}
+
+// Sends a request and waits for its response, the events
+// received in the meantime are kept for later
+func (c *testClient) request(command string, args any, body any) message {
+	c.seq++
+	err := dap.WriteMessage(c.out, map[string]any{"seq": c.seq, "type": "request", "command": command, "arguments": args})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	for {
+		msg := c.read()
+		if msg.Type == "event" {
+			c.events = append(c.events, msg)
+			continue
+		}
+
+		if msg.RequestSeq != c.seq || msg.Command != command {
+			c.t.Fatalf("Expected the response of %s, got %+v", command, msg)
+		}
+
+		if body != nil && msg.Success {
+			err = json.Unmarshal(msg.Body, body)
+			if err != nil {
+				c.t.Fatal(err)
+			}
+		}
+
+		return msg
+	}
+}
+
+func (c *testClient) waitEvent(event string) message {
+	for i, msg := range c.events {
+		if msg.Event == event {
+			c.events = append(c.events[:i], c.events[i+1:]...)
+			return msg
+		}
+	}
+
+	for {
+		msg := c.read()
+		if msg.Type == "event" && msg.Event == event {
+			return msg
+		}
+
+		c.events = append(c.events, msg)
+	}
+}
+
+// Compiles the source and serves a session of the program to the client
+func runSession(t *testing.T, src string, script func(c *testClient, mainPath string)) {
+	test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+		comp := compiler.NewGDCompiler()
+		defer comp.Dispose()
+
+		err := comp.Compile(tmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bytecode := &bytes.Buffer{}
+		err = comp.Root.BuildBytecode(bytecode, comp.Ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		clientIn, serverOut := io.Pipe()
+		serverIn, clientOut := io.Pipe()
+
+		session := dap.NewGDDAPSession(serverIn, serverOut, &dap.GDDAPProgram{Bytecode: bytecode.Bytes(), SrcMap: comp.Ctx.SrcMap})
+		served := make(chan error)
+		go func() { served <- session.Serve() }()
+
+		script(&testClient{t: t, session: session, in: bufio.NewReader(clientIn), out: clientOut}, tmpDir+"/main.gd")
+
+		_ = clientOut.Close()
+		if err := <-served; err != nil {
+			t.Error(err)
+		}
+
+		return nil
+	})
+}
+
+type stackTraceBody struct {
+	StackFrames []dap.StackFrame `json:"stackFrames"`
+}
+
+type scopesBody struct {
+	Scopes []dap.Scope `json:"scopes"`
+}
+
+type variablesBody struct {
+	Variables []dap.Variable `json:"variables"`
+}
+
+type breakpointsBody struct {
+	Breakpoints []dap.Breakpoint `json:"breakpoints"`
+}
+
+func (c *testClient) variables(ref int) map[string]dap.Variable {
+	var body variablesBody
+	resp := c.request("variables", map[string]any{"variablesReference": ref}, &body)
+	if !resp.Success {
+		c.t.Fatalf("Expected the variables of %d, got %s", ref, resp.Message)
+	}
+
+	variables := make(map[string]dap.Variable)
+	for _, variable := range body.Variables {
+		variables[variable.Name] = variable
+	}
+
+	return variables
+}
+
+func TestSessionBreakpointAndVariables(t *testing.T) {
+	runSession(t, debugSrc, func(c *testClient, mainPath string) {
+		resp := c.request("initialize", map[string]any{"adapterID": "gdlang"}, nil)
+		if !resp.Success {
+			t.Fatalf("Expected initialize to succeed, got %s", resp.Message)
+		}
+
+		c.request("launch", map[string]any{}, nil)
+		c.waitEvent("initialized")
+
+		var bps breakpointsBody
+		c.request("setBreakpoints", map[string]any{
+			"source":      map[string]any{"path": mainPath},
+			"breakpoints": []map[string]any{{"line": 5}, {"line": 2}},
+		}, &bps)
+		if len(bps.Breakpoints) != 2 || !bps.Breakpoints[0].Verified || bps.Breakpoints[1].Verified {
+			t.Errorf("Expected only the breakpoint of the line 5 to be verified, got %+v", bps.Breakpoints)
+		}
+
+		err := c.session.RedirectOutput()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.request("configurationDone", nil, nil)
+
+		stopped := c.waitEvent("stopped")
+		if !bytes.Contains(stopped.Body, []byte(`"reason":"breakpoint"`)) {
+			t.Errorf("Expected to stop on a breakpoint, got %s", stopped.Body)
+		}
+
+		var trace stackTraceBody
+		c.request("stackTrace", map[string]any{"threadId": 1}, &trace)
+		frames := trace.StackFrames
+		if len(frames) != 2 || frames[0].Name != "norm" || frames[0].Line != 5 || frames[1].Name != "main" || frames[1].Line != 11 {
+			t.Fatalf("Expected the frames norm:5 and main:11, got %+v", frames)
+		}
+
+		if frames[0].Source == nil || frames[0].Source.Path != mainPath {
+			t.Errorf("Expected the source %s, got %+v", mainPath, frames[0].Source)
+		}
+
+		// Locals of the function
+		var scopes scopesBody
+		c.request("scopes", map[string]any{"frameId": 0}, &scopes)
+		locals := c.variables(scopes.Scopes[0].VariablesReference)
+		if locals["n"].Value != "25" || locals["n"].Type != "int" {
+			t.Errorf("Expected n = 25, got %+v", locals["n"])
+		}
+
+		// Attributes of the struct argument
+		attrs := c.variables(locals["p"].VariablesReference)
+		if attrs["x"].Value != "3" || attrs["y"].Value != "4" {
+			t.Errorf("Expected the attributes x = 3 and y = 4, got %+v", attrs)
+		}
+
+		// Locals of the caller and the elements of an array
+		c.request("scopes", map[string]any{"frameId": 1}, &scopes)
+		locals = c.variables(scopes.Scopes[0].VariablesReference)
+		elements := c.variables(locals["xs"].VariablesReference)
+		if len(elements) != 2 || elements["[1]"].Value != "2" {
+			t.Errorf("Expected the elements of xs, got %+v", elements)
+		}
+
+		globals := c.variables(scopes.Scopes[1].VariablesReference)
+		if _, ok := globals["norm"]; !ok {
+			t.Errorf("Expected the global norm, got %+v", globals)
+		}
+
+		if _, ok := globals["print"]; ok {
+			t.Errorf("Expected the builtins to be hidden, got %+v", globals)
+		}
+
+		c.request("continue", map[string]any{"threadId": 1}, nil)
+
+		// The output of the program is sent before it exits
+		output := c.waitEvent("output")
+		if !bytes.Contains(output.Body, []byte(`"output":"25[1, 2]"`)) {
+			t.Errorf("Expected the output of the program, got %s", output.Body)
+		}
+
+		exited := c.waitEvent("exited")
+		if !bytes.Contains(exited.Body, []byte(`"exitCode":0`)) {
+			t.Errorf("Expected the exit code 0, got %s", exited.Body)
+		}
+		c.waitEvent("terminated")
+
+		c.request("disconnect", nil, nil)
+	})
+}
+
+func TestSessionStepping(t *testing.T) {
+	runSession(t, debugSrc, func(c *testClient, mainPath string) {
+		c.request("initialize", nil, nil)
+		c.request("launch", map[string]any{"stopOnEntry": true}, nil)
+		c.waitEvent("initialized")
+		c.request("configurationDone", nil, nil)
+
+		expectStop := func(reason, name string, line int) {
+			stopped := c.waitEvent("stopped")
+			if !bytes.Contains(stopped.Body, []byte(`"reason":"`+reason+`"`)) {
+				t.Errorf("Expected to stop on %s, got %s", reason, stopped.Body)
+			}
+
+			var trace stackTraceBody
+			c.request("stackTrace", map[string]any{"threadId": 1}, &trace)
+			frame := trace.StackFrames[0]
+			if frame.Name != name || frame.Line != line {
+				t.Errorf("Expected to stop at %s:%d, got %s:%d", name, line, frame.Name, frame.Line)
+			}
+		}
+
+		expectStop("entry", "global", 1)
+
+		c.request("setBreakpoints", map[string]any{
+			"source":      map[string]any{"path": mainPath},
+			"breakpoints": []map[string]any{{"line": 9}},
+		}, nil)
+		c.request("continue", nil, nil)
+		expectStop("breakpoint", "main", 9)
+
+		c.request("next", nil, nil)
+		expectStop("step", "main", 10)
+
+		c.request("next", nil, nil)
+		expectStop("step", "main", 11)
+
+		c.request("stepIn", nil, nil)
+		expectStop("step", "norm", 4)
+
+		c.request("stepOut", nil, nil)
+		expectStop("step", "main", 11)
+
+		// The session quits the stopped program
+		c.request("disconnect", nil, nil)
+	})
+}
+
+func TestSessionErrors(t *testing.T) {
+	runSession(t, debugSrc, func(c *testClient, mainPath string) {
+		resp := c.request("stackTrace", nil, nil)
+		if resp.Success || resp.Message != "the program is not stopped" {
+			t.Errorf("Expected an error for a stack trace without a program, got %+v", resp)
+		}
+
+		resp = c.request("evaluate", nil, nil)
+		if resp.Success || resp.Message != "unsupported request `evaluate`" {
+			t.Errorf("Expected an unsupported request, got %+v", resp)
+		}
+
+		resp = c.request("setBreakpoints", nil, nil)
+		if resp.Success || resp.Message != "the program was not launched" {
+			t.Errorf("Expected an error for breakpoints without a program, got %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package dap
+
+import (
+	"gdlang/lib/runtime"
+	"gdlang/src/vm"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Variables of the scopes and of the nested objects by reference, the
+// references are only valid until the program is resumed. The
+// variables are listed when the client requests them.
+type variableRefs struct {
+	stepper *vm.GDVMStepper
+	refs    []func() []Variable
+}
+
+// References start at 1, since 0 means that the variable has no children
+func (r *variableRefs) add(variables func() []Variable) int {
+	r.refs = append(r.refs, variables)
+	return len(r.refs)
+}
+
+func (r *variableRefs) get(ref int) ([]Variable, bool) {
+	if ref < 1 || ref > len(r.refs) {
+		return nil, false
+	}
+
+	return r.refs[ref-1](), true
+}
+
+// Objects of the stack chain of the frame, without the globals
+func (r *variableRefs) addLocals(frame vm.GDVMFrameState) int {
+	return r.add(func() []Variable {
+		stacks, globals := stackChain(frame.Stack)
+		return r.symbolVariables(frame.Off, stacks[:len(stacks)-globals])
+	})
+}
+
+func (r *variableRefs) addGlobals(frame vm.GDVMFrameState) int {
+	return r.add(func() []Variable {
+		stacks, globals := stackChain(frame.Stack)
+		return r.symbolVariables(frame.Off, stacks[len(stacks)-globals:])
+	})
+}
+
+// Stacks from the innermost to the root, and the number of stacks of the globals at the
+// end of the chain. The root stack has the builtins, and the globals are in its block.
+func stackChain(stack *runtime.GDSymbolStack) ([]*runtime.GDSymbolStack, int) {
+	stacks := make([]*runtime.GDSymbolStack, 0)
+	for ; stack != nil; stack = stack.Parent {
+		stacks = append(stacks, stack)
+	}
+
+	return stacks, min(len(stacks), 2)
+}
+
+// Variables of the named symbols of the stacks sorted by name,
+// the symbols of the first stacks shadow the ones of the last stacks
+func (r *variableRefs) symbolVariables(off uint, stacks []*runtime.GDSymbolStack) []Variable {
+	seen := make(map[string]bool)
+	variables := make([]Variable, 0)
+	for _, stack := range stacks {
+		for key, symbol := range stack.Symbols {
+			name, ok := r.stepper.SymbolName(off, key)
+			if !ok || seen[name] {
+				continue
+			}
+
+			seen[name] = true
+			variables = append(variables, r.variable(name, symbol.Type, symbol.Object))
+		}
+	}
+
+	slices.SortFunc(variables, func(a, b Variable) int { return strings.Compare(a.Name, b.Name) })
+
+	return variables
+}
+
+// Variable of an object, the attributes of the structs and the
+// elements of the collections are variables of their own
+func (r *variableRefs) variable(name string, typ runtime.GDTypable, obj runtime.GDObject) Variable {
+	if typ == nil || typ == runtime.GDAnyType {
+		typ = obj.GetType()
+	}
+
+	variable := Variable{Name: name, Value: r.stepper.ObjectString(obj), Type: r.stepper.TypeName(typ)}
+	switch obj := runtime.Unwrap(obj).(type) {
+	case *runtime.GDStruct:
+		variable.VariablesReference = r.add(func() []Variable {
+			variables := make([]Variable, 0, len(obj.Type))
+			for _, attr := range obj.Type {
+				symbol, err := obj.GetAttr(attr.Ident)
+				if err != nil {
+					continue
+				}
+
+				variables = append(variables, r.variable(attr.Ident.ToString(), attr.Type, symbol.Object))
+			}
+
+			return variables
+		})
+	case *runtime.GDArray:
+		variable.VariablesReference = r.addElements(obj.Objects)
+	case *runtime.GDTuple:
+		variable.VariablesReference = r.addElements(obj.Objects)
+	case *runtime.GDSet:
+		variable.VariablesReference = r.addElements(obj.Objects)
+	}
+
+	return variable
+}
+
+func (r *variableRefs) addElements(objects []runtime.GDObject) int {
+	if len(objects) == 0 {
+		return 0
+	}
+
+	return r.add(func() []Variable {
+		variables := make([]Variable, len(objects))
+		for i, obj := range objects {
+			variables[i] = r.variable("["+strconv.Itoa(i)+"]", nil, obj)
+		}
+
+		return variables
+	})
+}
+
+func newVariableRefs(stepper *vm.GDVMStepper) *variableRefs {
+	return &variableRefs{stepper, make([]func() []Variable, 0)}
+}
@@ -28,9 +28,15 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 )
 
+// Name of the functions that initialize a package
+const initFuncName = "init"
+
 type SourceFile struct {
 	// Scanner file reference for the source file, it contains the name and number of lines
 	file *scanner.File
@@ -38,6 +44,8 @@ type SourceFile struct {
 	parentPackage *SourcePackage
 	// A list all packages that are used in the source file
 	packages []Package
+	// The AST of the source file, kept for tools such as the language server
+	Root *ast.NodeFile
 	// Each source file has an internal package that stores all objects created in the file
 	// as well references to other objects from other packages that are used in the file
 	*runtime.GDPackage[any]
@@ -58,6 +66,12 @@ type NodeWithSourceFile struct {
 
 type SourceFiles []*SourceFile
 
+// A package imported with an alias, e.g. `use a.b.c as h`
+type PackageAlias struct {
+	Package Package
+	Node    *ast.NodePackage
+}
+
 type Package interface {
 	GetName() runtime.GDIdent
 	GetPath() string
@@ -66,6 +80,8 @@ type Package interface {
 
 type SourcePackage struct {
 	sourceFiles SourceFiles
+	// Initializers of the package in declaration order
+	initFuncs []*NodeWithSourceFile
 	*runtime.GDPackage[*NodeWithSourceFile]
 }
 
@@ -91,6 +107,8 @@ func (p *BuiltInPackage) GetMode() runtime.GDPackageMode { return runtime.Packag
 type PackageDependenciesAnalyzerOptions struct {
 	// The package that is being analyzed
 	ShouldLookUpFromMain bool
+	// Packages analyzed by tools, such as the language server, might not have a `main` function
+	IsMainOptional bool
 }
 
 type PackageDependenciesAnalyzer struct {
@@ -99,10 +117,23 @@ type PackageDependenciesAnalyzer struct {
 	mainPackage     *SourcePackage
 	trackedPackages map[string]Package
 	trackedNodes    map[string]bool
+	// Source packages in dependency order, the imported packages go first
+	sourcePackages []*SourcePackage
+	// Globals that are being analyzed, to detect initialization cycles
+	initPath []string
+	// Number of initializers found in the source files
+	initFuncCount int
+	// Names of the local objects by scope, they shadow the members with the same name
+	locals []map[string]bool
 	// Computed nodes based on the dependency hierarchy
 	Nodes []ast.Node
 	// A reference to the main entry point of the package
 	MainEntry ast.Node
+	// Initializers that are called before the main entry, in calling order
+	InitEntries []ast.Node
+	// Contents of the source files that replace the files in the filesystem,
+	// e.g. the unsaved documents of an editor
+	Overlay map[string][]byte
 }
 
 func (d *PackageDependenciesAnalyzer) Analyze(mainPackagePath string, options PackageDependenciesAnalyzerOptions) error {
@@ -112,7 +143,12 @@ func (d *PackageDependenciesAnalyzer) Analyze(mainPackagePath string, options Pa
 	d.astBuilder.Dispose()
 	d.trackedPackages = make(map[string]Package)
 	d.trackedNodes = make(map[string]bool)
+	d.sourcePackages = nil
+	d.initPath = nil
+	d.initFuncCount = 0
+	d.locals = nil
 	d.Nodes = make([]ast.Node, 0)
+	d.InitEntries = make([]ast.Node, 0)
 
 	mainIdent := runtime.NewGDStringIdent("main")
 	d.mainPackage = NewSourcePackage(mainIdent, mainPackagePath)
@@ -122,15 +158,15 @@ func (d *PackageDependenciesAnalyzer) Analyze(mainPackagePath string, options Pa
 	}
 
 	main, err := d.mainPackage.GetMember(mainIdent)
-	if err != nil {
+	if err != nil && (options.ShouldLookUpFromMain || !options.IsMainOptional) {
 		return ErrorAt(scanner.ZeroPos).MainEntryWasNotFound()
+	} else if err == nil {
+		d.MainEntry = main.Node
 	}
 
-	d.MainEntry = main.Node
-
 	if options.ShouldLookUpFromMain {
 		d.trackedNodes = make(map[string]bool)
-		return d.analyzeNode(main.Node, main.SourceFile)
+		err = d.analyzeNode(main.Node, main.SourceFile)
 	} else {
 		var traversePkg func(pkg Package) error
 		traversePkg = func(pkg Package) error {
@@ -144,15 +180,10 @@ func (d *PackageDependenciesAnalyzer) Analyze(mainPackagePath string, options Pa
 						}
 					}
 
-					for _, member := range file.Members {
-						switch node := member.Value.(type) {
-						case *NodeWithSourceFile:
-							err := d.analyzeNode(node.Node, node.SourceFile)
-							if err != nil {
-								return err
-							}
-						case *runtime.GDSymbol:
-							// Nothing to do
+					for _, node := range sourceMembers(file) {
+						err := d.analyzeNode(node.Node, node.SourceFile)
+						if err != nil {
+							return err
 						}
 					}
 				}
@@ -166,8 +197,14 @@ func (d *PackageDependenciesAnalyzer) Analyze(mainPackagePath string, options Pa
 			}
 		}
 
-		return traversePkg(d.mainPackage)
+		err = traversePkg(d.mainPackage)
+	}
+
+	if err != nil {
+		return err
 	}
+
+	return d.analyzeInitFuncs()
 }
 
 func (d *PackageDependenciesAnalyzer) BuildPackage(pkg Package) error {
@@ -198,8 +235,15 @@ func (d *PackageDependenciesAnalyzer) BuildPackage(pkg Package) error {
 
 			return nil
 		})
+		if err != nil {
+			return err
+		}
 
-		return err
+		// The imported packages are built while building the source files,
+		// so a package is registered after all its dependencies
+		d.sourcePackages = append(d.sourcePackages, pkg)
+
+		return nil
 	case *BuiltInPackage:
 		// Nothing to do
 		return nil
@@ -209,7 +253,12 @@ func (d *PackageDependenciesAnalyzer) BuildPackage(pkg Package) error {
 }
 
 func (d *PackageDependenciesAnalyzer) BuildSourceFile(sourceFilePath string, pkg *SourcePackage) (*SourceFile, error) {
-	sourceBytes, err := os.ReadFile(sourceFilePath)
+	sourceBytes, isOverlay := d.Overlay[sourceFilePath]
+	var err error
+	if !isOverlay {
+		sourceBytes, err = os.ReadFile(sourceFilePath)
+	}
+
 	if err != nil {
 		return nil, ErrorAt(scanner.ZeroPos).ReadingSourceFile(sourceFilePath)
 	}
@@ -231,20 +280,26 @@ func (d *PackageDependenciesAnalyzer) BuildSourceFile(sourceFilePath string, pkg
 		return nil, err
 	}
 
-	// Free memory (Ast is no longer needed)
+	// Free memory of the builder, the nodes are kept by the source file
 	defer (func() {
 		d.astBuilder.Dispose()
 		d.scanFileSet.Reset()
-		root = nil
 	})()
 
 	sourceFile := NewSourceFile(file, pkg)
+	sourceFile.Root = root
 
 	// Traverse nodes and register members
 	for _, node := range root.Nodes {
 		var err error
 		switch node := node.(type) {
 		case *ast.NodeFunc:
+			// Initializers are not members, they are called before the main entry
+			if node.Ident.Lit == initFuncName && !node.IsOperator {
+				err = d.addInitFunc(node, sourceFile)
+				break
+			}
+
 			nodeIdent := runtime.NewGDStringIdent(node.Ident.Lit)
 			err = d.addMemberToSourceFile(nodeIdent, node.IsPub, node, sourceFile)
 		case *ast.NodeTypeAlias:
@@ -317,6 +372,23 @@ func (d *PackageDependenciesAnalyzer) BuildSourceFile(sourceFilePath string, pkg
 		return nil, ErrorAt(nodePackage.GetPosition()).PackageNotFound(nodePackage.GetName())
 
 	next:
+		// Wildcards and aliases import all the public objects of the package
+		if nodePackage.IsWildcard || nodePackage.Alias != nil {
+			nodePackage.Imports = publicImports(pkg, nodePackage)
+		}
+
+		// The objects of an aliased package are only accessible through the alias,
+		// so the alias is registered instead of the objects
+		if nodePackage.Alias != nil {
+			aliasIdent := runtime.NewGDStringIdent(nodePackage.Alias.Lit)
+			err := sourceFile.AddLocal(aliasIdent, &PackageAlias{pkg, nodePackage})
+			if err != nil {
+				return nil, ErrorAt(nodePackage.Alias.GetPosition()).DuplicatedObject(aliasIdent.ToString())
+			}
+
+			goto track
+		}
+
 		for _, ident := range nodePackage.Imports {
 			identNode, isIdentNode := ident.(*ast.NodeIdent)
 			if !isIdentNode {
@@ -336,7 +408,7 @@ func (d *PackageDependenciesAnalyzer) BuildSourceFile(sourceFilePath string, pkg
 				// Add the node reference to the source file
 				err = sourceFile.AddPublic(ident, nod)
 				if err != nil {
-					return nil, ErrorAt(nod.Node.GetPosition()).DuplicatedObject(ident.ToString())
+					return nil, ErrorAt(importCollisionPos(ident, identNode, sourceFile)).DuplicatedObject(ident.ToString())
 				}
 			case *BuiltInPackage:
 				obj, err := pkg.GetMember(ident)
@@ -347,26 +419,31 @@ func (d *PackageDependenciesAnalyzer) BuildSourceFile(sourceFilePath string, pkg
 				// Add the object reference to the source file
 				err = sourceFile.AddPublic(ident, obj)
 				if err != nil {
-					return nil, ErrorAt(identNode.GetPosition()).DuplicatedObject(ident.ToString())
+					return nil, ErrorAt(importCollisionPos(ident, identNode, sourceFile)).DuplicatedObject(ident.ToString())
 				}
 			default:
 				panic("Invalid package type, expected *SourcePackage or *BuiltInPackage")
 			}
 		}
 
+	track:
 		if !isTracked {
 			d.trackedPackages[packageAbsolutePath] = pkg
+		}
 
-			// Set inferred values for the package
-			nodePackage.InferredPath = packagePath
-			nodePackage.InferredAbsolutePath = packageAbsolutePath
-			nodePackage.InferredMode = pkg.GetMode()
+		// Set inferred values for the package
+		nodePackage.InferredPath = packagePath
+		nodePackage.InferredAbsolutePath = packageAbsolutePath
+		nodePackage.InferredMode = pkg.GetMode()
 
-			// Append the package to the hierarchy
-			ident := "package: " + nodePackage.GetName() + "@" + sourceFile.file.Name()
-			if !d.trackIdent(ident) {
-				d.Nodes = append(d.Nodes, nodePackage)
-			}
+		// Append the package to the hierarchy, each source file declares its own imports
+		ident := "package: " + nodePackage.GetName() + "@" + sourceFile.file.Name()
+		if nodePackage.Alias != nil {
+			ident += " as " + nodePackage.Alias.Lit
+		}
+
+		if !d.trackIdent(ident) {
+			d.Nodes = append(d.Nodes, nodePackage)
 		}
 
 		// Register the package into the source file
@@ -382,8 +459,11 @@ func (d *PackageDependenciesAnalyzer) Dispose() {
 	d.mainPackage = nil
 	d.trackedPackages = nil
 	d.trackedNodes = nil
+	d.sourcePackages = nil
+	d.initPath = nil
 	d.Nodes = nil
 	d.MainEntry = nil
+	d.InitEntries = nil
 }
 
 func (d *PackageDependenciesAnalyzer) analyzeType(typ runtime.GDTypable, astNode ast.Node, sourceFile *SourceFile) error {
@@ -391,7 +471,7 @@ func (d *PackageDependenciesAnalyzer) analyzeType(typ runtime.GDTypable, astNode
 	case runtime.GDIdent:
 		typeIdent := typ.ToString()
 		nodeIdent := runtime.NewGDStringIdent(typeIdent)
-		if nodeRef := d.getNodeReference(nodeIdent, sourceFile); nodeRef != nil {
+		if nodeRef := d.GetNodeReference(nodeIdent, sourceFile); nodeRef != nil {
 			switch nodeRef := nodeRef.(type) {
 			case *NodeWithSourceFile:
 				return d.analyzeNode(nodeRef.Node, nodeRef.SourceFile)
@@ -414,6 +494,10 @@ func (d *PackageDependenciesAnalyzer) analyzeType(typ runtime.GDTypable, astNode
 		return nil
 	case *runtime.GDArrayType:
 		return d.analyzeType(typ.SubType, astNode, sourceFile)
+	case *runtime.GDSetType:
+		return d.analyzeType(typ.SubType, astNode, sourceFile)
+	case *runtime.GDNominalType:
+		return d.analyzeType(typ.Type, astNode, sourceFile)
 	case runtime.GDTupleType:
 		for _, typ := range typ {
 			err := d.analyzeType(typ, astNode, sourceFile)
@@ -455,15 +539,19 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 		// Nothing to do
 		return nil
 	case *ast.NodeIdent:
+		if d.isLocal(astNode.Lit) {
+			return nil
+		}
+
 		nodeIdent := runtime.NewGDStringIdent(astNode.Lit)
-		if nodeRef := d.getNodeReference(nodeIdent, sourceFile); nodeRef != nil {
+		if nodeRef := d.GetNodeReference(nodeIdent, sourceFile); nodeRef != nil {
 			switch nodeRef := nodeRef.(type) {
 			case *NodeWithSourceFile:
 				return d.analyzeNode(nodeRef.Node, nodeRef.SourceFile)
-			case *runtime.GDSymbol:
+			case *runtime.GDSymbol, *PackageAlias:
 				// Nothing to do
 			default:
-				panic("Invalid member type, expected *NodeWithSourceFile or *runtime.GDSymbol")
+				panic("Invalid member type, expected *NodeWithSourceFile, *runtime.GDSymbol or *PackageAlias")
 			}
 		}
 
@@ -474,6 +562,9 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 			return nil
 		}
 
+		// The local objects of the node that references the function are not visible from it
+		defer d.enterMember()()
+
 		err := d.analyzeNode(astNode.NodeLambda, sourceFile)
 		if err != nil {
 			return err
@@ -481,14 +572,30 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 
 		// Only append and analyse the node if it is part of the first citizen objects in the source file
 		nodeIdent := runtime.NewGDStringIdent(astNode.Ident.Lit)
-		if d.getNodeReference(nodeIdent, sourceFile) != nil {
+		if d.GetNodeReference(nodeIdent, sourceFile) != nil {
 			d.Nodes = append(d.Nodes, astNode)
 		}
 
 		return nil
 	case *ast.NodeLambda:
+		// The types of the signature must be declared before the lambda
+		err := d.analyzeType(astNode.Type, astNode, sourceFile)
+		if err != nil {
+			return err
+		}
+
+		d.pushScope()
+		defer d.popScope()
+
+		for _, arg := range astNode.Type.ArgTypes {
+			d.declareLocal(arg.Key.ToString())
+		}
+
 		return d.analyzeNode(astNode.Block, sourceFile)
 	case *ast.NodeBlock:
+		d.pushScope()
+		defer d.popScope()
+
 		for _, node := range astNode.Nodes {
 			err := d.analyzeNode(node, sourceFile)
 			if err != nil {
@@ -499,7 +606,10 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 		return nil
 	case *ast.NodeExprOperation:
 		if astNode.R != nil {
-			return d.analyzeNode(astNode.R, sourceFile)
+			err := d.analyzeNode(astNode.R, sourceFile)
+			if err != nil {
+				return err
+			}
 		}
 
 		if astNode.L != nil {
@@ -509,7 +619,9 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 			}
 		}
 
-		return nil
+		// Operand types are unknown at this stage, so any operator function
+		// that overloads the operation might be required
+		return d.analyzeOperatorFuncs(astNode.Op, sourceFile)
 	case *ast.NodeEllipsisExpr:
 		return d.analyzeNode(astNode.Expr, sourceFile)
 	case *ast.NodeTuple:
@@ -543,6 +655,16 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 			}
 		}
 
+		return nil
+	case *ast.NodeSetLiteral:
+		for i := len(astNode.Nodes) - 1; i >= 0; i-- {
+			node := astNode.Nodes[i]
+			err := d.analyzeNode(node, sourceFile)
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	case *ast.NodeReturn:
 		if astNode.Expr != nil {
@@ -571,6 +693,10 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 
 		return d.analyzeNode(astNode.Expr, sourceFile)
 	case *ast.NodeSafeDotExpr:
+		if alias := d.getPackageAlias(astNode.Expr, sourceFile); alias != nil {
+			return d.analyzePackageMember(alias, astNode.Ident)
+		}
+
 		err := d.analyzeNode(astNode.Expr, sourceFile)
 		if err != nil {
 			return err
@@ -588,9 +714,23 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 
 		return nil
 	case *ast.NodeSet:
-		ident := astNode.IdentWithType.Ident.Lit + "@" + sourceFile.file.Name()
-		if d.trackIdent(ident) {
-			return nil
+		// Only globals are tracked, since several local objects can have the same name
+		nodeIdent := runtime.NewGDStringIdent(astNode.IdentWithType.Ident.Lit)
+		isGlobal := d.isMember(astNode, nodeIdent, sourceFile)
+		if isGlobal {
+			ident := astNode.IdentWithType.Ident.Lit + "@" + sourceFile.file.Name()
+			if d.trackIdent(ident) {
+				return d.checkInitCycle(ident, astNode)
+			}
+
+			// The global is initialized after the objects of its expression,
+			// reaching it again before that is an initialization cycle
+			d.initPath = append(d.initPath, ident)
+			defer func() {
+				d.initPath = d.initPath[:len(d.initPath)-1]
+			}()
+
+			defer d.enterMember()()
 		}
 
 		if astNode.IdentWithType.Type != nil {
@@ -607,9 +747,10 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 			}
 		}
 
-		nodeIdent := runtime.NewGDStringIdent(astNode.IdentWithType.Ident.Lit)
-		if d.getNodeReference(nodeIdent, sourceFile) != nil {
+		if isGlobal {
 			d.Nodes = append(d.Nodes, astNode)
+		} else {
+			d.declareLocal(astNode.IdentWithType.Ident.Lit)
 		}
 
 		return nil
@@ -665,6 +806,10 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 
 		return d.analyzeNode(astNode.Else, sourceFile)
 	case *ast.NodeForIn:
+		// The objects of the loop are only visible inside the loop
+		d.pushScope()
+		defer d.popScope()
+
 		err := d.analyzeNode(astNode.Sets, sourceFile)
 		if err != nil {
 			return err
@@ -677,6 +822,9 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 
 		return d.analyzeNode(astNode.Block, sourceFile)
 	case *ast.NodeForIf:
+		d.pushScope()
+		defer d.popScope()
+
 		if astNode.Sets != nil {
 			err := d.analyzeNode(astNode.Sets, sourceFile)
 			if err != nil {
@@ -694,6 +842,9 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 		}
 
 		return d.analyzeNode(astNode.Block, sourceFile)
+	case *ast.NodeComprehension:
+		// The accumulator is declared by the comprehension itself
+		return d.analyzeNode(astNode.ForIn, sourceFile)
 	case *ast.NodeMutCollectionOp:
 		err := d.analyzeNode(astNode.L, sourceFile)
 		if err != nil {
@@ -713,7 +864,7 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 		}
 
 		nodeIdent := runtime.NewGDStringIdent(astNode.Ident.Lit)
-		if d.getNodeReference(nodeIdent, sourceFile) != nil {
+		if d.GetNodeReference(nodeIdent, sourceFile) != nil {
 			d.Nodes = append(d.Nodes, astNode)
 		}
 
@@ -735,11 +886,45 @@ func (d *PackageDependenciesAnalyzer) analyzeNode(astNode ast.Node, sourceFile *
 	}
 }
 
+// Analyzes the operator functions, reachable from the source file, that overload the operation
+func (d *PackageDependenciesAnalyzer) analyzeOperatorFuncs(op runtime.ExprOperationType, sourceFile *SourceFile) error {
+	operatorFuncs := make([]*NodeWithSourceFile, 0)
+	collectOperatorFunc := func(member any) {
+		if member, isSourceNode := member.(*NodeWithSourceFile); isSourceNode {
+			if nodeFunc, isFunc := member.Node.(*ast.NodeFunc); isFunc && nodeFunc.IsOperator && nodeFunc.Op == op {
+				operatorFuncs = append(operatorFuncs, member)
+			}
+		}
+	}
+
+	for _, member := range sourceFile.Members {
+		collectOperatorFunc(member.Value)
+	}
+
+	for _, member := range sourceFile.parentPackage.Members {
+		collectOperatorFunc(member.Value)
+	}
+
+	// Members are stored in a map, sort them to keep the order of the nodes deterministic
+	sort.Slice(operatorFuncs, func(i, j int) bool {
+		return operatorFuncs[i].Node.(*ast.NodeFunc).Ident.Lit < operatorFuncs[j].Node.(*ast.NodeFunc).Ident.Lit
+	})
+
+	for _, member := range operatorFuncs {
+		err := d.analyzeNode(member.Node, member.SourceFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Check if the identifier is a public object,
 // but it is not found, then it should not throw an error
 // because it could be a local object that must be evaluated later
 // in another stage with a stack
-func (d *PackageDependenciesAnalyzer) getNodeReference(ident runtime.GDIdent, sourceFile *SourceFile) any {
+func (d *PackageDependenciesAnalyzer) GetNodeReference(ident runtime.GDIdent, sourceFile *SourceFile) any {
 	// References are first looked up in the source file,
 	// with local references having higher priority
 	fileRef, _ := sourceFile.GetMember(ident)
@@ -757,6 +942,161 @@ func (d *PackageDependenciesAnalyzer) getNodeReference(ident runtime.GDIdent, so
 	return nil
 }
 
+// Returns the package imported with the path of a `use` directive, e.g. `a.b.c` or `math`,
+// nil if the package was not imported by the analyzed source files
+func (d *PackageDependenciesAnalyzer) GetPackage(usePath string) Package {
+	if pkg, isTracked := d.trackedPackages[path.Join(d.mainPackage.Path, usePath)]; isTracked {
+		return pkg
+	}
+
+	if builtInPackage, ok := builtin.Packages[usePath]; ok {
+		return &BuiltInPackage{builtInPackage}
+	}
+
+	return nil
+}
+
+// All the source files of the analyzed packages, the files of the imported packages go first
+func (d *PackageDependenciesAnalyzer) SourceFiles() SourceFiles {
+	var files SourceFiles
+	for _, pkg := range d.sourcePackages {
+		files = append(files, pkg.sourceFiles...)
+	}
+
+	return files
+}
+
+// Analyzing a member starts without local objects, the returned function restores them
+func (d *PackageDependenciesAnalyzer) enterMember() func() {
+	locals := d.locals
+	d.locals = nil
+
+	return func() {
+		d.locals = locals
+	}
+}
+
+func (d *PackageDependenciesAnalyzer) pushScope() {
+	d.locals = append(d.locals, make(map[string]bool))
+}
+
+func (d *PackageDependenciesAnalyzer) popScope() {
+	d.locals = d.locals[:len(d.locals)-1]
+}
+
+func (d *PackageDependenciesAnalyzer) declareLocal(name string) {
+	if len(d.locals) > 0 {
+		d.locals[len(d.locals)-1][name] = true
+	}
+}
+
+func (d *PackageDependenciesAnalyzer) isLocal(name string) bool {
+	for _, scope := range d.locals {
+		if scope[name] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Checks if the node is the member of the source file or its package with the given ident
+func (d *PackageDependenciesAnalyzer) isMember(node ast.Node, ident runtime.GDIdent, sourceFile *SourceFile) bool {
+	member, isSourceNode := d.GetNodeReference(ident, sourceFile).(*NodeWithSourceFile)
+	return isSourceNode && member.Node == node
+}
+
+// Reports an initialization cycle when the global is reached while its expression is being analyzed
+func (d *PackageDependenciesAnalyzer) checkInitCycle(ident string, node *ast.NodeSet) error {
+	start := slices.Index(d.initPath, ident)
+	if start < 0 {
+		return nil
+	}
+
+	cycle := make([]string, 0, len(d.initPath)-start+1)
+	for _, pathIdent := range append(d.initPath[start:], ident) {
+		name, _, _ := strings.Cut(pathIdent, "@")
+		cycle = append(cycle, name)
+	}
+
+	return ErrorAt(node.IdentWithType.Ident.GetPosition()).InitializationCycle(cycle)
+}
+
+// Registers an initializer of the package, it can't be public, have arguments or return a value
+func (d *PackageDependenciesAnalyzer) addInitFunc(node *ast.NodeFunc, sourceFile *SourceFile) error {
+	if node.IsPub || len(node.Type.ArgTypes) > 0 || node.Type.ReturnType != runtime.GDNilType {
+		return ErrorAt(node.Ident.GetPosition()).InvalidInitFunc()
+	}
+
+	// Several packages and source files can declare initializers,
+	// so each one gets an ident that can't be referenced from the source code
+	node.Ident.Lit = initFuncName + "." + strconv.Itoa(d.initFuncCount)
+	d.initFuncCount++
+
+	pkg := sourceFile.parentPackage
+	pkg.initFuncs = append(pkg.initFuncs, &NodeWithSourceFile{node, sourceFile})
+
+	return nil
+}
+
+// Initializers are called before the main entry, the ones of the imported
+// packages first, and then the ones of each package in declaration order
+func (d *PackageDependenciesAnalyzer) analyzeInitFuncs() error {
+	for _, pkg := range d.sourcePackages {
+		for _, initFunc := range pkg.initFuncs {
+			node := initFunc.Node.(*ast.NodeFunc)
+			err := d.analyzeNode(node.NodeLambda, initFunc.SourceFile)
+			if err != nil {
+				return err
+			}
+
+			d.Nodes = append(d.Nodes, node)
+			d.InitEntries = append(d.InitEntries, node)
+		}
+	}
+
+	return nil
+}
+
+// Returns the package alias when the node is the ident of an alias, e.g. `h` in `h.get()`
+func (d *PackageDependenciesAnalyzer) getPackageAlias(node ast.Node, sourceFile *SourceFile) *PackageAlias {
+	identNode, isIdentNode := node.(*ast.NodeIdent)
+	if !isIdentNode || d.isLocal(identNode.Lit) {
+		return nil
+	}
+
+	alias, _ := d.GetNodeReference(runtime.NewGDStringIdent(identNode.Lit), sourceFile).(*PackageAlias)
+	return alias
+}
+
+// Analyzes a public object accessed through a package alias
+func (d *PackageDependenciesAnalyzer) analyzePackageMember(alias *PackageAlias, node ast.Node) error {
+	identNode, isIdentNode := node.(*ast.NodeIdent)
+	if !isIdentNode {
+		return nil
+	}
+
+	ident := runtime.NewGDStringIdent(identNode.Lit)
+	for _, importNode := range alias.Node.Imports {
+		if importNode.(*ast.NodeIdent).Lit != identNode.Lit {
+			continue
+		}
+
+		if pkg, isSourcePackage := alias.Package.(*SourcePackage); isSourcePackage {
+			nod, err := pkg.GetMember(ident)
+			if err != nil {
+				return err
+			}
+
+			return d.analyzeNode(nod.Node, nod.SourceFile)
+		}
+
+		return nil
+	}
+
+	return ErrorAt(identNode.GetPosition()).PackageObjectWasNotFound(identNode.Lit, alias.Node.GetName())
+}
+
 func (d *PackageDependenciesAnalyzer) trackIdent(ident string) bool {
 	// Check if the node was already tracked, if so, return true
 	if _, isTracked := d.trackedNodes[ident]; isTracked {
@@ -789,6 +1129,56 @@ func (d *PackageDependenciesAnalyzer) addMemberToSourceFile(ident runtime.GDIden
 	return sourceFile.AddLocal(ident, sourceNode)
 }
 
+// Members of the source file sorted by position, since they are stored in a map
+func sourceMembers(file *SourceFile) []*NodeWithSourceFile {
+	members := make([]*NodeWithSourceFile, 0, len(file.Members))
+	for _, member := range file.Members {
+		if node, isSourceNode := member.Value.(*NodeWithSourceFile); isSourceNode {
+			members = append(members, node)
+		}
+	}
+
+	sort.Slice(members, func(i, j int) bool {
+		a, b := members[i].Node.GetPosition(), members[j].Node.GetPosition()
+		return a.Line < b.Line || (a.Line == b.Line && a.ColStart < b.ColStart)
+	})
+
+	return members
+}
+
+// Position of the object that collides with an import, the declaration in the
+// importing file, or the `use` directive when it collides with another import
+func importCollisionPos(ident runtime.GDIdent, identNode *ast.NodeIdent, sourceFile *SourceFile) scanner.Position {
+	member, err := sourceFile.GetMember(ident)
+	if err != nil {
+		return identNode.GetPosition()
+	}
+
+	if node, isSourceNode := member.(*NodeWithSourceFile); isSourceNode && node.SourceFile == sourceFile {
+		return node.Node.GetPosition()
+	}
+
+	return identNode.GetPosition()
+}
+
+// Idents of all the public objects of the package, positioned at the `use` directive
+func publicImports(pkg Package, nodePackage *ast.NodePackage) []ast.Node {
+	var idents []runtime.GDIdent
+	switch pkg := pkg.(type) {
+	case *SourcePackage:
+		idents = pkg.PublicMembers()
+	case *BuiltInPackage:
+		idents = pkg.PublicMembers()
+	}
+
+	imports := make([]ast.Node, len(idents))
+	for i, ident := range idents {
+		imports[i] = ast.NewNodeIdent(ast.NewNodeTokenInfo(scanner.IDENT, nodePackage.GetPosition(), ident.ToString()))
+	}
+
+	return imports
+}
+
 func NewPackageDependenciesAnalyzer() *PackageDependenciesAnalyzer {
 	return &PackageDependenciesAnalyzer{
 		astBuilder:      ast.NAstBuilderProc(),
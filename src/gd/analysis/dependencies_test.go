@@ -23,6 +23,7 @@ import (
 	"gdlang/src/comn"
 	"gdlang/src/gd/analysis"
 	"gdlang/src/test_helper"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -60,6 +61,26 @@ func TestCases(t *testing.T) {
 				}`),
 			),
 		},
+		{
+			"initialization cycle: `a` refers to `b` refers to `a`",
+			test_helper.NDir("",
+				test_helper.NFile("globals.gd", `set a = b
+				set b = a`),
+				test_helper.NMFile(`pub func main() {}`),
+			),
+		},
+		{
+			"",
+			test_helper.NDir("",
+				test_helper.NMFile(`set total = 1
+				func sum(xs: [int]) => int {
+					set total = 0
+					return total
+				}
+				set a = sum([total])
+				pub func main() {}`),
+			),
+		},
 	}
 
 	for _, test := range tests {
@@ -82,3 +103,40 @@ func TestCases(t *testing.T) {
 		}
 	}
 }
+
+func TestInitEntries(t *testing.T) {
+	dA := analysis.NewPackageDependenciesAnalyzer()
+	pkgs := test_helper.NDir("",
+		test_helper.NDir("config", test_helper.NFile("config.gd", `func init() {}`)),
+		test_helper.NFile("a.gd", `func init() {}`),
+		test_helper.NMFile(`use config {*}
+		func init() {}
+		pub func main() {}`),
+	)
+
+	var entries []string
+	err := test_helper.BuildPackageTree(pkgs, func(tmpDir string) error {
+		defer dA.Dispose()
+
+		err := dA.Analyze(tmpDir, analysis.PackageDependenciesAnalyzerOptions{ShouldLookUpFromMain: true})
+		for _, entry := range dA.InitEntries {
+			entries = append(entries, entry.GetPosition().Filename)
+		}
+
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"config.gd", "a.gd", "main.gd"}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d initializers but got %d", len(expected), len(entries))
+	}
+
+	for i, entry := range entries {
+		if filepath.Base(entry) != expected[i] {
+			t.Errorf("Expected the initializer of %q at %d but got %q", expected[i], i, entry)
+		}
+	}
+}
@@ -22,12 +22,15 @@ package analysis
 import (
 	"gdlang/src/comn"
 	"gdlang/src/gd/scanner"
+	"strings"
 )
 
 const (
 	ErrorPackageNotFound comn.ErrCode = 100 + iota
 	ErrorReadingSourceFile
 	ErrorPackageObjectNotFound
+	ErrorInitializationCycle
+	ErrorInvalidInitFunc
 )
 
 type ErrorAt scanner.Position
@@ -51,3 +54,11 @@ func (e ErrorAt) DuplicatedObject(objName string) comn.Error {
 func (e ErrorAt) MainEntryWasNotFound() comn.Error {
 	return comn.NewErrorf(ErrorPackageObjectNotFound, comn.FatalError, scanner.Position(e), "no `main` function was found in the package")
 }
+
+func (e ErrorAt) InitializationCycle(cycle []string) comn.Error {
+	return comn.NewErrorf(ErrorInitializationCycle, comn.FatalError, scanner.Position(e), "initialization cycle: `%s`", strings.Join(cycle, "` refers to `"))
+}
+
+func (e ErrorAt) InvalidInitFunc() comn.Error {
+	return comn.NewErrorf(ErrorInvalidInitFunc, comn.FatalError, scanner.Position(e), "`init` function can't be public, have arguments or return a value")
+}
@@ -0,0 +1,512 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package asm assembles the listing printed by the disassembler of the VM
+// back into bytecode. `gdc` writes the same listing to the `.gdasm` files.
+//
+// Each line holds an instruction, optionally preceded by its byte offset,
+// or a label, e.g. `L00042:`, and the comments start with a `;`. The lines
+// are parsed into the IR nodes of the compiler, so the bytecode is written
+// by the same nodes, and the labels are resolved by the IR context. The
+// offsets of the listing are ignored, so the instructions can be edited
+// and the jumps still land on their labels.
+package asm
+
+import (
+	"bytes"
+	"gdlang/lib/runtime"
+	"gdlang/src/comn"
+	"gdlang/src/cpu"
+	"gdlang/src/gd/ast"
+	"gdlang/src/gd/ir"
+	"gdlang/src/gd/scanner"
+	"strings"
+	"unicode"
+)
+
+// Instructions by their name in the listing
+var instsByName = make(map[string]cpu.GDInst)
+
+func init() {
+	for inst := cpu.TypeAlias; inst <= cpu.Label; inst++ {
+		instsByName[cpu.GetCPUInstName(inst)] = inst
+	}
+}
+
+// Assemble assembles the listing of a file, the filename is only used for the errors
+func Assemble(filename string, src []byte) ([]byte, error) {
+	p := newParser(filename)
+
+	nodes, err := p.parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	bytecode := &bytes.Buffer{}
+	ctx := ir.NewGDIRContext()
+	for _, node := range nodes {
+		err := node.BuildBytecode(bytecode, ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The labels are checked by the parser, so every mark is resolved
+	if len(ctx.Marks) > 0 {
+		return nil, p.errorAt(p.line, 0, "undefined label `%s`", ctx.Marks[0].Label.ToString())
+	}
+
+	return bytecode.Bytes(), nil
+}
+
+type parser struct {
+	filename string
+	// Line being parsed and its number
+	src  string
+	line int
+	off  int
+	// Nodes outside of the blocks
+	nodes []ir.GDIRNode
+	// Blocks being parsed, from the outermost to the innermost
+	blocks []*ir.GDIRBlock
+	// Lambda whose block is the next instruction
+	lambda *ir.GDIRLambda
+	// Lines of the labels defined and of the first jump to each label
+	labels, jumps map[string]int
+	// Types of the aliases, to parse the objects of the type references
+	aliases map[any]runtime.GDTypable
+}
+
+func (p *parser) parse(src string) ([]ir.GDIRNode, error) {
+	for i, line := range strings.Split(src, "\n") {
+		p.line, p.src, p.off = i+1, stripComment(line), 0
+
+		err := p.parseLine()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.lambda != nil {
+		return nil, p.errorAt(p.line, 0, "expected a block after the lambda")
+	}
+
+	if len(p.blocks) > 0 {
+		return nil, p.errorAt(p.line, 0, "expected the `end` of %d blocks", len(p.blocks))
+	}
+
+	// The first jump to an undefined label is reported, whatever the order of the map
+	undefined, undefinedLine := "", 0
+	for label, line := range p.jumps {
+		if _, found := p.labels[label]; !found && (undefinedLine == 0 || line < undefinedLine) {
+			undefined, undefinedLine = label, line
+		}
+	}
+
+	if undefinedLine > 0 {
+		return nil, p.errorAt(undefinedLine, 0, "undefined label `%s`", undefined)
+	}
+
+	return p.nodes, nil
+}
+
+func (p *parser) parseLine() error {
+	p.skipSpaces()
+
+	// The offset printed by the disassembler is skipped
+	if start := p.off; p.off < len(p.src) && isDigit(p.src[p.off]) {
+		for p.off < len(p.src) && isDigit(p.src[p.off]) {
+			p.off++
+		}
+
+		if p.off < len(p.src) && !unicode.IsSpace(rune(p.src[p.off])) {
+			p.off = start
+		}
+
+		p.skipSpaces()
+	}
+
+	if p.off >= len(p.src) {
+		return nil
+	}
+
+	start := p.off
+	name := p.word()
+	if name == "" {
+		return p.errorf("expected an instruction or a label")
+	}
+
+	if p.peek() == ':' {
+		p.off++
+		return p.parseLabel(name, start)
+	}
+
+	err := p.parseInst(name, start)
+	if err != nil {
+		return err
+	}
+
+	return p.expectEnd()
+}
+
+func (p *parser) parseLabel(name string, start int) error {
+	err := p.expectEnd()
+	if err != nil {
+		return err
+	}
+
+	if p.lambda != nil {
+		return p.errorAt(p.line, start, "expected a block after the lambda")
+	}
+
+	if line, found := p.labels[name]; found {
+		return p.errorAt(p.line, start, "label `%s` already defined at line %d", name, line)
+	}
+
+	p.labels[name] = p.line
+	p.add(ir.NewGDIRLabel(runtime.NewGDStringIdent(name), nil))
+
+	return nil
+}
+
+func (p *parser) parseInst(name string, start int) error {
+	// Nil safe accesses are marked like in the source code, e.g. `aget?`
+	name, isNilSafe := strings.CutSuffix(name, "?")
+
+	inst, found := instsByName[name]
+	if !found || inst == cpu.Label || isNilSafe && inst != cpu.AGet && inst != cpu.IGet {
+		return p.errorAt(p.line, start, "unknown instruction `%s`", name+tif(isNilSafe, "?", ""))
+	}
+
+	if p.lambda != nil && inst != cpu.BBegin {
+		return p.errorAt(p.line, start, "expected a block after the lambda")
+	}
+
+	switch inst {
+	case cpu.BBegin:
+		block := ir.NewGDIRBlock()
+		if p.lambda != nil {
+			block, p.lambda = p.lambda.GDIRBlock, nil
+		} else {
+			p.add(block)
+		}
+
+		p.blocks = append(p.blocks, block)
+	case cpu.BEnd:
+		if len(p.blocks) == 0 {
+			return p.errorAt(p.line, start, "`end` without a block")
+		}
+
+		p.blocks = p.blocks[:len(p.blocks)-1]
+	case cpu.Lambda:
+		typ, err := p.parseType()
+		if err != nil {
+			return err
+		}
+
+		lambdaType, isLambda := typ.(*runtime.GDLambdaType)
+		if !isLambda {
+			return p.errorf("expected a `func` type, got `%s`", typ.ToString())
+		}
+
+		lambda, _ := ir.NewGDIRLambda(lambdaType, nil)
+		p.add(lambda)
+		p.lambda = lambda
+	case cpu.Set, cpu.TypeAlias:
+		disc, ident, err := p.parseDisc()
+		if err != nil {
+			return err
+		}
+
+		typ, err := p.parseType()
+		if err != nil {
+			return err
+		}
+
+		if inst == cpu.TypeAlias {
+			p.aliases[ident.GetRawValue()] = typ
+			p.add(ir.NewGDIRTypeAlias(disc, typ, nil))
+			return nil
+		}
+
+		expr, err := p.parseObject()
+		if err != nil {
+			return err
+		}
+
+		p.add(ir.NewGDIRSet(disc, typ, expr, nil))
+	case cpu.Mov:
+		typ, err := p.parseType()
+		if err != nil {
+			return err
+		}
+
+		target, isObjRef := typ.(runtime.GDObjRefType)
+		if !isObjRef {
+			return p.errorf("expected an `obj_ref` type as the target, got `%s`", typ.ToString())
+		}
+
+		value, err := p.parseObject()
+		if err != nil {
+			return err
+		}
+
+		p.add(ir.NewGDIRMov(ir.NewGDIRIdentObject(target.GDIdent, runtime.GDZNil, nil), value, nil))
+	case cpu.CastObj:
+		typ, err := p.parseType()
+		if err != nil {
+			return err
+		}
+
+		expr, err := p.parseObject()
+		if err != nil {
+			return err
+		}
+
+		cast, _ := ir.NewGDIRCastObject(typ, expr, nil)
+		p.add(cast)
+	case cpu.AGet:
+		expr, err := p.parseObject()
+		if err != nil {
+			return err
+		}
+
+		ident, err := p.parseIdent()
+		if err != nil {
+			return err
+		}
+
+		aGet, _ := ir.NewGDIRAIGet(ident, isNilSafe, expr, nil)
+		p.add(aGet)
+	case cpu.Ret, cpu.ILen:
+		expr, err := p.parseObject()
+		if err != nil {
+			return err
+		}
+
+		if inst == cpu.Ret {
+			ret, _ := ir.NewGDIRRet(expr, nil)
+			p.add(ret)
+		} else {
+			iLen, _ := ir.NewGDIRLen(expr, nil)
+			p.add(iLen)
+		}
+	case cpu.Operation:
+		opName := p.word()
+		op, found := opsByName[opName]
+		if !found {
+			return p.errorf("unknown operation `%s`", opName)
+		}
+
+		objs, err := p.parseObjects(2)
+		if err != nil {
+			return err
+		}
+
+		// The right operand is written first
+		operation, _ := ir.NewGDIROp(op, objs[1], objs[0], nil)
+		p.add(operation)
+	case cpu.IGet, cpu.Call, cpu.CAdd, cpu.CRemove:
+		objs, err := p.parseObjects(2)
+		if err != nil {
+			return err
+		}
+
+		var node ir.GDIRNode
+		switch inst {
+		case cpu.IGet:
+			node, _ = ir.NewGDIRIGet(objs[0], isNilSafe, objs[1], nil)
+		case cpu.Call:
+			node, _ = ir.NewGDIRCall(objs[0], objs[1], nil)
+		case cpu.CAdd:
+			node, _ = ir.NewGDIRCOp(ast.MutableCollectionAddOp, objs[0], objs[1], nil)
+		case cpu.CRemove:
+			node, _ = ir.NewGDIRCOp(ast.MutableCollectionRemoveOp, objs[0], objs[1], nil)
+		}
+
+		p.add(node)
+	case cpu.CSet:
+		objs, err := p.parseObjects(3)
+		if err != nil {
+			return err
+		}
+
+		p.add(ir.NewGDIRISet(objs[0], objs[1], objs[2], nil))
+	case cpu.Tif:
+		objs, err := p.parseObjects(3)
+		if err != nil {
+			return err
+		}
+
+		// The else expression is written first
+		tIf, _ := ir.NewGDIRTIf(objs[2], objs[1], objs[0], nil)
+		p.add(tIf)
+	case cpu.CompareJump:
+		objs, err := p.parseObjects(2)
+		if err != nil {
+			return err
+		}
+
+		label, err := p.parseLabelRef()
+		if err != nil {
+			return err
+		}
+
+		p.add(ir.NewGDIRCompJump(objs[0], objs[1], label, nil))
+	case cpu.Jump:
+		label, err := p.parseLabelRef()
+		if err != nil {
+			return err
+		}
+
+		p.add(ir.NewGDIRJump(label, nil))
+	case cpu.Use:
+		return p.parseUse()
+	}
+
+	return nil
+}
+
+// Reads the public and constant modifiers followed by the ident
+func (p *parser) parseDisc() (*ir.GDIRDiscoverable, runtime.GDIdent, error) {
+	var isPub, isConst bool
+	if p.acceptWord("pub") {
+		isPub = true
+	}
+
+	if p.acceptWord("const") {
+		isConst = true
+	}
+
+	ident, err := p.parseIdent()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ir.NewGDIRDiscoverable(isPub, isConst, ident, nil), ident, nil
+}
+
+func (p *parser) parseUse() error {
+	modeName := p.word()
+	mode, found := packageModesByName[modeName]
+	if !found {
+		return p.errorf("unknown package mode `%s`", modeName)
+	}
+
+	pkg, err := p.parseIdent()
+	if err != nil {
+		return err
+	}
+
+	imports := make([]runtime.GDIdent, 0)
+	err = p.parseList('[', ']', func() error {
+		ident, err := p.parseIdent()
+		if err != nil {
+			return err
+		}
+
+		imports = append(imports, ident)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var alias runtime.GDIdent
+	if p.acceptWord("as") {
+		alias, err = p.parseIdent()
+		if err != nil {
+			return err
+		}
+	}
+
+	p.add(ir.NewGDIRUse(mode, pkg, imports, alias))
+
+	return nil
+}
+
+func (p *parser) parseLabelRef() (runtime.GDIdent, error) {
+	p.skipSpaces()
+	name := p.word()
+	if name == "" {
+		return nil, p.errorf("expected a label")
+	}
+
+	if _, found := p.jumps[name]; !found {
+		p.jumps[name] = p.line
+	}
+
+	return runtime.NewGDStringIdent(name), nil
+}
+
+func (p *parser) add(node ir.GDIRNode) {
+	if len(p.blocks) == 0 {
+		p.nodes = append(p.nodes, node)
+		return
+	}
+
+	p.blocks[len(p.blocks)-1].AddNode(node)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return p.errorAt(p.line, p.off, format, args...)
+}
+
+func (p *parser) errorAt(line, off int, format string, args ...any) error {
+	pos := scanner.Position{Filename: p.filename, Line: line, ColStart: off + 1, ColEnd: off + 1}
+	return comn.NewErrorf(comn.DefaultSyntaxErrCode, comn.SyntaxError, pos, format, args...)
+}
+
+// Removes the comment of a line, the `;` of the strings and chars are kept
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0 && c == '\\':
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ';':
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+func tif[T any](cond bool, a, b T) T {
+	if cond {
+		return a
+	}
+
+	return b
+}
+
+func newParser(filename string) *parser {
+	return &parser{
+		filename: filename,
+		labels:   make(map[string]int),
+		jumps:    make(map[string]int),
+		aliases:  make(map[any]runtime.GDTypable),
+	}
+}
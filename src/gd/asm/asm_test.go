@@ -0,0 +1,255 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package asm_test
+
+import (
+	"bytes"
+	"gdlang/src/comn"
+	"gdlang/src/compiler"
+	"gdlang/src/gd/asm"
+	"gdlang/src/gd/ir"
+	"gdlang/src/test_helper"
+	"gdlang/src/vm"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	comn.PrettyPrintErrors = false
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []string{
+		`pub func main() {
+	print("hello; world", 'a', ';', '\'', "tab\t", "é")
+}`,
+		`typealias Point = {x: int, y: int}
+typealias Json = (int | string | [Json])
+
+func sum(nums: [int]) => int {
+	set total = 0
+	for set n in nums {
+		total += n
+	}
+	return total
+}
+
+pub func main() {
+	set p: Point = {x: 1, y: 2}
+	set j: Json = [1, "a"]
+	set s = {| 1.5, 2.5 |}
+	s << 3.5
+	set xs = [1, 2, 3]
+	xs[0] = 4
+	print(sum([p.x, p.y]), p?.x, s, xs, (1, "a"), j)
+}`,
+		`use math as m
+
+type UserId = int
+
+func f(a: any, ...) {
+	print(a)
+}
+
+pub func main() {
+	set id: UserId = 5 as UserId
+	set a: uint8 = 255 as uint8
+	set b: int16 = -3 as int16
+	set big = 9223372036854775807n + 1n
+	set d = 1.10d * 3
+	print(id, a +% 1, b, big, d, m.abs(-2), [x * 2 for x in [1, 2] if x > 1])
+	f(1, 2, 3)
+	set t = true ? "yes" : "no"
+	if t == "yes" {
+		print(t)
+	} else {
+		print("no")
+	}
+}`,
+	}
+
+	for _, src := range tests {
+		test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+			comp := compiler.NewGDCompiler()
+			defer comp.Dispose()
+
+			err := comp.Compile(tmpDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bytecode := &bytes.Buffer{}
+			err = comp.Root.BuildBytecode(bytecode, comp.Ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The comments with the source positions are skipped
+			listing, err := vm.NewGDVMDisassembler(bytecode.Bytes(), comp.Ctx.SrcMap).Disassemble()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := asm.Assemble("main.gdasm", []byte(listing))
+			if err != nil {
+				t.Fatalf("Expected no error but got %q assembling\n%s", err.Error(), listing)
+			}
+
+			if !bytes.Equal(res, bytecode.Bytes()) {
+				resListing, _ := vm.NewGDVMDisassembler(res, nil).Disassemble()
+				t.Errorf("Expected the same bytecode after the round trip of\n%s\ngot\n%s", listing, resListing)
+			}
+
+			return nil
+		})
+	}
+}
+
+func TestAssembleCompilerArtifacts(t *testing.T) {
+	src := `func double(x: int) => int {
+	return x * 2
+}
+
+pub func main() {
+	for set x in [1, 2] {
+		print(double(x))
+	}
+}`
+
+	test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+		comp := compiler.NewGDCompiler()
+		defer comp.Dispose()
+
+		err := comp.Compile(tmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		outputPath := t.TempDir()
+		err = comp.GenerateArtifacts("main", outputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		listing, err := os.ReadFile(filepath.Join(outputPath, "main.gdasm"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bin, err := ir.ReadGDBin(filepath.Join(outputPath, "main.gdbin"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := asm.Assemble("main.gdasm", listing)
+		if err != nil {
+			t.Fatalf("Expected no error but got %q assembling\n%s", err.Error(), listing)
+		}
+
+		if !bytes.Equal(res, bin.Code) {
+			t.Errorf("Expected the bytecode of the binary file after assembling\n%s", listing)
+		}
+
+		return nil
+	})
+}
+
+func TestAssemble(t *testing.T) {
+	// Hand-written listings without offsets and with custom labels
+	src := `block
+  set x int (int: 0)
+loop:
+  op < (int: 3) x
+  cmpjump %rpop (bool: false) done
+  op + (int: 1) x
+  mov obj_ref(x) %rpop
+  jump loop ; back to the condition
+done:
+  call print (array(any): [x, (string: "a;b")])
+end
+`
+
+	res, err := asm.Assemble("main.gdasm", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listing, err := vm.NewGDVMDisassembler(res, nil).Disassemble()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `00000  block
+00003    set x int (int: 0)
+L00012:
+00012    op < (int: 3) x
+00020    cmpjump %rpop (bool: false) L00047
+00028    op + (int: 1) x
+00036    mov obj_ref(x) %rpop
+00044    jump L00012
+L00047:
+00047    call print (array(any): [x, (string: "a;b")])
+00069  end
+`
+	if listing != expected {
+		t.Errorf("Expected the listing\n%s\ngot\n%s", expected, listing)
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		errMsg string
+	}{
+		{"foo", "main.gdasm 1:1-1 syntax error: unknown instruction `foo`"},
+		{"set? x int (int: 1)", "unknown instruction `set?`"},
+		{"block", "expected the `end` of 1 blocks"},
+		{"end", "1:1-1 syntax error: `end` without a block"},
+		{"jump nowhere", "1:1-1 syntax error: undefined label `nowhere`"},
+		{"jump a\njump b\njump c\njump d", "1:1-1 syntax error: undefined label `a`"},
+		{"a:\na:", "2:1-1 syntax error: label `a` already defined at line 1"},
+		{"lambda func() nil\nret (nil)", "expected a block after the lambda"},
+		{"lambda int", "expected a `func` type, got `int`"},
+		{"ret (int: x)", "invalid `int` literal `x`"},
+		{"ret (int8: 300)", "invalid `int8` literal `300`"},
+		{"ret (char: 'ab')", "invalid char literal"},
+		{"ret (string: \"a)", "unterminated string"},
+		{"ret (bigint: \"1.5\")", "invalid `bigint` literal"},
+		{"ret (foo: 1)", "unknown type `foo`"},
+		{"ret (any: 1)", "objects of type `any` have no literals"},
+		{"ret (int: 1) x", "expected the end of the line, got `x`"},
+		{"ret (array(int): [(int: 1)", "expected `]`, got the end of the line"},
+		{"op ** x y", "unknown operation `**`"},
+		{"use remote math []", "unknown package mode `remote`"},
+		{"mov int (int: 1)", "expected an `obj_ref` type as the target, got `int`"},
+		{"ret #70000", "invalid ident `#70000`"},
+	}
+
+	for _, test := range tests {
+		_, err := asm.Assemble("main.gdasm", []byte(test.src))
+		if err == nil {
+			t.Errorf("Expected an error assembling %q, got none", test.src)
+		} else if !strings.Contains(err.Error(), test.errMsg) {
+			t.Errorf("Expected the error of %q to contain %q, got %q", test.src, test.errMsg, err.Error())
+		}
+	}
+}
@@ -0,0 +1,639 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package asm
+
+import (
+	"bytes"
+	"gdlang/lib/runtime"
+	"gdlang/src/cpu"
+	"gdlang/src/gd/ir"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	opsByName          = make(map[string]runtime.ExprOperationType)
+	packageModesByName = make(map[string]runtime.GDPackageMode)
+	typeCodesByName    = make(map[string]runtime.GDTypableCode)
+	regsByName         = make(map[string]cpu.GDReg)
+)
+
+func init() {
+	for op, name := range runtime.ExprOperationMap {
+		opsByName[name] = op
+	}
+
+	for mode, name := range runtime.PackageModeMap {
+		packageModesByName[name] = mode
+	}
+
+	for code, name := range runtime.GDTypeCodeMap {
+		if name != "" {
+			typeCodesByName[name] = runtime.GDTypableCode(code)
+		}
+	}
+
+	for reg := cpu.RPop; reg <= cpu.Ri; reg++ {
+		regsByName[cpu.GetCPURegName(reg)] = reg
+	}
+}
+
+// Payload of an object, it is written after the type of the object
+type gdasmValue struct {
+	lit   string
+	bytes []byte
+	ir.GDIRBaseNode
+}
+
+func (v *gdasmValue) BuildAssembly(padding string) string { return padding + v.lit }
+
+func (v *gdasmValue) BuildBytecode(bytecode *bytes.Buffer, ctx *ir.GDIRContext) error {
+	_, err := bytecode.Write(v.bytes)
+	return err
+}
+
+// Idents are written by their mode, `#` for the numeric idents
+// and `%` for the registers, the other idents are names
+func (p *parser) parseIdent() (runtime.GDIdent, error) {
+	p.skipSpaces()
+	if p.peek() == '"' {
+		name, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.NewGDStringIdent(name), nil
+	}
+
+	start := p.off
+	word := p.word()
+	switch {
+	case word == "":
+		return nil, p.errorf("expected an ident")
+	case word[0] == '#':
+		n, err := strconv.ParseUint(word[1:], 10, 16)
+		if err != nil {
+			return nil, p.errorAt(p.line, start, "invalid ident `%s`", word)
+		}
+
+		return runtime.NewGDUInt16Ident(uint16(n)), nil
+	case word[0] == '%':
+		if reg, found := regsByName[word[1:]]; found {
+			return runtime.NewGDByteIdent(byte(reg)), nil
+		}
+
+		n, err := strconv.ParseUint(word[1:], 10, 8)
+		if err != nil {
+			return nil, p.errorAt(p.line, start, "invalid register `%s`", word)
+		}
+
+		return runtime.NewGDByteIdent(byte(n)), nil
+	}
+
+	return runtime.NewGDStringIdent(word), nil
+}
+
+// Types are written by the names of their codes, the
+// composite types list their parts, e.g. `array(int)`
+func (p *parser) parseType() (runtime.GDTypable, error) {
+	p.skipSpaces()
+	start := p.off
+	name := p.word()
+	code, found := typeCodesByName[name]
+	if !found {
+		return nil, p.errorAt(p.line, start, "unknown type `%s`", name)
+	}
+
+	switch code {
+	case runtime.GDStructTypeCode:
+		attrs := make([]runtime.GDStructAttrType, 0)
+		err := p.parseList('(', ')', func() error {
+			ident, typ, err := p.parseTypedIdent()
+			attrs = append(attrs, runtime.GDStructAttrType{Ident: ident, Type: typ})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.NewGDStructType(attrs...), nil
+	case runtime.GDNominalTypeCode:
+		err := p.expect('(')
+		if err != nil {
+			return nil, err
+		}
+
+		ident, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.expect(',')
+		if err != nil {
+			return nil, err
+		}
+
+		p.skipSpaces()
+		start := p.off
+		decl, parseErr := strconv.ParseUint(p.word(), 10, 32)
+		if parseErr != nil {
+			return nil, p.errorAt(p.line, start, "invalid nominal declaration `%s`", p.src[start:p.off])
+		}
+
+		err = p.expect(',')
+		if err != nil {
+			return nil, err
+		}
+
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.NewGDNominalType(ident, uint32(decl), typ), p.expect(')')
+	case runtime.GDObjRefTypeCode, runtime.GDTypeRefTypeCode:
+		err := p.expect('(')
+		if err != nil {
+			return nil, err
+		}
+
+		ident, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		if code == runtime.GDObjRefTypeCode {
+			return runtime.NewGDObjRefType(ident), p.expect(')')
+		}
+
+		return runtime.NewRefType(ident), p.expect(')')
+	case runtime.GDArrayTypeCode, runtime.GDSetTypeCode, runtime.GDUnionTypeCode, runtime.GDTupleTypeCode:
+		types := make([]runtime.GDTypable, 0)
+		err := p.parseList('(', ')', func() error {
+			typ, err := p.parseType()
+			types = append(types, typ)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		switch code {
+		case runtime.GDUnionTypeCode:
+			return runtime.NewGDUnionType(types...), nil
+		case runtime.GDTupleTypeCode:
+			// Not created with the constructor, which adds a type to the empty tuples
+			return runtime.GDTupleType(types), nil
+		}
+
+		if len(types) != 1 {
+			return nil, p.errorAt(p.line, start, "expected a type of the elements of `%s`", name)
+		}
+
+		if code == runtime.GDArrayTypeCode {
+			return runtime.NewGDArrayType(types[0]), nil
+		}
+
+		return runtime.NewGDSetType(types[0]), nil
+	case runtime.GDLambdaTypeCode:
+		args := make(runtime.GDLambdaArgTypes, 0)
+		isVariadic := false
+		err := p.parseList('(', ')', func() error {
+			if isVariadic {
+				return p.errorf("expected `)` after `...`")
+			}
+
+			if strings.HasPrefix(p.src[p.off:], "...") {
+				p.off += len("...")
+				isVariadic = true
+				return nil
+			}
+
+			ident, typ, err := p.parseTypedIdent()
+			args = append(args, runtime.GDLambdaArgType{Key: ident, Value: typ})
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		returnType, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.NewGDLambdaType(args, returnType, isVariadic), nil
+	}
+
+	return runtime.GDType(code), nil
+}
+
+// Reads an ident followed by its type, e.g. `x: int`
+func (p *parser) parseTypedIdent() (runtime.GDIdent, runtime.GDTypable, error) {
+	ident, err := p.parseIdent()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = p.expect(':')
+	if err != nil {
+		return nil, nil, err
+	}
+
+	typ, err := p.parseType()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ident, typ, nil
+}
+
+func (p *parser) parseObjects(count int) ([]ir.GDIRNode, error) {
+	objs := make([]ir.GDIRNode, count)
+	for i := range count {
+		obj, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+
+		objs[i] = obj
+	}
+
+	return objs, nil
+}
+
+// Objects are written with their type, e.g. `(int: 1)`,
+// and the references to other objects by their ident
+func (p *parser) parseObject() (ir.GDIRNode, error) {
+	p.skipSpaces()
+	if p.peek() != '(' {
+		ident, err := p.parseIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		return ir.NewGDIRIdentObject(ident, runtime.GDZNil, nil), nil
+	}
+
+	p.off++
+	typ, err := p.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	if typ.GetCode() == runtime.GDNilTypeCode {
+		return ir.NewGDIRObject(runtime.GDZNil, nil), p.expect(')')
+	}
+
+	err = p.expect(':')
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := p.parseValue(typ, p.resolveAlias(typ))
+	if err != nil {
+		return nil, err
+	}
+
+	return obj, p.expect(')')
+}
+
+// Parses the value of an object by the type that it is read with,
+// the aliased type of a type reference if the alias is known
+func (p *parser) parseValue(typ, valueType runtime.GDTypable) (ir.GDIRNode, error) {
+	p.skipSpaces()
+	code := valueType.GetCode()
+	switch {
+	case code == runtime.GDTupleTypeCode || code == runtime.GDArrayTypeCode || code == runtime.GDSetTypeCode || code == runtime.GDStructTypeCode,
+		code == runtime.GDTypeRefTypeCode && p.peek() == '[':
+		objs := make([]ir.GDIRNode, 0)
+		err := p.parseList('[', ']', func() error {
+			obj, err := p.parseObject()
+			objs = append(objs, obj)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return ir.NewGDIRIterableObject(typ, objs), nil
+	case code == runtime.GDNominalTypeCode || code == runtime.GDSpreadableTypeCode,
+		code == runtime.GDTypeRefTypeCode && p.peek() == '(':
+		obj, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+
+		return ir.NewGDIRObjectWithType(typ, obj, nil), nil
+	}
+
+	start := p.off
+	value, err := p.parseLiteral(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return ir.NewGDIRObjectWithType(typ, &gdasmValue{p.src[start:p.off], value, ir.GDIRBaseNode{}}, nil), nil
+}
+
+// Parses a literal into the bytes of its value
+func (p *parser) parseLiteral(code runtime.GDTypableCode) ([]byte, error) {
+	start := p.off
+	bytecode := &bytes.Buffer{}
+	invalidErr := func() error {
+		return p.errorAt(p.line, start, "invalid `%s` literal `%s`", runtime.GDTypeCodeMap[code], p.src[start:p.off])
+	}
+
+	var err error
+	switch code {
+	case runtime.GDIntTypeCode, runtime.GDInt8TypeCode, runtime.GDInt16TypeCode, runtime.GDInt32TypeCode, runtime.GDInt64TypeCode:
+		bitSize := map[runtime.GDTypableCode]int{runtime.GDInt8TypeCode: 8, runtime.GDInt16TypeCode: 16, runtime.GDInt32TypeCode: 32}[code]
+		n, parseErr := strconv.ParseInt(p.word(), 10, bitSize)
+		if parseErr != nil {
+			return nil, invalidErr()
+		}
+
+		switch code {
+		case runtime.GDIntTypeCode:
+			err = ir.WriteInt(bytecode, int(n))
+		case runtime.GDInt8TypeCode:
+			err = ir.WriteInt8(bytecode, int8(n))
+		case runtime.GDInt16TypeCode:
+			err = ir.WriteInt16(bytecode, int16(n))
+		case runtime.GDInt32TypeCode:
+			err = ir.WriteInt32(bytecode, int32(n))
+		case runtime.GDInt64TypeCode:
+			err = ir.WriteInt64(bytecode, n)
+		}
+	case runtime.GDUInt8TypeCode, runtime.GDUInt16TypeCode, runtime.GDUInt32TypeCode, runtime.GDUInt64TypeCode:
+		bitSize := map[runtime.GDTypableCode]int{runtime.GDUInt8TypeCode: 8, runtime.GDUInt16TypeCode: 16, runtime.GDUInt32TypeCode: 32, runtime.GDUInt64TypeCode: 64}[code]
+		n, parseErr := strconv.ParseUint(p.word(), 10, bitSize)
+		if parseErr != nil {
+			return nil, invalidErr()
+		}
+
+		switch code {
+		case runtime.GDUInt8TypeCode:
+			err = ir.WriteByte(bytecode, byte(n))
+		case runtime.GDUInt16TypeCode:
+			err = ir.WriteUInt16(bytecode, uint16(n))
+		case runtime.GDUInt32TypeCode:
+			err = ir.WriteUInt32(bytecode, uint32(n))
+		case runtime.GDUInt64TypeCode:
+			err = ir.WriteUInt64(bytecode, n)
+		}
+	case runtime.GDFloat32TypeCode, runtime.GDFloat64TypeCode:
+		bitSize := tif(code == runtime.GDFloat32TypeCode, 32, 64)
+		n, parseErr := strconv.ParseFloat(p.word(), bitSize)
+		if parseErr != nil {
+			return nil, invalidErr()
+		}
+
+		if bitSize == 32 {
+			err = ir.WriteFloat32(bytecode, float32(n))
+		} else {
+			err = ir.WriteFloat64(bytecode, n)
+		}
+	case runtime.GDComplex64TypeCode, runtime.GDComplex128TypeCode:
+		// Complex numbers are written between parentheses, e.g. `(1+2i)`
+		if p.peek() == '(' {
+			end := strings.IndexByte(p.src[p.off:], ')')
+			if end == -1 {
+				return nil, p.errorf("expected `)`")
+			}
+
+			p.off += end + 1
+		} else {
+			p.word()
+		}
+
+		bitSize := tif(code == runtime.GDComplex64TypeCode, 64, 128)
+		n, parseErr := strconv.ParseComplex(p.src[start:p.off], bitSize)
+		if parseErr != nil {
+			return nil, invalidErr()
+		}
+
+		if bitSize == 64 {
+			err = ir.WriteComplex64(bytecode, complex64(n))
+		} else {
+			err = ir.WriteComplex128(bytecode, n)
+		}
+	case runtime.GDBoolTypeCode:
+		b, parseErr := strconv.ParseBool(p.word())
+		if parseErr != nil || !strings.HasPrefix(p.src[start:p.off], "t") && !strings.HasPrefix(p.src[start:p.off], "f") {
+			return nil, invalidErr()
+		}
+
+		err = ir.WriteBool(bytecode, b)
+	case runtime.GDStringTypeCode, runtime.GDBigIntTypeCode, runtime.GDDecimalTypeCode:
+		if p.peek() != '"' {
+			return nil, p.errorf("expected a string")
+		}
+
+		str, quoteErr := p.quoted()
+		if quoteErr != nil {
+			return nil, quoteErr
+		}
+
+		switch code {
+		case runtime.GDBigIntTypeCode:
+			_, quoteErr = runtime.NewGDBigIntFromString(str)
+		case runtime.GDDecimalTypeCode:
+			_, quoteErr = runtime.NewGDDecimalFromString(str)
+		}
+
+		if quoteErr != nil {
+			return nil, invalidErr()
+		}
+
+		err = ir.WriteString(bytecode, str)
+	case runtime.GDCharTypeCode:
+		if p.peek() != '\'' {
+			return nil, p.errorf("expected a char")
+		}
+
+		str, quoteErr := p.quoted()
+		if quoteErr != nil {
+			return nil, quoteErr
+		}
+
+		char, size := utf8.DecodeRuneInString(str)
+		if size == 0 || size != len(str) {
+			return nil, invalidErr()
+		}
+
+		err = ir.WriteChar(bytecode, char)
+	default:
+		return nil, p.errorAt(p.line, start, "objects of type `%s` have no literals", runtime.GDTypeCodeMap[code])
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bytecode.Bytes(), nil
+}
+
+// Follows the aliases of the type references, the last alias of an ident is used
+func (p *parser) resolveAlias(typ runtime.GDTypable) runtime.GDTypable {
+	resolved := typ
+	for range len(p.aliases) + 1 {
+		ref, isRef := resolved.(runtime.GDIdentRefType)
+		if !isRef {
+			return resolved
+		}
+
+		alias, found := p.aliases[ref.GetRawValue()]
+		if !found {
+			break
+		}
+
+		resolved = alias
+	}
+
+	// Unknown or recursive references are kept
+	return typ
+}
+
+// Reads the items of a list between the open and close characters, separated by commas
+func (p *parser) parseList(open, close byte, item func() error) error {
+	err := p.expect(open)
+	if err != nil {
+		return err
+	}
+
+	p.skipSpaces()
+	if p.peek() == close {
+		p.off++
+		return nil
+	}
+
+	for {
+		err := item()
+		if err != nil {
+			return err
+		}
+
+		p.skipSpaces()
+		if p.peek() != ',' {
+			return p.expect(close)
+		}
+
+		p.off++
+		p.skipSpaces()
+	}
+}
+
+// Reads a double or single quoted literal and unquotes it
+func (p *parser) quoted() (string, error) {
+	lit, err := strconv.QuotedPrefix(p.src[p.off:])
+	if err != nil && p.peek() == '\'' {
+		return "", p.errorf("invalid char literal")
+	} else if err != nil {
+		return "", p.errorf("unterminated string")
+	}
+
+	p.off += len(lit)
+
+	if lit[0] == '\'' {
+		char, _, tail, err := strconv.UnquoteChar(lit[1:len(lit)-1], '\'')
+		if err != nil || tail != "" {
+			return "", p.errorf("invalid char literal `%s`", lit)
+		}
+
+		return string(char), nil
+	}
+
+	str, err := strconv.Unquote(lit)
+	if err != nil {
+		return "", p.errorf("invalid string `%s`", lit)
+	}
+
+	return str, nil
+}
+
+// Reads the characters until a space or a delimiter
+func (p *parser) word() string {
+	p.skipSpaces()
+	start := p.off
+	for p.off < len(p.src) && !unicode.IsSpace(rune(p.src[p.off])) && !strings.ContainsRune("()[],:", rune(p.src[p.off])) {
+		p.off++
+	}
+
+	return p.src[start:p.off]
+}
+
+// Reads the word if it is the next one
+func (p *parser) acceptWord(word string) bool {
+	start := p.off
+	if p.word() == word {
+		return true
+	}
+
+	p.off = start
+
+	return false
+}
+
+func (p *parser) expect(c byte) error {
+	p.skipSpaces()
+	if p.peek() != c {
+		return p.unexpected("`" + string(c) + "`")
+	}
+
+	p.off++
+
+	return nil
+}
+
+func (p *parser) expectEnd() error {
+	p.skipSpaces()
+	if p.off < len(p.src) {
+		return p.unexpected("the end of the line")
+	}
+
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	if p.off >= len(p.src) {
+		return p.errorf("expected %s, got the end of the line", expected)
+	}
+
+	return p.errorf("expected %s, got `%s`", expected, string(p.src[p.off]))
+}
+
+func (p *parser) peek() byte {
+	if p.off >= len(p.src) {
+		return 0
+	}
+
+	return p.src[p.off]
+}
+
+func (p *parser) skipSpaces() {
+	for p.off < len(p.src) && unicode.IsSpace(rune(p.src[p.off])) {
+		p.off++
+	}
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
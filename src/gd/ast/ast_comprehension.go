@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ast
+
+import (
+	"fmt"
+	"gdlang/src/gd/scanner"
+)
+
+// Comprehension
+// e.g. [x * 2 for x in xs if x > 0]
+
+type NodeComprehension struct {
+	Expr       Node
+	Sets       Node
+	IterExpr   Node
+	Conditions []Node
+
+	// The comprehension is desugared into a `for in` loop
+	// that adds each element to the accumulator collection,
+	// e.g. for set x in xs { if x > 0 { acc << x * 2 } }
+	Acc   *NodeIdent
+	ForIn *NodeForIn
+
+	exprStart, exprEnd Node
+	BaseNode
+}
+
+func (c *NodeComprehension) GetPosition() scanner.Position {
+	return GetStartEndPosition([]Node{c.exprStart, c.exprEnd})
+}
+
+func NewNodeComprehension(exprStart, exprEnd Node, expr Node, setObjs Node, iterExpr Node, conditions []Node) *NodeComprehension {
+	// The accumulator ident can't be declared in the source code,
+	// and the position makes it unique for nested comprehensions
+	pos := exprStart.GetPosition()
+	accLit := fmt.Sprintf("@comprehension:%s:%d:%d", pos.Filename, pos.Line, pos.ColStart)
+	acc := NewNodeIdent(NewNodeTokenInfo(scanner.IDENT, pos, accLit))
+
+	var body Node = NewNodeMutCollectionOp(MutableCollectionAddOp, acc, expr)
+	if len(conditions) > 0 {
+		nodeIf := NewNodeIf(conditions, NewNodeBlock([]Node{body}))
+		body = NewNodeIfElse(nodeIf, []Node{}, nil)
+	}
+
+	forIn := NewNodeForIn(setObjs, iterExpr, NewNodeBlock([]Node{body})).(*NodeForIn)
+
+	comprehension := &NodeComprehension{expr, setObjs, iterExpr, conditions, acc, forIn, exprStart, exprEnd, BaseNode{}}
+	forIn.SetParentNode(comprehension)
+
+	return comprehension
+}
@@ -48,6 +48,10 @@ func NewNodeLambda(funcType *runtime.GDLambdaType, block *NodeBlock) *NodeLambda
 type NodeFunc struct {
 	IsPub bool
 	Ident *NodeIdent
+	// Operator functions overload an expression operation for its argument types,
+	// e.g. func +(a: Vec, b: Vec) => Vec { ... }
+	IsOperator bool
+	Op         runtime.ExprOperationType
 	*NodeLambda
 	BaseNode
 }
@@ -57,12 +61,61 @@ func (f *NodeFunc) GetPosition() scanner.Position {
 }
 
 func NewNodeFunc(isPublic bool, ident *NodeIdent, funcType *runtime.GDLambdaType, block *NodeBlock) *NodeFunc {
-	nodeFunc := &NodeFunc{isPublic, ident, NewNodeLambda(funcType, block), BaseNode{nodeType: NodeTypeFunc}}
+	nodeFunc := &NodeFunc{isPublic, ident, false, 0, NewNodeLambda(funcType, block), BaseNode{nodeType: NodeTypeFunc}}
 	block.SetParentNode(nodeFunc)
 
 	return nodeFunc
 }
 
+// Map of the tokens that can be overloaded by an operator function
+var operatorFuncTokens = map[scanner.Token]runtime.ExprOperationType{
+	scanner.ADD: runtime.ExprOperationAdd,
+	scanner.SUB: runtime.ExprOperationSubtract,
+	scanner.MUL: runtime.ExprOperationMultiply,
+	scanner.QUO: runtime.ExprOperationQuo,
+	scanner.REM: runtime.ExprOperationRem,
+	scanner.EQL: runtime.ExprOperationEqual,
+	scanner.NEQ: runtime.ExprOperationNotEqual,
+	scanner.LSS: runtime.ExprOperationLess,
+	scanner.GTR: runtime.ExprOperationGreater,
+	scanner.LEQ: runtime.ExprOperationLessEqual,
+	scanner.GEQ: runtime.ExprOperationGreaterEqual,
+	scanner.NOT: runtime.ExprOperationNot,
+}
+
+// Creates a function that overloads the operation of the token,
+// the function identifier is derived from the operation and the declared argument types,
+// so several overloads of the same operator can live in the same scope.
+func NewNodeOperatorFunc(isPublic bool, opToken *NodeTokenInfo, funcType *runtime.GDLambdaType, block *NodeBlock) *NodeFunc {
+	op, ok := operatorFuncTokens[opToken.Token]
+	if !ok {
+		panic("NewNodeOperatorFunc: Invalid operator token `" + opToken.Lit + "`")
+	}
+
+	argTypes := make([]runtime.GDTypable, len(funcType.ArgTypes))
+	for i, arg := range funcType.ArgTypes {
+		argTypes[i] = arg.Value
+	}
+
+	// A single argument overloads the unary form of the operator
+	if len(argTypes) == 1 {
+		switch op {
+		case runtime.ExprOperationAdd:
+			op = runtime.ExprOperationUnaryPlus
+		case runtime.ExprOperationSubtract:
+			op = runtime.ExprOperationUnaryMinus
+		}
+	}
+
+	identToken := NewNodeTokenInfo(scanner.IDENT, opToken.Position, runtime.OperatorIdent(op, argTypes...).ToString())
+
+	nodeFunc := NewNodeFunc(isPublic, NewNodeIdent(identToken), funcType, block)
+	nodeFunc.IsOperator = true
+	nodeFunc.Op = op
+
+	return nodeFunc
+}
+
 type NodeStructAttr struct {
 	Ident *NodeIdent
 	Expr  Node
@@ -19,7 +19,10 @@
 
 package ast
 
-import "gdlang/lib/runtime"
+import (
+	"gdlang/lib/runtime"
+	"path/filepath"
+)
 
 func buildFuncType(args []Node, variadic bool, returnType runtime.GDTypable) *runtime.GDLambdaType {
 	funcArgTypes := make(runtime.GDLambdaArgTypes, len(args))
@@ -31,3 +34,35 @@ func buildFuncType(args []Node, variadic bool, returnType runtime.GDTypable) *ru
 
 	return runtime.NewGDLambdaType(funcArgTypes, returnType, variadic)
 }
+
+// The attribute belongs to the package (directory) of the file where it is declared
+func buildStructAttrType(isPub, isConst bool, ident *NodeIdent, typ runtime.GDTypable) runtime.GDStructAttrType {
+	return runtime.GDStructAttrType{
+		Ident:   runtime.NewGDStringIdent(ident.Lit),
+		Type:    typ,
+		IsPub:   isPub,
+		IsConst: isConst,
+		PkgPath: filepath.Dir(ident.Position.Filename),
+	}
+}
+
+func buildComprehensionSet(node Node) *NodeSet {
+	identWithType, ok := node.(*NodeIdentWithType)
+	if !ok {
+		panic("comprehension_sets: Invalid `*NodeIdentWithType` object")
+	}
+
+	return NewNodeSet(false, false, identWithType, nil)
+}
+
+// The pipeline `a |> f(b)` is desugared into the call `f(a, b)`,
+// and `a |> f` into the call `f(a)`. A parenthesized call, as in
+// `a |> (f(b))`, is evaluated first and its result is called with `a`
+func buildPipeCall(arg Node, target Node) *NodeCallExpr {
+	if call, isCall := target.(*NodeCallExpr); isCall && !call.IsParenthesized {
+		call.Args = append([]Node{arg}, call.Args...)
+		return call
+	}
+
+	return NewNodeCallExpr(target, []Node{arg})
+}
@@ -37,29 +37,34 @@ import (
        gd_type_list         []runtime.GDTypable
 }
 
-%token  <token>                    LAS LLSHIFT LRSHIFT LIDENT LINT LFLOAT LSTRING LIMAG LCHAR LCOMMENT LMUL
+%token  <token>                    LAS LLSHIFT LRSHIFT LIDENT LINT LFLOAT LSTRING LIMAG LBIGINT LDECIMAL LCHAR LCOMMENT LMUL
 %token  <token>                    LADD LSUB LQUO LREM
+%token  <token>                    LADD_WRAP LSUB_WRAP LMUL_WRAP LADD_SAT LSUB_SAT LMUL_SAT
 %token  <token>                    LQMARK LNSAFE LADD_ASSIGN LSUB_ASSIGN LMUL_ASSIGN LQUO_ASSIGN LREM_ASSIGN
 %token  <token>                    LARROW LINC LDEC
-%token  <token>                    LLAND LOR LLOR LNOT
+%token  <token>                    LLAND LAND LOR LLOR LNOT LPIPE
 %token  <token>                    LEQL LLSS LGTR LASSIGN
 %token  <token>                    LNEQ LLEQ LGEQ LELLIPSIS
 %token  <token>                    LLPAREN LLBRACK LLBRACE LCOMMA LPERIOD LRPAREN LRBRACK LRBRACE LSEMICOLON LCOLON LCOLONCOLON
-%token  <token>                    LUSE LTYPEALIAS LSET LPUB LCONST LELSE LFOR LIN LFUNC LIF LBREAK LRETURN
-%token  <token>                    LTANY LTBOOL LTINT LTFLOAT LTCOMPLEX LTSTRING LTCHAR
+%token  <token>                    LLSET_BRACE LRSET_BRACE
+%token  <token>                    LUSE LTYPEALIAS LTYPE LSET LPUB LCONST LELSE LFOR LIN LFUNC LIF LBREAK LRETURN
+%token  <token>                    LTANY LTBOOL LTINT LTFLOAT LTCOMPLEX LTSTRING LTCHAR LTBIGINT LTDECIMAL
+%token  <token>                    LTINT8 LTINT16 LTINT32 LTINT64 LTUINT8 LTUINT16 LTUINT32 LTUINT64 LTBYTE
 %token  <token>                    LTRUE LFALSE LNIL
 
 %type   <node>                     file_body_stmt break_stmt return_stmt stmt expr pseudocall uexpr pexpr 
-%type   <node>                     set mut_collection_op literal update_obj block block_stmt func lambda tuple array
+%type   <node>                     set mut_collection_op literal update_obj block block_stmt func lambda tuple array set_literal
 %type   <node_list>                optional_expr_list optional_file_body_stmt_list file_body_stmt_list expr_list tuple_expr_list optional_block_stmt_list block_stmt_list
 
 %type   <node>                     struct struct_attr for_if_stmt for_in_stmt if_expr if_stmt elseif_stmt else_stmt selexpr ident file use ident_with_type ident_with_optional_type optional_assign_expr const_ident_with_optional_type
 %type   <node_list>                struct_attr_list elseif_stmt_list optional_file_package_list use_list ident_access_list ident_list func_arg_list optional_func_arg_list set_expr_list const_ident_with_optional_type_list set_expr_option_list
-%type   <node>                     typealias cast_expr
+%type   <node>                     typealias cast_expr comprehension_sets
+
+%type   <token>                    overloadable_op
 
 %type   <flag>                     safe_accessor optional_const optional_pub optional_trailing_comma
 
-%type   <gd_type>                  optional_return_type func_type type union_type tuple_type unary_type array_type struct_type struct_attr_type obj_optional_type
+%type   <gd_type>                  optional_return_type func_type type union_type tuple_type unary_type array_type set_type struct_type struct_attr_type obj_optional_type
 %type   <gd_type_list>             struct_attr_type_list type_list tuple_attr_type_list
 
 %error LSET LIDENT LCOLON LNIL:
@@ -71,11 +76,14 @@ import (
 %left  LAS
 %left  LLSHIFT LRSHIFT
 %left  LQMARK LCOLON
+%left  LPIPE
 %left  LLOR
 %left  LLAND
-%left  LEQL LNEQ LLSS LGTR LLEQ LGEQ
-%left  LADD LSUB
-%left  LMUL LQUO LREM
+%left  LEQL LNEQ LLSS LGTR LLEQ LGEQ LIN
+%left  LOR
+%left  LAND
+%left  LADD LSUB LADD_WRAP LSUB_WRAP LADD_SAT LSUB_SAT
+%left  LMUL LQUO LREM LMUL_WRAP LMUL_SAT
 
 %left  LLPAREN
 %left  LRPAREN
@@ -117,6 +125,12 @@ use:
        LUSE ident_access_list LLBRACE ident_list LRBRACE {
               $$ = NewNodePackage($2, $4)
        }
+       | LUSE ident_access_list LLBRACE LMUL LRBRACE {
+              $$ = NewNodeWildcardPackage($2)
+       }
+       | LUSE ident_access_list LAS ident {
+              $$ = NewNodeAliasPackage($2, $4.(*NodeIdent))
+       }
 ;
 
 ident_list:
@@ -202,6 +216,9 @@ typealias:
        LTYPEALIAS ident LASSIGN type {
               $$ = NewNodeTypeAlias(false, $2.(*NodeIdent), $4)
        }
+       | LTYPE ident LASSIGN type {
+              $$ = NewNodeNominalTypeAlias(false, $2.(*NodeIdent), $4)
+       }
 ;
 
 // Statements
@@ -294,7 +311,10 @@ const_ident_with_optional_type:
 ;
 
 optional_assign_expr:
-       LASSIGN expr {
+       // `in` ends the assigned expression, so the objects of a `for in` loop
+       // can be initialized, e.g. `for set i = 0, x in xs`. A membership
+       // check in a declaration is parenthesized, e.g. `set a = (x in xs)`
+       LASSIGN expr %prec LIN {
               $$ = $2
        }
        | /* empty */ {
@@ -368,15 +388,30 @@ unary_type:
        | LTANY              { $$ = runtime.GDAnyType                  }
        | LTSTRING           { $$ = runtime.GDStringType               }
        | LTCHAR             { $$ = runtime.GDCharType                 }
+       | LTBIGINT           { $$ = runtime.GDBigIntType               }
+       | LTDECIMAL          { $$ = runtime.GDDecimalType              }
+       | LTINT8             { $$ = runtime.GDInt8Type                 }
+       | LTINT16            { $$ = runtime.GDInt16Type                }
+       | LTINT32            { $$ = runtime.GDInt32Type                }
+       | LTINT64            { $$ = runtime.GDInt64Type                }
+       | LTUINT8            { $$ = runtime.GDUInt8Type                }
+       | LTUINT16           { $$ = runtime.GDUInt16Type               }
+       | LTUINT32           { $$ = runtime.GDUInt32Type               }
+       | LTUINT64           { $$ = runtime.GDUInt64Type               }
+       | LTBYTE             { $$ = runtime.GDByteType                 }
        | LIDENT             { $$ = runtime.NewStrRefType($1.Lit)      }
        | tuple_type         { $$ = $1                                 }
        | array_type         { $$ = $1                                 }
+       | set_type           { $$ = $1                                 }
        | struct_type        { $$ = $1                                 }
        | LFUNC func_type    { $$ = $2                                 }
 ;
 
 union_type:
-       type LOR type {
+       union_type LOR type {
+              $$ = runtime.NewGDUnionType(append($1.(runtime.GDUnionType), $3)...)
+       }
+       | type LOR type {
               if cT, isCT := $1.(runtime.GDUnionType); isCT {
                      $$ = runtime.NewGDUnionType(append(cT, $3)...)
               } else {
@@ -421,6 +456,12 @@ array_type:
        }
 ;
 
+set_type:
+       LSET LLSS type LGTR {
+              $$ = runtime.NewGDSetType($3)
+       }
+;
+
 struct_type:
        LLBRACE struct_attr_type_list optional_trailing_comma LRBRACE {
               attrTypes := make([]runtime.GDStructAttrType, len($2))
@@ -483,9 +524,8 @@ struct_attr_type_list:
 ;
 
 struct_attr_type:
-       ident LCOLON type {
-              ident := runtime.NewGDStringIdent($1.(*NodeIdent).Lit)
-              $$ = runtime.GDStructAttrType{Ident: ident, Type: $3}
+       optional_pub optional_const ident LCOLON type {
+              $$ = buildStructAttrType($1, $2, $3.(*NodeIdent), $5)
        }
 ;
 
@@ -558,6 +598,17 @@ func:
        LFUNC ident func_type block {
               $$ = NewNodeFunc(true, $2.(*NodeIdent), $3.(*runtime.GDLambdaType), $4.(*NodeBlock))
        }
+       // Operator function
+       // e.g. func +(a: Vec, b: Vec) => Vec { ... }
+       | LFUNC overloadable_op func_type block {
+              $$ = NewNodeOperatorFunc(true, $2, $3.(*runtime.GDLambdaType), $4.(*NodeBlock))
+       }
+;
+
+overloadable_op:
+       LADD | LSUB | LMUL | LQUO | LREM
+       | LEQL | LNEQ | LLSS | LGTR | LLEQ | LGEQ
+       | LNOT
 ;
 
 // Expression
@@ -579,6 +630,9 @@ expr:
        | if_expr          // Ternary if (cond ? expr : expr)
        | cast_expr        // Type cast (expr as type)
        | mut_collection_op // Add or remove from a collection (<< | >>)
+       | expr LPIPE expr { // |>
+              $$ = buildPipeCall($1, $3)
+       }
        | expr LLOR expr { // ||
               $$ = NewNodeExprOperation(runtime.ExprOperationOr, $1, $3)
        }
@@ -603,6 +657,15 @@ expr:
        | expr LGEQ expr { // >=
               $$ = NewNodeExprOperation(runtime.ExprOperationGreaterEqual, $1, $3)
        }
+       | expr LIN expr { // in
+              $$ = NewNodeExprOperation(runtime.ExprOperationIn, $1, $3)
+       }
+       | expr LOR expr { // |
+              $$ = NewNodeExprOperation(runtime.ExprOperationUnion, $1, $3)
+       }
+       | expr LAND expr { // &
+              $$ = NewNodeExprOperation(runtime.ExprOperationIntersection, $1, $3)
+       }
        | expr LADD expr { // +
               $$ = NewNodeExprOperation(runtime.ExprOperationAdd, $1, $3)
        }
@@ -618,6 +681,24 @@ expr:
        | expr LREM expr { // %
               $$ = NewNodeExprOperation(runtime.ExprOperationRem, $1, $3)
        }
+       | expr LADD_WRAP expr { // +%
+              $$ = NewNodeExprOperation(runtime.ExprOperationAddWrap, $1, $3)
+       }
+       | expr LSUB_WRAP expr { // -%
+              $$ = NewNodeExprOperation(runtime.ExprOperationSubtractWrap, $1, $3)
+       }
+       | expr LMUL_WRAP expr { // *%
+              $$ = NewNodeExprOperation(runtime.ExprOperationMultiplyWrap, $1, $3)
+       }
+       | expr LADD_SAT expr { // +|
+              $$ = NewNodeExprOperation(runtime.ExprOperationAddSat, $1, $3)
+       }
+       | expr LSUB_SAT expr { // -|
+              $$ = NewNodeExprOperation(runtime.ExprOperationSubtractSat, $1, $3)
+       }
+       | expr LMUL_SAT expr { // *|
+              $$ = NewNodeExprOperation(runtime.ExprOperationMultiplySat, $1, $3)
+       }
 ;
 
 uexpr:
@@ -630,6 +711,9 @@ uexpr:
 pexpr:
        selexpr
        | LLPAREN expr LRPAREN {
+              if call, isCall := $2.(*NodeCallExpr); isCall {
+                     call.IsParenthesized = true
+              }
               $$ = $2
        }
 ;
@@ -648,6 +732,7 @@ selexpr:
        | ident
        | tuple
        | array
+       | set_literal
        | struct
        | lambda
        | pexpr LELLIPSIS {
@@ -706,6 +791,8 @@ literal:
        | LTRUE       { $$ = NewNodeLiteral($1)  }
        | LFALSE      { $$ = NewNodeLiteral($1)  }
        | LIMAG       { $$ = NewNodeLiteral($1)  }
+       | LBIGINT     { $$ = NewNodeLiteral($1)  }
+       | LDECIMAL    { $$ = NewNodeLiteral($1)  }
        | LCHAR       { $$ = NewNodeLiteral($1)  }
 ;
 
@@ -772,6 +859,34 @@ array:
        LLBRACK optional_expr_list LRBRACK {
               $$ = NewNodeArray($1, $3, $2)
        }
+       // Array comprehension
+       // e.g. [x * 2 for x in xs if x > 0]
+       | LLBRACK expr LFOR comprehension_sets LIN expr LRBRACK {
+              $$ = NewNodeComprehension($1, $7, $2, $4, $6, nil)
+       }
+       | LLBRACK expr LFOR comprehension_sets LIN expr LIF expr LRBRACK {
+              $$ = NewNodeComprehension($1, $9, $2, $4, $6, []Node{$8})
+       }
+;
+
+// Set
+// e.g. {| 1, 2, 3 |}
+
+set_literal:
+       LLSET_BRACE optional_expr_list LRSET_BRACE {
+              $$ = NewNodeSetLiteral($1, $3, $2)
+       }
+;
+
+// Objects declared by a comprehension, either the element or the index and the element
+// e.g. x, x: int or i, x
+comprehension_sets:
+       ident_with_optional_type {
+              $$ = NewNodeSets([]Node{buildComprehensionSet($1)})
+       }
+       | ident_with_optional_type LCOMMA ident_with_optional_type {
+              $$ = NewNodeSets([]Node{buildComprehensionSet($1), buildComprehensionSet($3)})
+       }
 ;
 
 // Statements
@@ -47,68 +47,92 @@ const LINT = 57350
 const LFLOAT = 57351
 const LSTRING = 57352
 const LIMAG = 57353
-const LCHAR = 57354
-const LCOMMENT = 57355
-const LMUL = 57356
-const LADD = 57357
-const LSUB = 57358
-const LQUO = 57359
-const LREM = 57360
-const LQMARK = 57361
-const LNSAFE = 57362
-const LADD_ASSIGN = 57363
-const LSUB_ASSIGN = 57364
-const LMUL_ASSIGN = 57365
-const LQUO_ASSIGN = 57366
-const LREM_ASSIGN = 57367
-const LARROW = 57368
-const LINC = 57369
-const LDEC = 57370
-const LLAND = 57371
-const LOR = 57372
-const LLOR = 57373
-const LNOT = 57374
-const LEQL = 57375
-const LLSS = 57376
-const LGTR = 57377
-const LASSIGN = 57378
-const LNEQ = 57379
-const LLEQ = 57380
-const LGEQ = 57381
-const LELLIPSIS = 57382
-const LLPAREN = 57383
-const LLBRACK = 57384
-const LLBRACE = 57385
-const LCOMMA = 57386
-const LPERIOD = 57387
-const LRPAREN = 57388
-const LRBRACK = 57389
-const LRBRACE = 57390
-const LSEMICOLON = 57391
-const LCOLON = 57392
-const LCOLONCOLON = 57393
-const LUSE = 57394
-const LTYPEALIAS = 57395
-const LSET = 57396
-const LPUB = 57397
-const LCONST = 57398
-const LELSE = 57399
-const LFOR = 57400
-const LIN = 57401
-const LFUNC = 57402
-const LIF = 57403
-const LBREAK = 57404
-const LRETURN = 57405
-const LTANY = 57406
-const LTBOOL = 57407
-const LTINT = 57408
-const LTFLOAT = 57409
-const LTCOMPLEX = 57410
-const LTSTRING = 57411
-const LTCHAR = 57412
-const LTRUE = 57413
-const LFALSE = 57414
-const LNIL = 57415
+const LBIGINT = 57354
+const LDECIMAL = 57355
+const LCHAR = 57356
+const LCOMMENT = 57357
+const LMUL = 57358
+const LADD = 57359
+const LSUB = 57360
+const LQUO = 57361
+const LREM = 57362
+const LADD_WRAP = 57363
+const LSUB_WRAP = 57364
+const LMUL_WRAP = 57365
+const LADD_SAT = 57366
+const LSUB_SAT = 57367
+const LMUL_SAT = 57368
+const LQMARK = 57369
+const LNSAFE = 57370
+const LADD_ASSIGN = 57371
+const LSUB_ASSIGN = 57372
+const LMUL_ASSIGN = 57373
+const LQUO_ASSIGN = 57374
+const LREM_ASSIGN = 57375
+const LARROW = 57376
+const LINC = 57377
+const LDEC = 57378
+const LLAND = 57379
+const LAND = 57380
+const LOR = 57381
+const LLOR = 57382
+const LNOT = 57383
+const LPIPE = 57384
+const LEQL = 57385
+const LLSS = 57386
+const LGTR = 57387
+const LASSIGN = 57388
+const LNEQ = 57389
+const LLEQ = 57390
+const LGEQ = 57391
+const LELLIPSIS = 57392
+const LLPAREN = 57393
+const LLBRACK = 57394
+const LLBRACE = 57395
+const LCOMMA = 57396
+const LPERIOD = 57397
+const LRPAREN = 57398
+const LRBRACK = 57399
+const LRBRACE = 57400
+const LSEMICOLON = 57401
+const LCOLON = 57402
+const LCOLONCOLON = 57403
+const LLSET_BRACE = 57404
+const LRSET_BRACE = 57405
+const LUSE = 57406
+const LTYPEALIAS = 57407
+const LTYPE = 57408
+const LSET = 57409
+const LPUB = 57410
+const LCONST = 57411
+const LELSE = 57412
+const LFOR = 57413
+const LIN = 57414
+const LFUNC = 57415
+const LIF = 57416
+const LBREAK = 57417
+const LRETURN = 57418
+const LTANY = 57419
+const LTBOOL = 57420
+const LTINT = 57421
+const LTFLOAT = 57422
+const LTCOMPLEX = 57423
+const LTSTRING = 57424
+const LTCHAR = 57425
+const LTBIGINT = 57426
+const LTDECIMAL = 57427
+const LTINT8 = 57428
+const LTINT16 = 57429
+const LTINT32 = 57430
+const LTINT64 = 57431
+const LTUINT8 = 57432
+const LTUINT16 = 57433
+const LTUINT32 = 57434
+const LTUINT64 = 57435
+const LTBYTE = 57436
+const LTRUE = 57437
+const LFALSE = 57438
+const LNIL = 57439
 
 var yyToknames = [...]string{
 	"$end",
@@ -122,6 +146,8 @@ var yyToknames = [...]string{
 	"LFLOAT",
 	"LSTRING",
 	"LIMAG",
+	"LBIGINT",
+	"LDECIMAL",
 	"LCHAR",
 	"LCOMMENT",
 	"LMUL",
@@ -129,6 +155,12 @@ var yyToknames = [...]string{
 	"LSUB",
 	"LQUO",
 	"LREM",
+	"LADD_WRAP",
+	"LSUB_WRAP",
+	"LMUL_WRAP",
+	"LADD_SAT",
+	"LSUB_SAT",
+	"LMUL_SAT",
 	"LQMARK",
 	"LNSAFE",
 	"LADD_ASSIGN",
@@ -140,9 +172,11 @@ var yyToknames = [...]string{
 	"LINC",
 	"LDEC",
 	"LLAND",
+	"LAND",
 	"LOR",
 	"LLOR",
 	"LNOT",
+	"LPIPE",
 	"LEQL",
 	"LLSS",
 	"LGTR",
@@ -162,8 +196,11 @@ var yyToknames = [...]string{
 	"LSEMICOLON",
 	"LCOLON",
 	"LCOLONCOLON",
+	"LLSET_BRACE",
+	"LRSET_BRACE",
 	"LUSE",
 	"LTYPEALIAS",
+	"LTYPE",
 	"LSET",
 	"LPUB",
 	"LCONST",
@@ -181,6 +218,17 @@ var yyToknames = [...]string{
 	"LTCOMPLEX",
 	"LTSTRING",
 	"LTCHAR",
+	"LTBIGINT",
+	"LTDECIMAL",
+	"LTINT8",
+	"LTINT16",
+	"LTINT32",
+	"LTINT64",
+	"LTUINT8",
+	"LTUINT16",
+	"LTUINT32",
+	"LTUINT64",
+	"LTBYTE",
 	"LTRUE",
 	"LFALSE",
 	"LNIL",
@@ -197,245 +245,345 @@ var yyExca = [...]int16{
 	1, -1,
 	-2, 0,
 	-1, 2,
-	1, 12,
-	-2, 19,
+	1, 14,
+	-2, 21,
 	-1, 15,
-	1, 11,
-	-2, 19,
-	-1, 154,
-	49, 23,
-	-2, 107,
-	-1, 158,
-	49, 27,
-	-2, 134,
-	-1, 160,
-	49, 29,
-	-2, 138,
+	1, 13,
+	-2, 21,
+	-1, 206,
+	59, 26,
+	-2, 137,
+	-1, 210,
+	59, 30,
+	-2, 175,
+	-1, 212,
+	59, 32,
+	-2, 179,
+	-1, 303,
+	58, 34,
+	-2, 21,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 687
+const yyLast = 1313
 
 var yyAct = [...]int16{
-	82, 137, 225, 140, 60, 136, 178, 205, 86, 64,
-	13, 37, 52, 65, 152, 148, 29, 28, 135, 83,
-	83, 277, 30, 142, 16, 31, 32, 5, 34, 35,
-	19, 253, 49, 254, 41, 21, 19, 222, 279, 10,
-	48, 98, 20, 88, 237, 208, 170, 81, 87, 213,
-	14, 34, 15, 11, 263, 42, 79, 78, 243, 212,
-	132, 128, 45, 234, 203, 144, 259, 143, 240, 231,
-	230, 202, 168, 83, 256, 90, 103, 104, 174, 272,
-	141, 123, 126, 125, 41, 164, 127, 129, 130, 131,
-	33, 139, 256, 158, 102, 236, 154, 160, 95, 94,
-	91, 92, 93, 96, 97, 23, 83, 24, 180, 179,
-	182, 183, 184, 185, 186, 187, 188, 189, 190, 191,
-	192, 193, 194, 195, 196, 145, 175, 198, 232, 197,
-	207, 204, 169, 46, 173, 36, 14, 105, 14, 38,
-	43, 98, 233, 209, 44, 199, 206, 176, 14, 27,
-	226, 181, 8, 4, 14, 210, 118, 116, 117, 119,
-	120, 79, 211, 172, 214, 22, 261, 223, 25, 228,
-	31, 177, 43, 224, 87, 90, 103, 104, 227, 159,
-	118, 221, 39, 119, 120, 235, 47, 155, 80, 18,
-	101, 100, 89, 99, 102, 171, 9, 17, 95, 94,
-	91, 92, 93, 96, 97, 124, 242, 241, 51, 77,
-	245, 244, 141, 26, 229, 164, 84, 247, 248, 249,
-	250, 251, 252, 158, 85, 257, 154, 160, 255, 246,
-	12, 3, 2, 270, 138, 40, 1, 57, 275, 276,
-	266, 179, 161, 264, 50, 156, 157, 63, 147, 146,
-	133, 7, 6, 62, 61, 267, 269, 153, 242, 59,
-	268, 53, 271, 149, 150, 151, 0, 0, 273, 274,
-	258, 0, 0, 0, 0, 0, 260, 262, 280, 0,
-	0, 265, 282, 0, 0, 281, 14, 66, 67, 68,
-	72, 73, 0, 0, 54, 55, 0, 0, 0, 0,
-	0, 0, 0, 0, 118, 116, 117, 119, 120, 0,
-	0, 56, 0, 0, 0, 0, 278, 0, 0, 109,
-	58, 74, 75, 110, 112, 113, 0, 111, 114, 115,
-	0, 0, 21, 19, 0, 0, 0, 166, 0, 165,
-	167, 163, 162, 0, 0, 0, 0, 0, 0, 0,
-	70, 71, 69, 14, 66, 67, 68, 72, 73, 0,
-	0, 54, 55, 0, 0, 0, 0, 14, 66, 67,
-	68, 72, 73, 0, 0, 54, 55, 0, 56, 0,
-	0, 0, 0, 0, 0, 0, 0, 58, 74, 75,
-	134, 0, 56, 0, 0, 0, 0, 0, 0, 0,
-	0, 58, 74, 75, 0, 0, 76, 0, 0, 0,
-	0, 107, 121, 122, 0, 0, 0, 70, 71, 69,
-	76, 118, 116, 117, 119, 120, 106, 0, 0, 0,
-	0, 70, 71, 69, 0, 0, 109, 0, 108, 0,
-	110, 112, 113, 0, 111, 114, 115, 0, 107, 121,
-	122, 0, 0, 0, 0, 0, 0, 238, 118, 116,
-	117, 119, 120, 106, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 109, 0, 108, 0, 110, 112, 113,
-	0, 111, 114, 115, 107, 121, 122, 0, 0, 0,
-	0, 239, 0, 0, 118, 116, 117, 119, 120, 106,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 109,
-	0, 108, 0, 110, 112, 113, 0, 111, 114, 115,
-	107, 121, 122, 0, 201, 0, 200, 0, 0, 0,
-	118, 116, 117, 119, 120, 106, 0, 216, 217, 218,
-	219, 220, 0, 0, 0, 109, 0, 108, 0, 110,
-	112, 113, 215, 111, 114, 115, 107, 121, 122, 0,
-	0, 0, 0, 0, 0, 0, 118, 116, 117, 119,
-	120, 106, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 109, 0, 108, 0, 110, 112, 113, 0, 111,
-	114, 115, 107, 121, 122, 83, 0, 0, 0, 0,
-	0, 0, 118, 116, 117, 119, 120, 106, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 109, 0, 108,
-	0, 110, 112, 113, 0, 111, 114, 115, 118, 116,
-	117, 119, 120, 106, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 109, 0, 108, 0, 110, 112, 113,
-	0, 111, 114, 115, 118, 116, 117, 119, 120, 0,
-	0, 118, 116, 117, 119, 120, 0, 0, 0, 109,
-	0, 108, 0, 110, 112, 113, 0, 111, 114, 115,
-	110, 112, 113, 0, 111, 114, 115,
+	189, 9, 107, 290, 231, 81, 187, 58, 86, 73,
+	87, 13, 200, 31, 269, 111, 30, 192, 55, 204,
+	70, 185, 322, 338, 323, 108, 33, 47, 48, 16,
+	51, 52, 53, 108, 194, 353, 32, 59, 14, 88,
+	89, 90, 94, 95, 96, 97, 358, 19, 75, 76,
+	21, 22, 19, 5, 287, 10, 49, 69, 20, 270,
+	346, 273, 222, 106, 278, 113, 15, 112, 60, 62,
+	114, 104, 77, 51, 103, 11, 334, 312, 14, 277,
+	182, 66, 79, 98, 100, 184, 65, 300, 295, 266,
+	196, 328, 195, 99, 307, 297, 179, 180, 181, 186,
+	265, 220, 14, 108, 101, 296, 193, 108, 325, 216,
+	59, 37, 35, 36, 38, 39, 298, 210, 206, 212,
+	345, 188, 339, 29, 145, 61, 92, 93, 91, 191,
+	325, 299, 25, 303, 272, 144, 46, 268, 40, 42,
+	43, 221, 41, 44, 45, 232, 61, 233, 67, 235,
+	236, 237, 238, 239, 240, 241, 242, 243, 244, 245,
+	246, 247, 248, 249, 250, 251, 252, 253, 254, 255,
+	256, 257, 258, 259, 54, 227, 261, 197, 68, 178,
+	260, 24, 14, 26, 56, 64, 63, 225, 333, 14,
+	229, 291, 8, 14, 224, 14, 331, 274, 262, 4,
+	230, 173, 176, 175, 50, 271, 177, 211, 27, 105,
+	104, 23, 228, 276, 275, 279, 139, 18, 234, 14,
+	138, 288, 137, 33, 207, 293, 289, 112, 37, 35,
+	36, 38, 39, 57, 17, 115, 136, 292, 286, 223,
+	162, 174, 34, 163, 164, 302, 304, 167, 309, 72,
+	170, 102, 28, 46, 109, 40, 42, 43, 110, 41,
+	44, 45, 12, 3, 2, 343, 190, 1, 78, 311,
+	351, 308, 352, 59, 314, 310, 213, 71, 193, 216,
+	208, 316, 317, 318, 319, 320, 321, 210, 206, 212,
+	313, 315, 326, 294, 324, 209, 85, 199, 198, 183,
+	301, 7, 6, 84, 83, 232, 337, 82, 335, 205,
+	336, 162, 160, 161, 163, 164, 165, 166, 167, 168,
+	169, 170, 80, 340, 74, 201, 311, 342, 202, 203,
+	341, 0, 344, 159, 158, 0, 0, 0, 0, 347,
+	0, 0, 0, 349, 350, 59, 0, 348, 0, 0,
+	0, 0, 0, 0, 0, 0, 359, 0, 360, 0,
+	0, 0, 327, 0, 363, 361, 329, 0, 0, 330,
+	332, 0, 0, 0, 14, 88, 89, 90, 94, 95,
+	96, 97, 0, 0, 75, 76, 0, 162, 160, 161,
+	163, 164, 165, 166, 167, 168, 169, 170, 0, 0,
+	135, 0, 0, 0, 0, 0, 0, 0, 77, 159,
+	0, 0, 0, 0, 0, 0, 354, 355, 79, 98,
+	100, 0, 0, 0, 0, 0, 0, 0, 0, 99,
+	0, 0, 21, 22, 19, 0, 0, 0, 218, 0,
+	217, 219, 215, 214, 116, 141, 143, 226, 135, 162,
+	160, 161, 163, 164, 165, 166, 167, 168, 169, 170,
+	142, 0, 92, 93, 91, 0, 140, 0, 0, 0,
+	121, 120, 117, 118, 119, 122, 123, 124, 125, 126,
+	127, 128, 129, 130, 131, 132, 133, 134, 0, 0,
+	0, 0, 116, 141, 143, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 142, 0,
+	0, 0, 0, 0, 140, 0, 0, 0, 121, 120,
+	117, 118, 119, 122, 123, 124, 125, 126, 127, 128,
+	129, 130, 131, 132, 133, 134, 14, 88, 89, 90,
+	94, 95, 96, 97, 0, 0, 75, 76, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	77, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	79, 98, 100, 0, 0, 0, 0, 0, 0, 0,
+	0, 99, 0, 0, 147, 171, 172, 0, 0, 0,
+	0, 0, 101, 0, 0, 0, 162, 160, 161, 163,
+	164, 165, 166, 167, 168, 169, 170, 146, 0, 281,
+	282, 283, 284, 285, 92, 93, 91, 150, 159, 158,
+	149, 0, 148, 151, 153, 154, 280, 152, 155, 156,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 147, 171, 172, 0, 0, 0, 0, 0, 0,
+	0, 0, 157, 162, 160, 161, 163, 164, 165, 166,
+	167, 168, 169, 170, 146, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 150, 159, 158, 149, 0, 148,
+	151, 153, 154, 0, 152, 155, 156, 0, 0, 0,
+	0, 0, 0, 0, 356, 0, 0, 0, 0, 0,
+	147, 171, 172, 0, 0, 0, 0, 0, 0, 157,
+	0, 357, 162, 160, 161, 163, 164, 165, 166, 167,
+	168, 169, 170, 146, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 150, 159, 158, 149, 0, 148, 151,
+	153, 154, 0, 152, 155, 156, 0, 0, 0, 0,
+	264, 0, 263, 0, 0, 0, 0, 147, 171, 172,
+	0, 0, 0, 0, 0, 0, 0, 0, 157, 162,
+	160, 161, 163, 164, 165, 166, 167, 168, 169, 170,
+	146, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	150, 159, 158, 149, 0, 148, 151, 153, 154, 0,
+	152, 155, 156, 0, 0, 0, 0, 0, 0, 0,
+	362, 0, 0, 0, 147, 171, 172, 0, 0, 0,
+	0, 0, 0, 0, 0, 157, 162, 160, 161, 163,
+	164, 165, 166, 167, 168, 169, 170, 146, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 150, 159, 158,
+	149, 0, 148, 151, 153, 154, 0, 152, 155, 156,
+	0, 0, 0, 108, 0, 0, 0, 0, 0, 0,
+	0, 147, 171, 172, 0, 0, 0, 0, 0, 0,
+	0, 0, 157, 162, 160, 161, 163, 164, 165, 166,
+	167, 168, 169, 170, 146, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 150, 159, 158, 149, 0, 148,
+	151, 153, 154, 0, 152, 155, 156, 0, 0, 0,
+	0, 0, 0, 0, 306, 0, 0, 0, 147, 171,
+	172, 0, 0, 0, 0, 0, 0, 0, 0, 157,
+	162, 160, 161, 163, 164, 165, 166, 167, 168, 169,
+	170, 146, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 150, 159, 158, 149, 0, 148, 151, 153, 154,
+	0, 152, 155, 156, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 305, 147, 171, 172, 0, 0,
+	0, 0, 0, 0, 0, 0, 157, 162, 160, 161,
+	163, 164, 165, 166, 167, 168, 169, 170, 146, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 150, 159,
+	158, 149, 0, 148, 151, 153, 154, 0, 152, 155,
+	156, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 147, 171, 172, 0, 0, 0, 0, 0,
+	0, 0, 267, 157, 162, 160, 161, 163, 164, 165,
+	166, 167, 168, 169, 170, 146, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 150, 159, 158, 149, 0,
+	148, 151, 153, 154, 0, 152, 155, 156, 0, 0,
+	162, 160, 161, 163, 164, 165, 166, 167, 168, 169,
+	170, 146, 0, 0, 0, 0, 0, 0, 0, 0,
+	157, 150, 159, 158, 149, 0, 148, 151, 153, 154,
+	0, 152, 155, 156, 0, 0, 162, 160, 161, 163,
+	164, 165, 166, 167, 168, 169, 170, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 157, 150, 159, 158,
+	149, 0, 148, 151, 153, 154, 0, 152, 155, 156,
+	0, 0, 162, 160, 161, 163, 164, 165, 166, 167,
+	168, 169, 170, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 157, 150, 159, 158, 149, 0, 0, 151,
+	153, 154, 0, 152, 155, 156, 0, 0, 162, 160,
+	161, 163, 164, 165, 166, 167, 168, 169, 170, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 157, 150,
+	159, 158, 0, 0, 0, 151, 153, 154, 0, 152,
+	155, 156, 0, 162, 160, 161, 163, 164, 165, 166,
+	167, 168, 169, 170, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 157, 159, 158, 147, 171, 172,
+	151, 153, 154, 0, 152, 155, 156, 0, 0, 162,
+	160, 161, 163, 164, 165, 166, 167, 168, 169, 170,
+	146, 0, 0, 0, 0, 0, 0, 0, 0, 157,
+	150, 159, 158, 149, 0, 148, 151, 153, 154, 0,
+	152, 155, 156,
 }
 
 var yyPact = [...]int16{
-	-25, -1000, -16, 4, -1000, 147, -1000, 3, -1000, -18,
-	-1000, -25, 62, -1000, -1000, -16, -1000, -1000, -1000, -34,
-	147, 147, -1000, 147, 147, -1000, 91, -1000, 103, 141,
-	-1000, 99, 108, 14, 89, -1000, -34, -1000, 360, -34,
-	-1000, -3, 63, 147, 134, -1000, 147, -1000, 588, -1000,
-	-1000, -1000, -1000, 41, 360, 360, 360, -1000, 346, -1000,
+	-11, -1000, -13, 16, -1000, 186, -1000, 7, -1000, -15,
+	-1000, -11, 128, -1000, -1000, -13, -1000, -1000, -1000, -33,
+	212, 186, 186, -1000, 188, 186, 186, -1000, 120, -1000,
+	138, 182, -1000, 74, 74, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, 140, 139, 28,
+	23, 94, -1000, -1000, -33, -1000, 529, -33, -1000, 3,
+	50, 186, 50, 441, 441, -1000, -1000, 186, -1000, 1263,
+	-1000, -1000, -1000, -1000, 151, 529, 529, 529, -1000, 31,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 360, 43, 99, 21, -1000, 147,
-	-1000, 134, -1000, 279, 26, 88, -1000, -4, -1000, -1000,
-	34, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 99, 134, 147, -1000, 360, 134, 360, 360,
-	360, 360, 360, 360, 360, 360, 360, 360, 360, 360,
-	360, 360, 360, -1000, 147, 360, 360, -1000, -1000, -1000,
-	-1000, -1000, 480, 25, -1000, 17, 87, 588, 86, -1000,
-	-1000, -5, 63, 103, -34, -1000, 11, 0, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 529, 529,
+	71, 74, 36, -1000, 186, -1000, 441, -1000, 367, 45,
+	87, -1000, 2, -1000, -1000, -1000, 393, -1000, -1000, -1000,
 	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, 360, -1000, 516, 131, -24, 360, 124, 129,
-	134, 24, 23, 98, -1000, -1000, 16, 51, -1000, -6,
-	407, -1000, 290, 647, 142, 142, 142, 142, 142, 142,
-	166, 166, -1000, -1000, -1000, 614, 614, -1000, 444, 22,
-	-1000, 360, -1000, -1000, 360, -1000, 10, 147, 360, -1000,
-	-1000, -1000, -1000, 279, 588, 360, 360, 360, 360, 360,
-	360, -28, 360, -1000, 30, -1000, 134, -1000, 20, -1000,
-	-1000, -1000, 134, 134, -1000, 6, 147, 134, 360, -1000,
-	-1000, 48, 588, -1000, -1000, 588, -1000, 588, 588, 588,
-	588, 588, 588, 360, 360, 30, 360, -1000, -1000, 124,
-	-1000, 35, -1000, -1000, -1000, -1000, 640, 552, 30, -1000,
-	-36, -1000, 134, -1000, -1000, -1000, -1000, -23, -1000, 360,
-	-1000, 30, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	74, 441, 146, -13, -1000, -1000, 529, 441, 529, 529,
+	529, 529, 529, 529, 529, 529, 529, 529, 529, 529,
+	529, 529, 529, 529, 529, 529, 529, 529, 529, 529,
+	529, 529, 529, -1000, 186, 529, 529, -1000, -1000, -1000,
+	-1000, -1000, 706, 44, -1000, 32, 991, 83, -4, 1048,
+	80, -1000, -1000, 1, 50, 138, -33, -1000, 21, 5,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 529, -1000, 590, 95, -20, 529,
+	157, 175, 441, 49, 39, 77, -1000, -1000, 30, 441,
+	79, -1000, -33, 934, -1000, 1156, 1192, 1227, 295, 295,
+	295, 295, 295, 295, 295, 371, 433, 224, 224, -1000,
+	-1000, -1000, 224, 224, -1000, 224, 224, -1000, 1084, 1084,
+	-1000, 877, 38, -1000, 529, -1000, -1000, 186, 529, -1000,
+	-1000, 19, 186, 529, -1000, -1000, -1000, -1000, 367, 1048,
+	529, 529, 529, 529, 529, 529, -50, 529, -1000, 54,
+	-1000, 441, -1000, 35, -1000, 441, -1000, -1000, 441, 441,
+	-1000, 143, 18, -13, 186, 529, -1000, -1000, 76, -49,
+	68, 1048, -1000, -1000, 1048, -1000, 1048, 1048, 1048, 1048,
+	1048, 1048, 529, 529, 54, 529, -1000, -1000, 157, -1000,
+	-1000, 66, -1000, -1000, -1000, -1000, 0, 1120, 529, 186,
+	820, 54, -1000, -35, -1000, 441, 441, 647, -1000, -1000,
+	-1000, -1000, -1000, -28, -1000, -1000, -1000, 529, 529, -1000,
+	763, 54, -1000, -1000,
 }
 
 var yyPgo = [...]int16{
-	0, 152, 265, 264, 263, 1, 13, 32, 261, 14,
-	12, 259, 257, 0, 15, 187, 9, 254, 253, 18,
-	252, 251, 5, 250, 249, 248, 247, 3, 246, 245,
-	244, 242, 239, 238, 237, 4, 236, 153, 8, 235,
-	11, 17, 234, 233, 232, 231, 230, 90, 224, 216,
-	213, 209, 149, 179, 208, 205, 16, 196, 7, 2,
-	23, 43, 195, 193, 192, 191, 190, 6, 188, 171,
-	166, 163,
+	0, 192, 329, 328, 325, 0, 10, 20, 324, 19,
+	9, 322, 309, 2, 12, 224, 8, 307, 304, 303,
+	21, 302, 301, 6, 299, 298, 297, 296, 17, 295,
+	280, 277, 276, 272, 270, 268, 5, 267, 199, 15,
+	7, 18, 16, 266, 265, 264, 263, 262, 56, 258,
+	254, 252, 251, 123, 207, 249, 248, 242, 241, 13,
+	1, 14, 3, 34, 70, 239, 236, 235, 222, 220,
+	216, 4, 209, 200, 196, 194,
 }
 
 var yyR1 = [...]int8{
-	0, 36, 44, 44, 45, 45, 37, 47, 47, 46,
-	46, 20, 20, 21, 21, 1, 1, 1, 57, 57,
-	53, 4, 4, 4, 4, 4, 4, 4, 4, 4,
-	4, 58, 58, 9, 50, 50, 52, 52, 51, 51,
-	41, 40, 40, 56, 56, 12, 12, 12, 12, 12,
-	12, 39, 68, 68, 38, 64, 64, 64, 64, 64,
-	64, 64, 64, 64, 64, 64, 64, 62, 61, 61,
-	63, 71, 71, 71, 65, 66, 48, 48, 49, 49,
-	59, 59, 60, 60, 69, 69, 67, 70, 70, 13,
-	14, 14, 14, 3, 3, 2, 24, 24, 25, 25,
-	16, 15, 30, 54, 5, 5, 5, 5, 5, 5,
+	0, 37, 45, 45, 46, 46, 38, 38, 38, 48,
+	48, 47, 47, 21, 21, 22, 22, 1, 1, 1,
+	60, 60, 54, 54, 4, 4, 4, 4, 4, 4,
+	4, 4, 4, 4, 61, 61, 9, 51, 51, 53,
+	53, 52, 52, 42, 41, 41, 59, 59, 12, 12,
+	12, 12, 12, 12, 40, 72, 72, 39, 67, 67,
+	67, 67, 67, 67, 67, 67, 67, 67, 67, 67,
+	67, 67, 67, 67, 67, 67, 67, 67, 67, 67,
+	67, 67, 65, 65, 64, 64, 66, 75, 75, 75,
+	68, 69, 70, 49, 49, 50, 50, 62, 62, 63,
+	63, 73, 73, 71, 74, 74, 13, 14, 14, 14,
+	3, 3, 2, 25, 25, 26, 26, 16, 15, 15,
+	57, 57, 57, 57, 57, 57, 57, 57, 57, 57,
+	57, 57, 31, 55, 5, 5, 5, 5, 5, 5,
+	5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
 	5, 5, 5, 5, 5, 5, 5, 5, 5, 5,
-	5, 7, 7, 7, 7, 8, 8, 10, 10, 34,
-	34, 34, 34, 34, 34, 34, 34, 34, 34, 6,
-	55, 55, 22, 22, 19, 19, 11, 11, 11, 11,
-	11, 11, 11, 11, 35, 17, 26, 26, 42, 42,
-	27, 23, 23, 23, 18, 29, 28, 28, 28, 31,
-	43, 43, 32, 33, 33,
+	5, 7, 7, 7, 7, 8, 8, 10, 10, 35,
+	35, 35, 35, 35, 35, 35, 35, 35, 35, 35,
+	6, 58, 58, 23, 23, 20, 20, 11, 11, 11,
+	11, 11, 11, 11, 11, 11, 11, 36, 17, 27,
+	27, 43, 43, 28, 24, 24, 24, 18, 18, 18,
+	19, 56, 56, 30, 29, 29, 29, 32, 44, 44,
+	33, 34, 34,
 }
 
 var yyR2 = [...]int8{
-	0, 2, 2, 0, 3, 1, 5, 3, 1, 3,
-	1, 2, 0, 3, 1, 2, 2, 2, 1, 0,
-	4, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 0, 2, 3, 1, 2, 5, 3, 1,
-	2, 2, 0, 1, 0, 3, 3, 3, 3, 3,
-	3, 2, 2, 0, 3, 1, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 2, 3, 1, 3,
-	3, 1, 2, 3, 3, 4, 3, 1, 1, 0,
-	2, 0, 4, 6, 3, 1, 3, 3, 1, 3,
-	1, 1, 1, 1, 2, 1, 2, 0, 3, 1,
-	3, 4, 5, 3, 1, 1, 1, 1, 3, 3,
+	0, 2, 2, 0, 3, 1, 5, 5, 4, 3,
+	1, 3, 1, 2, 0, 3, 1, 2, 2, 2,
+	1, 0, 4, 4, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 0, 2, 3, 1, 2,
+	5, 3, 1, 2, 2, 0, 1, 0, 3, 3,
+	3, 3, 3, 3, 2, 2, 0, 3, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 2, 3, 3, 1, 3, 3, 1, 2, 3,
+	3, 4, 4, 3, 1, 1, 0, 2, 0, 4,
+	6, 3, 1, 5, 3, 1, 3, 1, 1, 1,
+	1, 2, 1, 2, 0, 3, 1, 3, 4, 4,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 5, 3, 1, 1, 1, 1, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 1, 2, 2, 2, 1, 3, 3, 3, 1,
-	1, 1, 1, 1, 1, 2, 3, 4, 1, 4,
-	1, 1, 3, 1, 2, 0, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 3, 4, 2, 3, 1,
-	3, 1, 2, 3, 3, 5, 5, 4, 2, 5,
-	2, 0, 4, 2, 0,
+	1, 1, 1, 1, 1, 1, 2, 3, 4, 1,
+	4, 1, 1, 3, 1, 2, 0, 1, 1, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 3, 4,
+	2, 3, 1, 3, 1, 2, 3, 3, 7, 9,
+	3, 1, 3, 5, 5, 4, 2, 5, 2, 0,
+	4, 2, 0,
 }
 
 var yyChk = [...]int16{
-	-1000, -36, -44, -45, -37, 52, -20, -21, -1, -57,
-	55, 49, -46, -35, 7, 49, -9, -15, -53, 54,
-	60, 53, -37, 43, 45, -1, -50, -52, -41, -56,
-	56, -35, -35, -47, -35, -35, 44, -40, 36, 41,
-	-39, -35, -60, 41, 36, 48, 44, -52, -5, -7,
-	-30, -54, -10, -8, 15, 16, 32, -34, 41, -11,
-	-35, -17, -18, -26, -16, -6, 8, 9, 10, 73,
-	71, 72, 11, 12, 42, 43, 60, -51, -41, -56,
-	-68, 50, -13, 43, -49, -48, -38, -35, -61, -64,
-	41, 66, 67, 68, 65, 64, 69, 70, 7, -63,
-	-65, -66, 60, 42, 43, -47, 19, 4, 31, 29,
-	33, 37, 34, 35, 38, 39, 15, 16, 14, 17,
-	18, 5, 6, 40, -55, 42, 41, 45, 20, -7,
-	-7, -7, -5, -23, 44, -19, -22, -5, -42, 48,
-	-27, -35, -60, 46, 44, -61, -24, -25, -14, -4,
-	-3, -2, -9, -12, -10, -15, -29, -28, -16, -53,
-	-6, -31, 63, 62, -5, 60, 58, 61, 46, 44,
-	50, -62, -71, -61, 44, -60, -61, -69, -67, -35,
-	-5, -61, -5, -5, -5, -5, -5, -5, -5, -5,
-	-5, -5, -5, -5, -5, -5, -5, -35, -5, -19,
-	46, 44, 46, 47, 44, -58, -58, 44, 50, -13,
-	-40, -41, 48, 49, -5, 36, 21, 22, 23, 24,
-	25, -9, 61, -13, -22, -59, 26, -38, 40, -61,
-	46, 46, 30, 44, 47, -58, 44, 50, 50, 47,
-	46, -22, -5, 48, -27, -5, -14, -5, -5, -5,
-	-5, -5, -5, 59, 61, -22, 44, -13, -61, 46,
-	-61, -70, -61, 48, -67, -61, -5, -5, -22, -13,
-	-43, -59, 44, -13, -13, -33, -32, 57, -61, 61,
-	-13, -22, -13,
+	-1000, -37, -45, -46, -38, 64, -21, -22, -1, -60,
+	68, 59, -47, -36, 7, 59, -9, -15, -54, 67,
+	73, 65, 66, -38, 53, 4, 55, -1, -51, -53,
+	-42, -59, 69, -36, -57, 17, 18, 16, 19, 20,
+	43, 47, 44, 45, 48, 49, 41, -36, -36, -48,
+	16, -36, -36, -36, 54, -41, 46, 51, -40, -36,
+	-63, 51, -63, 46, 46, 58, 58, 54, -53, -5,
+	-7, -31, -55, -10, -8, 17, 18, 41, -35, 51,
+	-11, -36, -17, -18, -19, -27, -16, -6, 8, 9,
+	10, 97, 95, 96, 11, 12, 13, 14, 52, 62,
+	53, 73, -52, -42, -59, -72, 60, -13, 53, -50,
+	-49, -39, -36, -13, -64, -67, 51, 79, 80, 81,
+	78, 77, 82, 83, 84, 85, 86, 87, 88, 89,
+	90, 91, 92, 93, 94, 7, -66, -68, -69, -70,
+	73, 52, 67, 53, -64, -48, 27, 4, 42, 40,
+	37, 43, 47, 44, 45, 48, 49, 72, 39, 38,
+	17, 18, 16, 19, 20, 21, 22, 23, 24, 25,
+	26, 5, 6, 50, -58, 52, 51, 55, 28, -7,
+	-7, -7, -5, -24, 54, -20, -5, -23, -20, -5,
+	-43, 58, -28, -36, -63, 56, 54, -64, -25, -26,
+	-14, -4, -3, -2, -9, -12, -10, -15, -30, -29,
+	-16, -54, -6, -32, 76, 75, -5, 73, 71, 74,
+	56, 54, 60, -65, -75, -64, 54, -63, -64, 44,
+	-73, -71, -60, -5, -64, -5, -5, -5, -5, -5,
+	-5, -5, -5, -5, -5, -5, -5, -5, -5, -5,
+	-5, -5, -5, -5, -5, -5, -5, -5, -5, -5,
+	-36, -5, -20, 56, 54, 56, 57, 71, 54, -61,
+	63, -61, 54, 60, -13, -41, -42, 58, 59, -5,
+	46, 29, 30, 31, 32, 33, -9, 74, -13, -23,
+	-62, 34, -39, 50, -64, 39, 56, 56, 39, 54,
+	57, -64, -61, 54, -59, 60, 57, 56, -23, -56,
+	-40, -5, 58, -28, -5, -14, -5, -5, -5, -5,
+	-5, -5, 72, 74, -23, 54, -13, -64, 56, -64,
+	-64, -74, -64, 45, 58, -71, -36, -5, 72, 54,
+	-5, -23, -13, -44, -62, 54, 60, -5, -40, -13,
+	-13, -34, -33, 70, -64, -64, 57, 74, 74, -13,
+	-5, -23, 57, -13,
 }
 
 var yyDef = [...]int16{
-	3, -2, -2, 0, 5, 0, 1, 0, 14, 0,
-	18, 2, 0, 10, 154, -2, 15, 16, 17, 44,
-	0, 0, 4, 0, 0, 13, 33, 35, 42, 0,
-	43, 0, 0, 0, 8, 9, 44, 36, 0, 44,
-	40, 53, 0, 79, 0, 6, 0, 34, 41, 104,
-	105, 106, 107, 121, 0, 0, 0, 125, 0, 129,
-	130, 131, 132, 133, 134, 138, 146, 147, 148, 149,
-	150, 151, 152, 153, 145, 0, 0, 0, 39, 0,
-	51, 0, 101, 97, 0, 78, 77, 0, 20, 68,
-	0, 55, 56, 57, 58, 59, 60, 61, 62, 63,
-	64, 65, 0, 0, 0, 7, 0, 0, 0, 0,
+	3, -2, -2, 0, 5, 0, 1, 0, 16, 0,
+	20, 2, 0, 12, 197, -2, 17, 18, 19, 47,
+	0, 0, 0, 4, 0, 0, 0, 15, 36, 38,
+	45, 0, 46, 0, 0, 120, 121, 122, 123, 124,
+	125, 126, 127, 128, 129, 130, 131, 0, 0, 0,
+	0, 10, 8, 11, 47, 39, 0, 47, 43, 56,
+	0, 96, 0, 0, 0, 6, 7, 0, 37, 44,
+	134, 135, 136, 137, 161, 0, 0, 0, 165, 0,
+	169, 170, 171, 172, 173, 174, 175, 179, 187, 188,
+	189, 190, 191, 192, 193, 194, 195, 196, 186, 186,
+	0, 0, 0, 42, 0, 54, 0, 118, 114, 0,
+	95, 94, 0, 119, 22, 84, 0, 58, 59, 60,
+	61, 62, 63, 64, 65, 66, 67, 68, 69, 70,
+	71, 72, 73, 74, 75, 76, 77, 78, 79, 80,
+	0, 0, 0, 21, 23, 9, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 135, 0, 0, 145, 140, 141, 122,
-	123, 124, 0, 0, 161, 0, 32, 143, 32, 157,
-	159, 0, 0, 42, 44, 52, 0, 0, 99, 90,
-	91, 92, 21, 22, -2, 24, 25, 26, -2, 28,
-	-2, 30, 93, 95, 0, 0, 0, 0, 81, 0,
-	0, 0, 0, 0, 71, 66, 0, 32, 85, 0,
-	0, 103, 108, 109, 110, 111, 112, 113, 114, 115,
-	116, 117, 118, 119, 120, 127, 128, 136, 0, 0,
-	126, 162, 155, 164, 31, 144, 0, 31, 0, 100,
-	37, 38, 89, 96, 94, 0, 0, 0, 0, 0,
-	0, 0, 0, 168, 0, 82, 0, 76, 0, 54,
-	69, 70, 0, 72, 74, 0, 31, 0, 0, 137,
-	139, 163, 142, 156, 158, 160, 98, 45, 46, 47,
-	48, 49, 50, 0, 0, 0, 0, 171, 80, 81,
-	67, 73, 88, 75, 84, 86, 102, 0, 0, 167,
-	174, 83, 0, 165, 166, 169, 170, 0, 87, 0,
-	173, 0, 172,
+	0, 0, 0, 176, 0, 0, 186, 181, 182, 162,
+	163, 164, 0, 0, 204, 0, 184, 35, 0, 184,
+	35, 200, 202, 0, 0, 45, 47, 55, 0, 0,
+	116, 107, 108, 109, 24, 25, -2, 27, 28, 29,
+	-2, 31, -2, 33, 110, 112, 0, 0, 0, 0,
+	98, 0, 0, 0, 0, 0, 87, 81, 0, 0,
+	35, 102, 47, 0, 133, 138, 139, 140, 141, 142,
+	143, 144, 145, 146, 147, 148, 149, 150, 151, 152,
+	153, 154, 155, 156, 157, 158, 159, 160, 167, 168,
+	177, 0, 0, 166, 205, 198, 207, 0, 34, 185,
+	210, 0, 34, 0, 117, 40, 41, 106, 113, 111,
+	0, 0, 0, 0, 0, 0, 0, 0, 216, 0,
+	99, 0, 93, 0, 57, 0, 85, 86, 0, 88,
+	90, 0, 0, -2, 0, 0, 178, 180, 206, 0,
+	211, 183, 199, 201, 203, 115, 48, 49, 50, 51,
+	52, 53, 0, 0, 0, 0, 219, 97, 98, 82,
+	83, 89, 105, 91, 92, 101, 0, 132, 0, 0,
+	0, 0, 215, 222, 100, 0, 0, 0, 212, 213,
+	214, 217, 218, 0, 104, 103, 208, 0, 0, 221,
+	0, 0, 209, 220,
 }
 
 var yyTok1 = [...]int8{
@@ -450,7 +598,9 @@ var yyTok2 = [...]int8{
 	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
 	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
 	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
-	72, 73,
+	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
+	82, 83, 84, 85, 86, 87, 88, 89, 90, 91,
+	92, 93, 94, 95, 96, 97,
 }
 
 var yyTok3 = [...]int8{
@@ -462,8 +612,8 @@ var yyErrorMessages = [...]struct {
 	token int
 	msg   string
 }{
-	{81, 73, "NIL_AS_A_TYPE_ERR"},
-	{1, 52, "USE_ONLY_AT_HEADER_ERR"},
+	{106, 97, "NIL_AS_A_TYPE_ERR"},
+	{1, 64, "USE_ONLY_AT_HEADER_ERR"},
 }
 
 /*	parser for yacc output	*/
@@ -830,45 +980,55 @@ yydefault:
 			yyVAL.node = NewNodePackage(yyDollar[2].node_list, yyDollar[4].node_list)
 		}
 	case 7:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.node = NewNodeWildcardPackage(yyDollar[2].node_list)
+		}
+	case 8:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.node = NewNodeAliasPackage(yyDollar[2].node_list, yyDollar[4].node.(*NodeIdent))
+		}
+	case 9:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[3].node_list = append(yyDollar[3].node_list, yyDollar[1].node)
 			yyVAL.node_list = yyDollar[3].node_list
 		}
-	case 8:
+	case 10:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = []Node{yyDollar[1].node}
 		}
-	case 9:
+	case 11:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 10:
+	case 12:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = []Node{yyDollar[1].node}
 		}
-	case 12:
+	case 14:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 0)
 		}
-	case 13:
+	case 15:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 14:
+	case 16:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 1)
 			yyVAL.node_list[0] = yyDollar[1].node
 		}
-	case 15:
+	case 17:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			sets, ok := yyDollar[2].node.(*NodeSets)
@@ -886,55 +1046,60 @@ yydefault:
 
 			yyVAL.node = yyDollar[2].node
 		}
-	case 16:
+	case 18:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyDollar[2].node.(*NodeFunc).IsPub = yyDollar[1].flag
 			yyVAL.node = yyDollar[2].node
 		}
-	case 17:
+	case 19:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyDollar[2].node.(*NodeTypeAlias).IsPub = yyDollar[1].flag
 			yyVAL.node = yyDollar[2].node
 		}
-	case 18:
+	case 20:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.flag = true
 		}
-	case 19:
+	case 21:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.flag = false
 		}
-	case 20:
+	case 22:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = NewNodeTypeAlias(false, yyDollar[2].node.(*NodeIdent), yyDollar[4].gd_type)
 		}
-	case 31:
+	case 23:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.node = NewNodeNominalTypeAlias(false, yyDollar[2].node.(*NodeIdent), yyDollar[4].gd_type)
+		}
+	case 34:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.flag = true
 		}
-	case 32:
+	case 35:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.flag = false
 		}
-	case 33:
+	case 36:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeSets(yyDollar[2].node_list)
 		}
-	case 34:
+	case 37:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node_list...)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 36:
+	case 39:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			nodeSet, ok := yyDollar[1].node.(*NodeSet)
@@ -944,7 +1109,7 @@ yydefault:
 			nodeSet.Expr = yyDollar[2].node
 			yyVAL.node_list = []Node{nodeSet}
 		}
-	case 37:
+	case 40:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			sharedExpr := NewNodeSharedExpr(yyDollar[5].node)
@@ -958,19 +1123,19 @@ yydefault:
 			}
 			yyVAL.node_list = yyDollar[3].node_list
 		}
-	case 38:
+	case 41:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 39:
+	case 42:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 1)
 			yyVAL.node_list[0] = yyDollar[1].node
 		}
-	case 40:
+	case 43:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			identWithType, ok := yyDollar[2].node.(*NodeIdentWithType)
@@ -979,137 +1144,202 @@ yydefault:
 			}
 			yyVAL.node = NewNodeSet(false, yyDollar[1].flag, identWithType, nil)
 		}
-	case 41:
+	case 44:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = yyDollar[2].node
 		}
-	case 42:
+	case 45:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node = nil
 		}
-	case 43:
+	case 46:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.flag = true
 		}
-	case 44:
+	case 47:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.flag = false
 		}
-	case 45:
+	case 48:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeUpdateSet(yyDollar[1].node, yyDollar[3].node)
 		}
-	case 46:
+	case 49:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeUpdateSet(yyDollar[1].node, NewNodeExprOperation(runtime.ExprOperationAdd, yyDollar[1].node, yyDollar[3].node))
 		}
-	case 47:
+	case 50:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeUpdateSet(yyDollar[1].node, NewNodeExprOperation(runtime.ExprOperationSubtract, yyDollar[1].node, yyDollar[3].node))
 		}
-	case 48:
+	case 51:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeUpdateSet(yyDollar[1].node, NewNodeExprOperation(runtime.ExprOperationMultiply, yyDollar[1].node, yyDollar[3].node))
 		}
-	case 49:
+	case 52:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeUpdateSet(yyDollar[1].node, NewNodeExprOperation(runtime.ExprOperationQuo, yyDollar[1].node, yyDollar[3].node))
 		}
-	case 50:
+	case 53:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeUpdateSet(yyDollar[1].node, NewNodeExprOperation(runtime.ExprOperationRem, yyDollar[1].node, yyDollar[3].node))
 		}
-	case 51:
+	case 54:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeIdentWithType(yyDollar[1].node.(*NodeIdent), yyDollar[2].gd_type)
 		}
-	case 52:
+	case 55:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.gd_type = yyDollar[2].gd_type
 		}
-	case 53:
+	case 56:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDUntypedType
 		}
-	case 54:
+	case 57:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeIdentWithType(yyDollar[1].node.(*NodeIdent), yyDollar[3].gd_type)
 		}
-	case 55:
+	case 58:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDIntType
 		}
-	case 56:
+	case 59:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDFloatType
 		}
-	case 57:
+	case 60:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDComplexType
 		}
-	case 58:
+	case 61:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDBoolType
 		}
-	case 59:
+	case 62:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDAnyType
 		}
-	case 60:
+	case 63:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDStringType
 		}
-	case 61:
+	case 64:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDCharType
 		}
-	case 62:
+	case 65:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDBigIntType
+		}
+	case 66:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDDecimalType
+		}
+	case 67:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDInt8Type
+		}
+	case 68:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDInt16Type
+		}
+	case 69:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDInt32Type
+		}
+	case 70:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDInt64Type
+		}
+	case 71:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDUInt8Type
+		}
+	case 72:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDUInt16Type
+		}
+	case 73:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDUInt32Type
+		}
+	case 74:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDUInt64Type
+		}
+	case 75:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.GDByteType
+		}
+	case 76:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.NewStrRefType(yyDollar[1].token.Lit)
 		}
-	case 63:
+	case 77:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = yyDollar[1].gd_type
 		}
-	case 64:
+	case 78:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = yyDollar[1].gd_type
 		}
-	case 65:
+	case 79:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type = yyDollar[1].gd_type
 		}
-	case 66:
+	case 80:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.gd_type = yyDollar[1].gd_type
+		}
+	case 81:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.gd_type = yyDollar[2].gd_type
 		}
-	case 67:
+	case 82:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.NewGDUnionType(append(yyDollar[1].gd_type.(runtime.GDUnionType), yyDollar[3].gd_type)...)
+		}
+	case 83:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if cT, isCT := yyDollar[1].gd_type.(runtime.GDUnionType); isCT {
@@ -1118,39 +1348,44 @@ yydefault:
 				yyVAL.gd_type = runtime.NewGDUnionType(yyDollar[1].gd_type, yyDollar[3].gd_type)
 			}
 		}
-	case 69:
+	case 85:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.gd_type = yyDollar[2].gd_type
 		}
-	case 70:
+	case 86:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.NewGDTupleType(yyDollar[2].gd_type_list...)
 		}
-	case 71:
+	case 87:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type_list = make([]runtime.GDTypable, 0)
 		}
-	case 72:
+	case 88:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.gd_type_list = make([]runtime.GDTypable, 1)
 			yyVAL.gd_type_list[0] = yyDollar[1].gd_type
 		}
-	case 73:
+	case 89:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[3].gd_type_list = append([]runtime.GDTypable{yyDollar[1].gd_type}, yyDollar[3].gd_type_list...)
 			yyVAL.gd_type_list = yyDollar[3].gd_type_list
 		}
-	case 74:
+	case 90:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.NewGDArrayType(yyDollar[2].gd_type)
 		}
-	case 75:
+	case 91:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.gd_type = runtime.NewGDSetType(yyDollar[3].gd_type)
+		}
+	case 92:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			attrTypes := make([]runtime.GDStructAttrType, len(yyDollar[2].gd_type_list))
@@ -1159,419 +1394,511 @@ yydefault:
 			}
 			yyVAL.gd_type = runtime.NewGDStructType(attrTypes...)
 		}
-	case 76:
+	case 93:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 77:
+	case 94:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 1)
 			yyVAL.node_list[0] = yyDollar[1].node
 		}
-	case 79:
+	case 96:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 0)
 		}
-	case 80:
+	case 97:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.gd_type = yyDollar[2].gd_type
 		}
-	case 81:
+	case 98:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.gd_type = runtime.GDNilType
 		}
-	case 82:
+	case 99:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.gd_type = buildFuncType(yyDollar[2].node_list, false, yyDollar[4].gd_type)
 		}
-	case 83:
+	case 100:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.gd_type = buildFuncType(yyDollar[2].node_list, true, yyDollar[6].gd_type)
 		}
-	case 84:
+	case 101:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].gd_type_list = append(yyDollar[1].gd_type_list, yyDollar[3].gd_type)
 			yyVAL.gd_type_list = yyDollar[1].gd_type_list
 		}
-	case 85:
+	case 102:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type_list = make([]runtime.GDTypable, 1)
 			yyVAL.gd_type_list[0] = yyDollar[1].gd_type
 		}
-	case 86:
-		yyDollar = yyS[yypt-3 : yypt+1]
+	case 103:
+		yyDollar = yyS[yypt-5 : yypt+1]
 		{
-			ident := runtime.NewGDStringIdent(yyDollar[1].node.(*NodeIdent).Lit)
-			yyVAL.gd_type = runtime.GDStructAttrType{Ident: ident, Type: yyDollar[3].gd_type}
+			yyVAL.gd_type = buildStructAttrType(yyDollar[1].flag, yyDollar[2].flag, yyDollar[3].node.(*NodeIdent), yyDollar[5].gd_type)
 		}
-	case 87:
+	case 104:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].gd_type_list = append(yyDollar[1].gd_type_list, yyDollar[3].gd_type)
 			yyVAL.gd_type_list = yyDollar[1].gd_type_list
 		}
-	case 88:
+	case 105:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.gd_type_list = make([]runtime.GDTypable, 1)
 			yyVAL.gd_type_list[0] = yyDollar[1].gd_type
 		}
-	case 89:
+	case 106:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeBlock(yyDollar[2].node_list)
 		}
-	case 93:
+	case 110:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeReturn(yyDollar[1].token, nil)
 		}
-	case 94:
+	case 111:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeReturn(yyDollar[1].token, yyDollar[2].node)
 		}
-	case 95:
+	case 112:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeBreak(yyDollar[1].token)
 		}
-	case 97:
+	case 114:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 0)
 		}
-	case 98:
+	case 115:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 99:
+	case 116:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 1)
 			yyVAL.node_list[0] = yyDollar[1].node
 		}
-	case 100:
+	case 117:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeLambda(yyDollar[2].gd_type.(*runtime.GDLambdaType), yyDollar[3].node.(*NodeBlock))
 		}
-	case 101:
+	case 118:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = NewNodeFunc(true, yyDollar[2].node.(*NodeIdent), yyDollar[3].gd_type.(*runtime.GDLambdaType), yyDollar[4].node.(*NodeBlock))
 		}
-	case 102:
+	case 119:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.node = NewNodeOperatorFunc(true, yyDollar[2].token, yyDollar[3].gd_type.(*runtime.GDLambdaType), yyDollar[4].node.(*NodeBlock))
+		}
+	case 132:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{ // cond ? expr : expr
 			yyVAL.node = NewNodeTernaryIf(yyDollar[1].node, yyDollar[3].node, yyDollar[5].node)
 		}
-	case 103:
+	case 133:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeCastExpr(yyDollar[1].node, yyDollar[3].gd_type)
 		}
-	case 108:
+	case 138:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // |>
+			yyVAL.node = buildPipeCall(yyDollar[1].node, yyDollar[3].node)
+		}
+	case 139:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // ||
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationOr, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 109:
+	case 140:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // &&
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationAnd, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 110:
+	case 141:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // ==
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationEqual, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 111:
+	case 142:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // !=
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationNotEqual, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 112:
+	case 143:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // <
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationLess, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 113:
+	case 144:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // >
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationGreater, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 114:
+	case 145:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // <=
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationLessEqual, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 115:
+	case 146:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // >=
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationGreaterEqual, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 116:
+	case 147:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // in
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationIn, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 148:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // |
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationUnion, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 149:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // &
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationIntersection, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 150:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // +
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationAdd, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 117:
+	case 151:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // -
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationSubtract, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 118:
+	case 152:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // *
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationMultiply, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 119:
+	case 153:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // /
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationQuo, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 120:
+	case 154:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{ // %
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationRem, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 122:
+	case 155:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // +%
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationAddWrap, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 156:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // -%
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationSubtractWrap, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 157:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // *%
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationMultiplyWrap, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 158:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // +|
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationAddSat, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 159:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // -|
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationSubtractSat, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 160:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{ // *|
+			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationMultiplySat, yyDollar[1].node, yyDollar[3].node)
+		}
+	case 162:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationUnaryPlus, yyDollar[2].node, nil)
 		}
-	case 123:
+	case 163:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationUnaryMinus, yyDollar[2].node, nil)
 		}
-	case 124:
+	case 164:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeExprOperation(runtime.ExprOperationNot, yyDollar[2].node, nil)
 		}
-	case 126:
+	case 166:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
+			if call, isCall := yyDollar[2].node.(*NodeCallExpr); isCall {
+				call.IsParenthesized = true
+			}
 			yyVAL.node = yyDollar[2].node
 		}
-	case 127:
+	case 167:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeMutCollectionOp(MutableCollectionAddOp, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 128:
+	case 168:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeMutCollectionOp(MutableCollectionRemoveOp, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 135:
+	case 176:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeEllipsisExpr(yyDollar[1].node)
 		}
-	case 136:
+	case 177:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeSafeDotExpr(yyDollar[1].node, yyDollar[2].flag, yyDollar[3].node)
 		}
-	case 137:
+	case 178:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = NewNodeIterIdxExpr(false, yyDollar[1].node, yyDollar[3].node)
 		}
-	case 139:
+	case 180:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = NewNodeCallExpr(yyDollar[1].node, yyDollar[3].node_list)
 		}
-	case 140:
+	case 181:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.flag = false
 		}
-	case 141:
+	case 182:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.flag = true
 		}
-	case 142:
+	case 183:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 143:
+	case 184:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 1)
 			yyVAL.node_list[0] = yyDollar[1].node
 		}
-	case 145:
+	case 186:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 0)
 		}
-	case 146:
+	case 187:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 147:
+	case 188:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 148:
+	case 189:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 149:
+	case 190:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 150:
+	case 191:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 151:
+	case 192:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 152:
+	case 193:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 153:
+	case 194:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
 		}
-	case 154:
+	case 195:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
+		}
+	case 196:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.node = NewNodeLiteral(yyDollar[1].token)
+		}
+	case 197:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node = NewNodeIdent(yyDollar[1].token)
 		}
-	case 155:
+	case 198:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeTuple(yyDollar[2].node_list...)
 		}
-	case 156:
+	case 199:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = NewNodeStruct(yyDollar[2].node_list...)
 		}
-	case 157:
+	case 200:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeStruct()
 		}
-	case 158:
+	case 201:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[3].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 159:
+	case 202:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 1)
 			yyVAL.node_list[0] = yyDollar[1].node
 		}
-	case 160:
+	case 203:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeStructAttr(yyDollar[1].node.(*NodeIdent), yyDollar[3].node)
 		}
-	case 161:
+	case 204:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 0)
 		}
-	case 162:
+	case 205:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 1)
 			yyVAL.node_list[0] = yyDollar[1].node
 		}
-	case 163:
+	case 206:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyDollar[3].node_list = append([]Node{yyDollar[1].node}, yyDollar[3].node_list...)
 			yyVAL.node_list = yyDollar[3].node_list
 		}
-	case 164:
+	case 207:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.node = NewNodeArray(yyDollar[1].token, yyDollar[3].token, yyDollar[2].node_list)
 		}
-	case 165:
+	case 208:
+		yyDollar = yyS[yypt-7 : yypt+1]
+		{
+			yyVAL.node = NewNodeComprehension(yyDollar[1].token, yyDollar[7].token, yyDollar[2].node, yyDollar[4].node, yyDollar[6].node, nil)
+		}
+	case 209:
+		yyDollar = yyS[yypt-9 : yypt+1]
+		{
+			yyVAL.node = NewNodeComprehension(yyDollar[1].token, yyDollar[9].token, yyDollar[2].node, yyDollar[4].node, yyDollar[6].node, []Node{yyDollar[8].node})
+		}
+	case 210:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.node = NewNodeSetLiteral(yyDollar[1].token, yyDollar[3].token, yyDollar[2].node_list)
+		}
+	case 211:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.node = NewNodeSets([]Node{buildComprehensionSet(yyDollar[1].node)})
+		}
+	case 212:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.node = NewNodeSets([]Node{buildComprehensionSet(yyDollar[1].node), buildComprehensionSet(yyDollar[3].node)})
+		}
+	case 213:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.node = NewNodeForIn(yyDollar[2].node, yyDollar[4].node, yyDollar[5].node.(*NodeBlock))
 		}
-	case 166:
+	case 214:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.node = NewNodeForIf(yyDollar[2].node, yyDollar[4].node_list, yyDollar[5].node.(*NodeBlock))
 		}
-	case 167:
+	case 215:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = NewNodeForIf(nil, yyDollar[3].node_list, yyDollar[4].node.(*NodeBlock))
 		}
-	case 168:
+	case 216:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeForIf(nil, nil, yyDollar[2].node.(*NodeBlock))
 		}
-	case 169:
+	case 217:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			nIf := NewNodeIf(yyDollar[2].node_list, yyDollar[3].node.(*NodeBlock))
 			yyVAL.node = NewNodeIfElse(nIf, yyDollar[4].node_list, yyDollar[5].node)
 		}
-	case 170:
+	case 218:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyDollar[1].node_list = append(yyDollar[1].node_list, yyDollar[2].node)
 			yyVAL.node_list = yyDollar[1].node_list
 		}
-	case 171:
+	case 219:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node_list = make([]Node, 0)
 		}
-	case 172:
+	case 220:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.node = NewNodeIf(yyDollar[3].node_list, yyDollar[4].node.(*NodeBlock))
 		}
-	case 173:
+	case 221:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.node = NewNodeIf(nil, yyDollar[2].node.(*NodeBlock))
 		}
-	case 174:
+	case 222:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.node = nil
@@ -20,6 +20,7 @@
 package ast
 
 import (
+	"fmt"
 	"gdlang/lib/runtime"
 	"gdlang/src/gd/scanner"
 	"path"
@@ -65,6 +66,12 @@ type NodePackage struct {
 	// Public import references that are required from the package
 	// Example: use a.b.c {object, ...}
 	Imports []Node
+	// Imports all the public objects of the package
+	// Example: use a.b.c {*}
+	IsWildcard bool
+	// Name used to access the public objects of the package
+	// Example: use a.b.c as h
+	Alias *NodeIdent
 	// Inferences
 	InferredMode         runtime.GDPackageMode
 	InferredPath         string
@@ -84,7 +91,15 @@ func (p *NodePackage) GetPath() string {
 }
 
 func NewNodePackage(packagePath []Node, imports []Node) *NodePackage {
-	return &NodePackage{packagePath, imports, runtime.PackageModeSource, "", "", BaseNode{}}
+	return &NodePackage{packagePath, imports, false, nil, runtime.PackageModeSource, "", "", BaseNode{}}
+}
+
+func NewNodeWildcardPackage(packagePath []Node) *NodePackage {
+	return &NodePackage{packagePath, make([]Node, 0), true, nil, runtime.PackageModeSource, "", "", BaseNode{}}
+}
+
+func NewNodeAliasPackage(packagePath []Node, alias *NodeIdent) *NodePackage {
+	return &NodePackage{packagePath, make([]Node, 0), false, alias, runtime.PackageModeSource, "", "", BaseNode{}}
 }
 
 // Node for type definitions
@@ -102,6 +117,14 @@ func NewNodeTypeAlias(isPub bool, ident *NodeIdent, identType runtime.GDTypable)
 	return &NodeTypeAlias{isPub, ident, identType, BaseNode{}}
 }
 
+// The nominal type is identified by the position of its declaration
+func NewNodeNominalTypeAlias(isPub bool, ident *NodeIdent, identType runtime.GDTypable) *NodeTypeAlias {
+	pos := ident.Position
+	decl := runtime.NominalDeclOf(fmt.Sprintf("%s:%d:%d", pos.Filename, pos.Line, pos.ColStart))
+
+	return NewNodeTypeAlias(isPub, ident, runtime.NewGDNominalType(runtime.NewGDStringIdent(ident.Lit), decl, identType))
+}
+
 // Ident with type
 
 type NodeIdentWithType struct {
@@ -261,6 +284,28 @@ func NewNodeArray(exprStart, exprEnd Node, nodes []Node) *NodeArray {
 	return &NodeArray{exprStart, exprEnd, nodes, BaseNode{}}
 }
 
+// Set
+
+type NodeSetLiteral struct {
+	// It is used to get the position of the set
+	// when the set is empty.
+	exprStart, exprEnd Node
+	Nodes              []Node
+	BaseNode
+}
+
+func (s *NodeSetLiteral) GetPosition() scanner.Position {
+	if len(s.Nodes) == 0 {
+		return GetStartEndPosition([]Node{s.exprStart, s.exprEnd})
+	}
+
+	return GetStartEndPosition(s.Nodes)
+}
+
+func NewNodeSetLiteral(exprStart, exprEnd Node, nodes []Node) *NodeSetLiteral {
+	return &NodeSetLiteral{exprStart, exprEnd, nodes, BaseNode{}}
+}
+
 // Label
 
 type NodeLabel struct {
@@ -280,13 +325,15 @@ func NewNodeLabel(ident NodeIdent) *NodeLabel {
 type NodeCallExpr struct {
 	Expr Node
 	Args []Node
+	// Set when the call is wrapped in parentheses, as in `(f(a))`
+	IsParenthesized bool
 	BaseNode
 }
 
 func (s *NodeCallExpr) GetPosition() scanner.Position { return s.Expr.GetPosition() }
 
 func NewNodeCallExpr(expr Node, args []Node) *NodeCallExpr {
-	return &NodeCallExpr{expr, args, BaseNode{}}
+	return &NodeCallExpr{expr, args, false, BaseNode{}}
 }
 
 // expr.?[IdxExpr]
@@ -310,11 +357,14 @@ type NodeSafeDotExpr struct {
 	Expr      Node
 	Ident     Node
 	IsNilSafe bool
+	// Inferences
+	// Set when the expression accesses an object of a package alias, e.g. `h.get`
+	InferredPackageMember bool
 	BaseNode
 }
 
 func (s *NodeSafeDotExpr) GetPosition() scanner.Position { return s.Expr.GetPosition() }
 
 func NewNodeSafeDotExpr(node Node, isNilSafe bool, ident Node) *NodeSafeDotExpr {
-	return &NodeSafeDotExpr{node, ident, isNilSafe, BaseNode{}}
+	return &NodeSafeDotExpr{node, ident, isNilSafe, false, BaseNode{}}
 }
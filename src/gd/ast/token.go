@@ -33,6 +33,8 @@ var ScannerTokens = map[scanner.Token]int{
 	scanner.FLOAT:   LFLOAT,
 	scanner.STRING:  LSTRING,
 	scanner.IMAG:    LIMAG,
+	scanner.BIGINT:  LBIGINT,
+	scanner.DECIMAL: LDECIMAL,
 	scanner.CHAR:    LCHAR,
 	scanner.COMMENT: LCOMMENT,
 
@@ -51,13 +53,22 @@ var ScannerTokens = map[scanner.Token]int{
 	scanner.QUO_ASSIGN: LQUO_ASSIGN,
 	scanner.REM_ASSIGN: LREM_ASSIGN,
 
+	scanner.ADD_WRAP: LADD_WRAP,
+	scanner.SUB_WRAP: LSUB_WRAP,
+	scanner.MUL_WRAP: LMUL_WRAP,
+	scanner.ADD_SAT:  LADD_SAT,
+	scanner.SUB_SAT:  LSUB_SAT,
+	scanner.MUL_SAT:  LMUL_SAT,
+
 	scanner.ARROW: LARROW,
 
 	scanner.LAND: LLAND,
 	scanner.LOR:  LLOR,
 	scanner.NOT:  LNOT,
 
+	scanner.AND:    LAND,
 	scanner.OR:     LOR,
+	scanner.PIPE:   LPIPE,
 	scanner.LSHIFT: LLSHIFT,
 	scanner.RSHIFT: LRSHIFT,
 
@@ -83,6 +94,9 @@ var ScannerTokens = map[scanner.Token]int{
 	scanner.SEMICOLON: LSEMICOLON,
 	scanner.COLON:     LCOLON,
 
+	scanner.LSET_BRACE: LLSET_BRACE,
+	scanner.RSET_BRACE: LRSET_BRACE,
+
 	scanner.USE:       LUSE,
 	scanner.SET:       LSET,
 	scanner.PUB:       LPUB,
@@ -95,6 +109,7 @@ var ScannerTokens = map[scanner.Token]int{
 	scanner.RETURN:    LRETURN,
 	scanner.BREAK:     LBREAK,
 	scanner.TYPEALIAS: LTYPEALIAS,
+	scanner.TYPE:      LTYPE,
 	scanner.AS:        LAS,
 
 	scanner.TANY:     LTANY,
@@ -104,6 +119,18 @@ var ScannerTokens = map[scanner.Token]int{
 	scanner.TCOMPLEX: LTCOMPLEX,
 	scanner.TSTRING:  LTSTRING,
 	scanner.TCHAR:    LTCHAR,
+	scanner.TBIGINT:  LTBIGINT,
+	scanner.TDECIMAL: LTDECIMAL,
+
+	scanner.TINT8:   LTINT8,
+	scanner.TINT16:  LTINT16,
+	scanner.TINT32:  LTINT32,
+	scanner.TINT64:  LTINT64,
+	scanner.TUINT8:  LTUINT8,
+	scanner.TUINT16: LTUINT16,
+	scanner.TUINT32: LTUINT32,
+	scanner.TUINT64: LTUINT64,
+	scanner.TBYTE:   LTBYTE,
 
 	scanner.TRUE:  LTRUE,
 	scanner.FALSE: LFALSE,
@@ -116,6 +143,8 @@ var LexTokens = map[string]scanner.Token{
 	"LFLOAT":   scanner.FLOAT,
 	"LSTRING":  scanner.STRING,
 	"LIMAG":    scanner.IMAG,
+	"LBIGINT":  scanner.BIGINT,
+	"LDECIMAL": scanner.DECIMAL,
 	"LCHAR":    scanner.CHAR,
 	"LCOMMENT": scanner.COMMENT,
 
@@ -133,15 +162,24 @@ var LexTokens = map[string]scanner.Token{
 	"LQUO_ASSIGN": scanner.QUO_ASSIGN,
 	"LREM_ASSIGN": scanner.REM_ASSIGN,
 
+	"LADD_WRAP": scanner.ADD_WRAP,
+	"LSUB_WRAP": scanner.SUB_WRAP,
+	"LMUL_WRAP": scanner.MUL_WRAP,
+	"LADD_SAT":  scanner.ADD_SAT,
+	"LSUB_SAT":  scanner.SUB_SAT,
+	"LMUL_SAT":  scanner.MUL_SAT,
+
 	"LARROW": scanner.ARROW,
 
 	"LLAND": scanner.LAND,
 	"LLOR":  scanner.LOR,
 	"LNOT":  scanner.NOT,
+	"LAND":  scanner.AND,
 
 	"LEQL":    scanner.EQL,
 	"LLSS":    scanner.LSS,
 	"LGTR":    scanner.GTR,
+	"LPIPE":   scanner.PIPE,
 	"LLSHIFT": scanner.LSHIFT,
 	"LRSHIFT": scanner.RSHIFT,
 	"LASSIGN": scanner.ASSIGN,
@@ -163,6 +201,9 @@ var LexTokens = map[string]scanner.Token{
 	"LSEMICOLON": scanner.SEMICOLON,
 	"LCOLON":     scanner.COLON,
 
+	"LLSET_BRACE": scanner.LSET_BRACE,
+	"LRSET_BRACE": scanner.RSET_BRACE,
+
 	"LUSE":       scanner.USE,
 	"LSET":       scanner.SET,
 	"LPUB":       scanner.PUB,
@@ -175,6 +216,7 @@ var LexTokens = map[string]scanner.Token{
 	"LRETURN":    scanner.RETURN,
 	"LBREAK":     scanner.BREAK,
 	"LTYPEALIAS": scanner.TYPEALIAS,
+	"LTYPE":      scanner.TYPE,
 	"LAS":        scanner.AS,
 
 	"LTANY":     scanner.TANY,
@@ -184,6 +226,18 @@ var LexTokens = map[string]scanner.Token{
 	"LTCOMPLEX": scanner.TCOMPLEX,
 	"LTSTRING":  scanner.TSTRING,
 	"LTCHAR":    scanner.TCHAR,
+	"LTBIGINT":  scanner.TBIGINT,
+	"LTDECIMAL": scanner.TDECIMAL,
+
+	"LTINT8":   scanner.TINT8,
+	"LTINT16":  scanner.TINT16,
+	"LTINT32":  scanner.TINT32,
+	"LTINT64":  scanner.TINT64,
+	"LTUINT8":  scanner.TUINT8,
+	"LTUINT16": scanner.TUINT16,
+	"LTUINT32": scanner.TUINT32,
+	"LTUINT64": scanner.TUINT64,
+	"LTBYTE":   scanner.TBYTE,
 
 	"LTRUE":  scanner.TRUE,
 	"LFALSE": scanner.FALSE,
@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ast
+
+// Traverses the node and its children in source order, the children of a node
+// are only visited when f returns true for the node, followed by a call of f(nil)
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+
+	inspectNodes := func(nodes ...Node) {
+		for _, child := range nodes {
+			Inspect(child, f)
+		}
+	}
+
+	switch node := node.(type) {
+	case *NodeFile:
+		inspectNodes(node.Packages...)
+		inspectNodes(node.Nodes...)
+	case *NodePackage:
+		inspectNodes(node.PackagePath...)
+		// The imports of wildcards and aliases are not in the source code
+		if !node.IsWildcard && node.Alias == nil {
+			inspectNodes(node.Imports...)
+		}
+
+		if node.Alias != nil {
+			Inspect(node.Alias, f)
+		}
+	case *NodeTypeAlias:
+		Inspect(node.Ident, f)
+	case *NodeIdentWithType:
+		Inspect(node.Ident, f)
+	case *NodeCastExpr:
+		Inspect(node.Expr, f)
+	case *NodeExprOperation:
+		inspectNodes(node.L, node.R)
+	case *NodeEllipsisExpr:
+		Inspect(node.Expr, f)
+	case *NodeStruct:
+		inspectNodes(node.Nodes...)
+	case *NodeStructAttr:
+		inspectNodes(node.Ident, node.Expr)
+	case *NodeTuple:
+		inspectNodes(node.Nodes...)
+	case *NodeArray:
+		inspectNodes(node.Nodes...)
+	case *NodeSetLiteral:
+		inspectNodes(node.Nodes...)
+	case *NodeMutCollectionOp:
+		inspectNodes(node.L, node.R)
+	case *NodeLabel:
+		Inspect(&node.Ident, f)
+	case *NodeCallExpr:
+		Inspect(node.Expr, f)
+		inspectNodes(node.Args...)
+	case *NodeIterIdxExpr:
+		inspectNodes(node.Expr, node.IdxExpr)
+	case *NodeSafeDotExpr:
+		inspectNodes(node.Expr, node.Ident)
+	case *NodeBlock:
+		inspectNodes(node.Nodes...)
+	case *NodeForIn:
+		inspectNodes(node.Sets, node.Expr)
+		Inspect(node.Block, f)
+	case *NodeForIf:
+		Inspect(node.Sets, f)
+		inspectNodes(node.Conditions...)
+		Inspect(node.Block, f)
+	case *NodeComprehension:
+		inspectNodes(node.Expr, node.Sets, node.IterExpr)
+		inspectNodes(node.Conditions...)
+	case *NodeTernaryIf:
+		inspectNodes(node.Expr, node.Then, node.Else)
+	case *NodeIf:
+		inspectNodes(node.Conditions...)
+		if node.Block != nil {
+			Inspect(node.Block, f)
+		}
+	case *NodeIfElse:
+		Inspect(node.If, f)
+		inspectNodes(node.ElseIf...)
+		Inspect(node.Else, f)
+	case *NodeFunc:
+		Inspect(node.Ident, f)
+		Inspect(node.Block, f)
+	case *NodeLambda:
+		Inspect(node.Block, f)
+	case *NodeReturn:
+		Inspect(node.Expr, f)
+	case *NodeSharedExpr:
+		Inspect(node.Expr, f)
+	case *NodeSets:
+		inspectNodes(node.Nodes...)
+	case *NodeSet:
+		inspectNodes(node.IdentWithType, node.Expr)
+	case *NodeUpdateSet:
+		inspectNodes(node.IdentExpr, node.Expr)
+	}
+
+	f(nil)
+}
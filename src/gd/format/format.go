@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+// Package format prints the source code of GDLang in its canonical style.
+//
+// The source is parsed into the AST and printed back node by node,
+// with four spaces of indentation, a statement per line and the lists
+// broken into one element per line, with a trailing comma, when the
+// first element starts on a new line. The AST doesn't keep the comments,
+// so the source is scanned again with the comments, and the comments are
+// printed before the first token that follows them in the source.
+package format
+
+import (
+	"bytes"
+	"errors"
+	"gdlang/src/gd/ast"
+	"gdlang/src/gd/scanner"
+)
+
+// A token of the source, it is used to place the comments and
+// the blank lines, and to keep the literals as they were written
+type token struct {
+	tok     scanner.Token
+	lit     string
+	line    int
+	col     int
+	endLine int
+}
+
+// Source formats the source code of a file, the filename is only used for the errors
+func Source(filename string, src []byte) ([]byte, error) {
+	fileSet := scanner.NewFileSet()
+	file, err := fileSet.AddFile(filename, fileSet.Base(), len(src))
+	if err != nil {
+		return nil, err
+	}
+
+	builder := ast.NAstBuilderProc()
+	defer builder.Dispose()
+
+	err = builder.Init(file, src)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := scanTokens(file, src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &printer{tokens: tokens}
+	p.file(root)
+
+	return p.bytes(), nil
+}
+
+// Scans the tokens of the source with the comments, the semicolons are left out
+// since the printer ends every statement with a new line
+func scanTokens(file *scanner.File, src []byte) ([]token, error) {
+	var errs []error
+	errorHandler := func(msg string, pos scanner.Position) {
+		errs = append(errs, errors.New(msg))
+	}
+
+	var s scanner.Scanner
+	err := s.Init(file, src, errorHandler, scanner.ScanComments)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Dispose()
+
+	var tokens []token
+	for {
+		start, end, tok, lit := s.Scan()
+		if tok == scanner.EOF {
+			break
+		}
+
+		if tok == scanner.SEMICOLON {
+			continue
+		}
+
+		if end > start {
+			end--
+		}
+
+		startPos := file.Position(start)
+		tokens = append(tokens, token{tok, lit, startPos.Line, startPos.ColStart, file.Position(end).Line})
+	}
+
+	return tokens, errors.Join(errs...)
+}
+
+func isLineComment(text string) bool { return len(text) > 1 && text[1] == '/' }
+
+func trimComment(text string) string {
+	if isLineComment(text) {
+		return string(bytes.TrimRight([]byte(text), " \t"))
+	}
+
+	return text
+}
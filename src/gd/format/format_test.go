@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package format_test
+
+import (
+	"gdlang/src/comn"
+	"gdlang/src/gd/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func init() {
+	comn.PrettyPrintErrors = false
+}
+
+// Lines of the source joined by new lines, so the indentation of the test doesn't leak into the source
+func src(lines ...string) string { return strings.Join(lines, "\n") + "\n" }
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+	}{
+		// Spacing and indentation
+		{
+			src("pub   func main(){", "print( 1+2*3 )", "}"),
+			src("pub func main() {", "    print(1 + 2 * 3)", "}"),
+		},
+		{
+			src("func add(a:int,b:int)=>int{", "return a+b", "}"),
+			src("func add(a: int, b: int) => int {", "    return a + b", "}"),
+		},
+		{
+			src("func f(){}"),
+			src("func f() {}"),
+		},
+		// Parentheses are only kept when the precedence requires them
+		{
+			src("set a = (1 + 2) * 3", "set b = 1 + (2 * 3)", "set c = -(-1)"),
+			src("set a = (1 + 2) * 3", "set b = 1 + 2 * 3", "set c = -(-1)"),
+		},
+		{
+			src("set isAdmin = (\"admin\" in roles)"),
+			src("set isAdmin = (\"admin\" in roles)"),
+		},
+		// Sugar of the parser is printed back as written
+		{
+			src("pub func main() {", "set a = 1", "a+=2", "print(a|>inc|>add(1))", "}"),
+			src("pub func main() {", "    set a = 1", "    a += 2", "    print(a |> inc |> add(1))", "}"),
+		},
+		// A parenthesized call is kept as the target of a pipeline, since its result is called
+		{
+			src("set a = [5|>(g(2)), 6|>g(1), 8, 7|>(3|>h)]"),
+			src("set a = [5 |> (g(2)), 6 |> g(1), 8, 7 |> (3 |> h)]"),
+		},
+		{
+			src("set (a,b) = (1,2)", "set c: (int, byte) = (3,)", "set d: (,) = (,)"),
+			src("set (a, b) = (1, 2)", "set c: (int, byte) = (3,)", "set d: (,) = (,)"),
+		},
+		{
+			src("use math {sqrt,abs}", "use http as h", "use strings {*}"),
+			src("use math {sqrt, abs}", "use http as h", "use strings {*}"),
+		},
+		{
+			src("set s = {|1,2|}", "set xs = [x*2 for x in ys if x>0]"),
+			src("set s = {| 1, 2 |}", "set xs = [x * 2 for x in ys if x > 0]"),
+		},
+		// Comments and blank lines
+		{
+			src("// Entry point", "pub func main() { // trailing", "", "", "", "    // Own line", "print(1) /* block */", "", "    // End of block", "}", "// End of file"),
+			src("// Entry point", "pub func main() { // trailing", "    // Own line", "    print(1) /* block */", "", "    // End of block", "}", "// End of file"),
+		},
+		{
+			src("func f() {", "// Only a comment", "}"),
+			src("func f() {", "    // Only a comment", "}"),
+		},
+		// Multi-line lists keep a line per element and a trailing comma
+		{
+			src("set xs = [", "1,", "2, // two", "]", "set ys = [1, 2,]"),
+			src("set xs = [", "    1,", "    2, // two", "]", "set ys = [1, 2]"),
+		},
+		{
+			src("typealias Point = {", "x: int,", "y: int}"),
+			src("typealias Point = {", "    x: int,", "    y: int,", "}"),
+		},
+		{
+			src("pub func main() {", "if a {", "print(1)", "} else if b {", "print(2)", "} else {", "print(3)", "}", "for set i, x in xs {", "break", "}", "}"),
+			src("pub func main() {", "    if a {", "        print(1)", "    } else if b {", "        print(2)", "    } else {", "        print(3)", "    }", "    for set i, x in xs {", "        break", "    }", "}"),
+		},
+	}
+
+	for _, test := range tests {
+		res, err := format.Source("test.gd", []byte(test.src))
+		if err != nil {
+			t.Errorf("Expected no error for %q but got %q", test.src, err.Error())
+			continue
+		}
+
+		if string(res) != test.expected {
+			t.Errorf("Expected the source %q to be formatted as\n%s\nbut got\n%s", test.src, test.expected, res)
+			continue
+		}
+
+		// The formatted source is formatted again without changes
+		again, err := format.Source("test.gd", res)
+		if err != nil || string(again) != string(res) {
+			t.Errorf("Expected the formatting of %q to be idempotent but got\n%s", test.src, again)
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	tests := []struct {
+		src    string
+		errMsg string
+	}{
+		{"pub func main() {", "expected"},
+		{"set a = \"abc", "not terminated"},
+	}
+
+	for _, test := range tests {
+		_, err := format.Source("test.gd", []byte(test.src))
+		if err == nil {
+			t.Errorf("Expected an error for %q", test.src)
+		} else if !strings.Contains(err.Error(), test.errMsg) {
+			t.Errorf("Expected an error to contain %q but got %q", test.errMsg, err.Error())
+		}
+	}
+}
+
+// The examples are already formatted
+func TestFormatExamples(t *testing.T) {
+	files, err := filepath.Glob("../../../examples/*/*.gd")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res, err := format.Source(file, src)
+		if err != nil {
+			t.Errorf("Expected no error for %q but got %q", file, err.Error())
+		} else if strings.TrimSuffix(string(res), "\n") != strings.TrimSuffix(string(src), "\n") {
+			t.Errorf("Expected the example %q to be formatted but got\n%s", file, res)
+		}
+	}
+}
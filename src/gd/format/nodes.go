@@ -0,0 +1,786 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package format
+
+import (
+	"cmp"
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/ast"
+	"gdlang/src/gd/scanner"
+	"slices"
+)
+
+// Precedence of the expressions from the grammar, from the lowest to the highest.
+// The parentheses are not kept by the AST, so they are added back
+// when an operand has a lower precedence than its operation
+const (
+	precLowest = iota
+	precCast
+	precMutCollection
+	precTernary
+	precPipe
+	precOr
+	precAnd
+	precCompare
+	precUnion
+	precIntersection
+	precAdd
+	precMul
+	precUnary
+	precPrimary
+)
+
+type binaryOp struct {
+	tok  scanner.Token
+	prec int
+}
+
+var binaryOps = map[runtime.ExprOperationType]binaryOp{
+	runtime.ExprOperationOr:           {scanner.LOR, precOr},
+	runtime.ExprOperationAnd:          {scanner.LAND, precAnd},
+	runtime.ExprOperationEqual:        {scanner.EQL, precCompare},
+	runtime.ExprOperationNotEqual:     {scanner.NEQ, precCompare},
+	runtime.ExprOperationLess:         {scanner.LSS, precCompare},
+	runtime.ExprOperationGreater:      {scanner.GTR, precCompare},
+	runtime.ExprOperationLessEqual:    {scanner.LEQ, precCompare},
+	runtime.ExprOperationGreaterEqual: {scanner.GEQ, precCompare},
+	runtime.ExprOperationIn:           {scanner.IN, precCompare},
+	runtime.ExprOperationUnion:        {scanner.OR, precUnion},
+	runtime.ExprOperationIntersection: {scanner.AND, precIntersection},
+	runtime.ExprOperationAdd:          {scanner.ADD, precAdd},
+	runtime.ExprOperationSubtract:     {scanner.SUB, precAdd},
+	runtime.ExprOperationAddWrap:      {scanner.ADD_WRAP, precAdd},
+	runtime.ExprOperationSubtractWrap: {scanner.SUB_WRAP, precAdd},
+	runtime.ExprOperationAddSat:       {scanner.ADD_SAT, precAdd},
+	runtime.ExprOperationSubtractSat:  {scanner.SUB_SAT, precAdd},
+	runtime.ExprOperationMultiply:     {scanner.MUL, precMul},
+	runtime.ExprOperationQuo:          {scanner.QUO, precMul},
+	runtime.ExprOperationRem:          {scanner.REM, precMul},
+	runtime.ExprOperationMultiplyWrap: {scanner.MUL_WRAP, precMul},
+	runtime.ExprOperationMultiplySat:  {scanner.MUL_SAT, precMul},
+}
+
+var unaryOps = map[runtime.ExprOperationType]scanner.Token{
+	runtime.ExprOperationUnaryPlus:  scanner.ADD,
+	runtime.ExprOperationUnaryMinus: scanner.SUB,
+	runtime.ExprOperationNot:        scanner.NOT,
+}
+
+// The compound assignments are desugared by the parser, e.g. `a += 1` into `a = a + 1`
+var assignOps = map[runtime.ExprOperationType]scanner.Token{
+	runtime.ExprOperationAdd:      scanner.ADD_ASSIGN,
+	runtime.ExprOperationSubtract: scanner.SUB_ASSIGN,
+	runtime.ExprOperationMultiply: scanner.MUL_ASSIGN,
+	runtime.ExprOperationQuo:      scanner.QUO_ASSIGN,
+	runtime.ExprOperationRem:      scanner.REM_ASSIGN,
+}
+
+var typeTokens = map[runtime.GDType]scanner.Token{
+	runtime.GDAnyType:     scanner.TANY,
+	runtime.GDBoolType:    scanner.TBOOL,
+	runtime.GDIntType:     scanner.TINT,
+	runtime.GDFloatType:   scanner.TFLOAT,
+	runtime.GDComplexType: scanner.TCOMPLEX,
+	runtime.GDStringType:  scanner.TSTRING,
+	runtime.GDCharType:    scanner.TCHAR,
+	runtime.GDBigIntType:  scanner.TBIGINT,
+	runtime.GDDecimalType: scanner.TDECIMAL,
+	runtime.GDInt8Type:    scanner.TINT8,
+	runtime.GDInt16Type:   scanner.TINT16,
+	runtime.GDInt32Type:   scanner.TINT32,
+	runtime.GDInt64Type:   scanner.TINT64,
+	runtime.GDUInt8Type:   scanner.TUINT8,
+	runtime.GDUInt16Type:  scanner.TUINT16,
+	runtime.GDUInt32Type:  scanner.TUINT32,
+	runtime.GDUInt64Type:  scanner.TUINT64,
+	runtime.GDNilType:     scanner.NIL,
+}
+
+func (p *printer) file(root *ast.NodeFile) {
+	for _, node := range root.Packages {
+		p.use(node.(*ast.NodePackage))
+		p.newline()
+	}
+
+	for _, node := range root.Nodes {
+		// Only the declarations of the file can be public
+		switch node := node.(type) {
+		case *ast.NodeFunc:
+			p.function(node, node.IsPub)
+		case *ast.NodeTypeAlias:
+			p.typeAlias(node)
+		case *ast.NodeSets:
+			p.sets(node, true)
+		default:
+			p.stmt(node)
+		}
+
+		p.newline()
+	}
+}
+
+func (p *printer) use(pkg *ast.NodePackage) {
+	p.token(scanner.USE)
+	p.write(" ")
+	for i, ident := range pkg.PackagePath {
+		if i > 0 {
+			p.token(scanner.PERIOD)
+		}
+
+		p.ident(ident.(*ast.NodeIdent))
+	}
+
+	p.write(" ")
+	switch {
+	case pkg.IsWildcard:
+		p.token(scanner.LBRACE)
+		p.token(scanner.MUL)
+		p.token(scanner.RBRACE)
+	case pkg.Alias != nil:
+		p.token(scanner.AS)
+		p.write(" ")
+		p.ident(pkg.Alias)
+	default:
+		// The parser keeps the imports in reverse order
+		imports := slices.SortedFunc(slices.Values(pkg.Imports), func(a, b ast.Node) int {
+			return comparePositions(a.GetPosition(), b.GetPosition())
+		})
+
+		p.list(scanner.LBRACE, scanner.RBRACE, len(imports), false, false, func(i int) {
+			p.ident(imports[i].(*ast.NodeIdent))
+		})
+	}
+}
+
+func (p *printer) typeAlias(alias *ast.NodeTypeAlias) {
+	if alias.IsPub {
+		p.token(scanner.PUB)
+		p.write(" ")
+	}
+
+	typ := alias.Type
+	if nominal, isNominal := typ.(*runtime.GDNominalType); isNominal && nominal.Ident.ToString() == alias.Ident.Lit {
+		p.token(scanner.TYPE)
+		typ = nominal.Type
+	} else {
+		p.token(scanner.TYPEALIAS)
+	}
+
+	p.write(" ")
+	p.ident(alias.Ident)
+	p.write(" ")
+	p.token(scanner.ASSIGN)
+	p.write(" ")
+	p.typ(typ)
+}
+
+func (p *printer) function(fn *ast.NodeFunc, isPub bool) {
+	if isPub {
+		p.token(scanner.PUB)
+		p.write(" ")
+	}
+
+	p.token(scanner.FUNC)
+	p.write(" ")
+	if fn.IsOperator {
+		tok, isUnary := unaryOps[fn.Op]
+		if !isUnary {
+			tok = binaryOps[fn.Op].tok
+		}
+
+		p.token(tok)
+	} else {
+		p.ident(fn.Ident)
+	}
+
+	p.signature(fn.Type)
+	p.write(" ")
+	p.block(fn.Block)
+}
+
+// Arguments and return type of a function, e.g. `(a: int, b: string) => bool`
+func (p *printer) signature(typ *runtime.GDLambdaType) {
+	p.token(scanner.LPAREN)
+	for i, arg := range typ.ArgTypes {
+		if i > 0 {
+			p.write(", ")
+		}
+
+		p.text(scanner.IDENT, arg.Key.ToString())
+		p.token(scanner.COLON)
+		p.write(" ")
+		p.typ(arg.Value)
+	}
+
+	if typ.IsVariadic {
+		p.write(", ")
+		p.token(scanner.ELLIPSIS)
+	}
+
+	p.token(scanner.RPAREN)
+
+	// Functions without a return type return nil
+	if typ.ReturnType != nil && typ.ReturnType != runtime.GDNilType {
+		p.write(" ")
+		p.token(scanner.ARROW)
+		p.write(" ")
+		p.typ(typ.ReturnType)
+	}
+}
+
+func (p *printer) block(block *ast.NodeBlock) {
+	p.token(scanner.LBRACE)
+	if len(block.Nodes) == 0 && !p.hasComments() {
+		p.token(scanner.RBRACE)
+		return
+	}
+
+	p.indent++
+	p.afterOpen = true
+	p.newline()
+	for _, node := range block.Nodes {
+		p.stmt(node)
+		p.newline()
+	}
+
+	p.flushComments()
+	p.indent--
+	p.token(scanner.RBRACE)
+}
+
+func (p *printer) stmt(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.NodeSets:
+		p.sets(node, true)
+	case *ast.NodeFunc:
+		p.function(node, false)
+	case *ast.NodeTypeAlias:
+		p.typeAlias(node)
+	case *ast.NodeUpdateSet:
+		p.expr(node.IdentExpr, precLowest)
+		p.write(" ")
+
+		// The compound assignment shares the node of the updated object with the operation
+		if op, isOp := node.Expr.(*ast.NodeExprOperation); isOp && op.L == node.IdentExpr && assignOps[op.Op] != 0 {
+			p.token(assignOps[op.Op])
+			p.write(" ")
+			p.expr(op.R, precLowest)
+			return
+		}
+
+		p.token(scanner.ASSIGN)
+		p.write(" ")
+		p.expr(node.Expr, precLowest)
+	case *ast.NodeForIn:
+		p.token(scanner.FOR)
+		p.write(" ")
+		p.sets(node.Sets.(*ast.NodeSets), true)
+		p.write(" ")
+		p.token(scanner.IN)
+		p.write(" ")
+		p.expr(node.Expr, precLowest)
+		p.write(" ")
+		p.block(node.Block)
+	case *ast.NodeForIf:
+		p.token(scanner.FOR)
+		p.write(" ")
+		if node.Sets != nil {
+			p.sets(node.Sets.(*ast.NodeSets), true)
+			p.write(" ")
+		}
+
+		if len(node.Conditions) > 0 {
+			p.token(scanner.IF)
+			p.write(" ")
+			p.exprs(node.Conditions)
+			p.write(" ")
+		}
+
+		p.block(node.Block)
+	case *ast.NodeIfElse:
+		p.ifBlock(node.If.(*ast.NodeIf))
+		for _, elseIf := range node.ElseIf {
+			p.write(" ")
+			p.token(scanner.ELSE)
+			p.write(" ")
+			p.ifBlock(elseIf.(*ast.NodeIf))
+		}
+
+		if node.Else != nil {
+			p.write(" ")
+			p.token(scanner.ELSE)
+			p.write(" ")
+			p.block(node.Else.(*ast.NodeIf).Block)
+		}
+	case *ast.NodeReturn:
+		p.token(scanner.RETURN)
+		if node.Expr != nil {
+			p.write(" ")
+			p.expr(node.Expr, precLowest)
+		}
+	case *ast.NodeBreak:
+		p.token(scanner.BREAK)
+	default:
+		p.expr(node, precLowest)
+	}
+}
+
+func (p *printer) ifBlock(node *ast.NodeIf) {
+	p.token(scanner.IF)
+	p.write(" ")
+	p.exprs(node.Conditions)
+	p.write(" ")
+	p.block(node.Block)
+}
+
+// Objects declared by `set`, the objects of a deconstruction share the same expression,
+// e.g. `set a = 1, (b, c) = (2, 3)`
+func (p *printer) sets(sets *ast.NodeSets, hasKeyword bool) {
+	if hasKeyword {
+		if set, isSet := sets.Nodes[0].(*ast.NodeSet); isSet && set.IsPub {
+			p.token(scanner.PUB)
+			p.write(" ")
+		}
+
+		p.token(scanner.SET)
+		p.write(" ")
+	}
+
+	for i := 0; i < len(sets.Nodes); {
+		if i > 0 {
+			p.write(", ")
+		}
+
+		set := sets.Nodes[i].(*ast.NodeSet)
+		shared, isShared := set.Expr.(*ast.NodeSharedExpr)
+		if !isShared {
+			p.setIdent(set)
+			if set.Expr != nil {
+				p.assign(set.Expr)
+			}
+
+			i++
+			continue
+		}
+
+		p.token(scanner.LPAREN)
+		for first := i; i < len(sets.Nodes) && sets.Nodes[i].(*ast.NodeSet).Expr == shared; i++ {
+			if i > first {
+				p.write(", ")
+			}
+
+			p.setIdent(sets.Nodes[i].(*ast.NodeSet))
+		}
+
+		p.token(scanner.RPAREN)
+		if shared.Expr != nil {
+			p.assign(shared.Expr)
+		}
+	}
+}
+
+func (p *printer) setIdent(set *ast.NodeSet) {
+	if set.IsConst {
+		p.token(scanner.CONST)
+		p.write(" ")
+	}
+
+	p.identWithType(set.IdentWithType)
+}
+
+func (p *printer) identWithType(ident *ast.NodeIdentWithType) {
+	p.ident(ident.Ident)
+	if ident.Type != nil && ident.Type != runtime.GDUntypedType {
+		p.token(scanner.COLON)
+		p.write(" ")
+		p.typ(ident.Type)
+	}
+}
+
+// The value of a declaration ends before `in`, since `in` also ends
+// the objects of a `for in` loop, so a membership check is parenthesized
+func (p *printer) assign(expr ast.Node) {
+	p.write(" ")
+	p.token(scanner.ASSIGN)
+	p.write(" ")
+	if hasBareIn(expr) {
+		p.write("(")
+		p.expr(expr, precLowest)
+		p.write(")")
+		return
+	}
+
+	p.expr(expr, precLowest)
+}
+
+func (p *printer) exprs(nodes []ast.Node) {
+	for i, node := range nodes {
+		if i > 0 {
+			p.write(", ")
+		}
+
+		p.expr(node, precLowest)
+	}
+}
+
+// Prints the expression, with parentheses when its precedence is lower than the minimum
+func (p *printer) expr(node ast.Node, minPrec int) {
+	if precedence(node) < minPrec {
+		p.write("(")
+		p.expr(node, precLowest)
+		p.write(")")
+		return
+	}
+
+	switch node := node.(type) {
+	case *ast.NodeIdent:
+		p.ident(node)
+	case *ast.NodeLiteral:
+		p.literal(node)
+	case *ast.NodeExprOperation:
+		if tok, isUnary := unaryOps[node.Op]; isUnary {
+			p.token(tok)
+
+			// A sign after a sign is parenthesized, e.g. `-(-a)`
+			operandPrec := precUnary
+			if operand, isOp := node.L.(*ast.NodeExprOperation); isOp && tok != scanner.NOT && operand.Op != runtime.ExprOperationNot && unaryOps[operand.Op] != 0 {
+				operandPrec = precPrimary
+			}
+
+			p.expr(node.L, operandPrec)
+			return
+		}
+
+		op := binaryOps[node.Op]
+		p.expr(node.L, op.prec)
+		p.write(" ")
+		p.token(op.tok)
+		p.write(" ")
+		p.expr(node.R, op.prec+1)
+	case *ast.NodeTernaryIf:
+		p.expr(node.Expr, precTernary+1)
+		p.write(" ")
+		p.token(scanner.QMARK)
+		p.write(" ")
+		p.expr(node.Then, precTernary+1)
+		p.write(" ")
+		p.token(scanner.COLON)
+		p.write(" ")
+		p.expr(node.Else, precTernary+1)
+	case *ast.NodeCastExpr:
+		p.expr(node.Expr, precCast)
+		p.write(" ")
+		p.token(scanner.AS)
+		p.write(" ")
+		p.typ(node.Type)
+	case *ast.NodeMutCollectionOp:
+		tok := scanner.LSHIFT
+		if node.Op == ast.MutableCollectionRemoveOp {
+			tok = scanner.RSHIFT
+		}
+
+		p.expr(node.L, precMutCollection)
+		p.write(" ")
+		p.token(tok)
+		p.write(" ")
+		p.expr(node.R, precMutCollection+1)
+	case *ast.NodeCallExpr:
+		p.call(node)
+	case *ast.NodeSafeDotExpr:
+		p.expr(node.Expr, precPrimary)
+		if node.IsNilSafe {
+			p.token(scanner.NSAFE)
+		} else {
+			p.token(scanner.PERIOD)
+		}
+
+		p.expr(node.Ident, precPrimary)
+	case *ast.NodeIterIdxExpr:
+		p.expr(node.Expr, precPrimary)
+		p.token(scanner.LBRACK)
+		p.expr(node.IdxExpr, precLowest)
+		p.token(scanner.RBRACK)
+	case *ast.NodeEllipsisExpr:
+		p.expr(node.Expr, precPrimary)
+		p.token(scanner.ELLIPSIS)
+	case *ast.NodeTuple:
+		p.token(scanner.LPAREN)
+		p.exprs(node.Nodes)
+
+		// A tuple of one element or without elements ends with a comma, e.g. `(a,)` or `(,)`
+		if len(node.Nodes) < 2 {
+			p.write(",")
+		}
+
+		p.token(scanner.RPAREN)
+	case *ast.NodeArray:
+		p.list(scanner.LBRACK, scanner.RBRACK, len(node.Nodes), true, false, func(i int) {
+			p.expr(node.Nodes[i], precLowest)
+		})
+	case *ast.NodeSetLiteral:
+		p.list(scanner.LSET_BRACE, scanner.RSET_BRACE, len(node.Nodes), true, true, func(i int) {
+			p.expr(node.Nodes[i], precLowest)
+		})
+	case *ast.NodeStruct:
+		p.list(scanner.LBRACE, scanner.RBRACE, len(node.Nodes), true, false, func(i int) {
+			attr := node.Nodes[i].(*ast.NodeStructAttr)
+			p.ident(attr.Ident)
+			p.token(scanner.COLON)
+			p.write(" ")
+			p.expr(attr.Expr, precLowest)
+		})
+	case *ast.NodeComprehension:
+		p.token(scanner.LBRACK)
+		p.expr(node.Expr, precLowest)
+		p.write(" ")
+		p.token(scanner.FOR)
+		p.write(" ")
+		p.sets(node.Sets.(*ast.NodeSets), false)
+		p.write(" ")
+		p.token(scanner.IN)
+		p.write(" ")
+		p.expr(node.IterExpr, precLowest)
+		for _, condition := range node.Conditions {
+			p.write(" ")
+			p.token(scanner.IF)
+			p.write(" ")
+			p.expr(condition, precLowest)
+		}
+
+		p.token(scanner.RBRACK)
+	case *ast.NodeLambda:
+		p.token(scanner.FUNC)
+		p.signature(node.Type)
+		p.write(" ")
+		p.block(node.Block)
+	default:
+		panic("expr: Unsupported node to format")
+	}
+}
+
+// The pipeline `a |> f(b)` is desugared into the call `f(a, b)`,
+// so it is a call whose first argument is before the called expression
+func (p *printer) call(call *ast.NodeCallExpr) {
+	args := call.Args
+	if isPipeline(call) {
+		p.expr(args[0], precPipe)
+		p.write(" ")
+		p.token(scanner.PIPE)
+		p.write(" ")
+		// `a |> (f(b))` calls the result of `f(b)` with `a`, so the parentheses are kept
+		callee, isCall := call.Expr.(*ast.NodeCallExpr)
+		if isCall && callee.IsParenthesized {
+			p.token(scanner.LPAREN)
+			p.expr(callee, precLowest)
+			p.token(scanner.RPAREN)
+		} else {
+			p.expr(call.Expr, precPipe+1)
+		}
+
+		// `a |> f()` is the same as `a |> f`, unless the called expression is a bare call
+		if len(args) == 1 && (!isCall || callee.IsParenthesized) {
+			return
+		}
+
+		args = args[1:]
+	} else {
+		p.expr(call.Expr, precPrimary)
+	}
+
+	p.list(scanner.LPAREN, scanner.RPAREN, len(args), true, false, func(i int) {
+		p.expr(args[i], precLowest)
+	})
+}
+
+func (p *printer) ident(ident *ast.NodeIdent) {
+	p.text(scanner.IDENT, ident.Lit)
+}
+
+// Literals are printed as they were written in the source, e.g. `0x1F` or `1_000`,
+// the value of the AST is printed when the token is not the one of the literal
+func (p *printer) literal(lit *ast.NodeLiteral) {
+	i := p.sync(lit.Token)
+	if i >= 0 && p.tokens[i].lit != "" && p.tokens[i].line == lit.Position.Line && p.tokens[i].col == lit.Position.ColStart {
+		p.write(p.tokens[i].lit)
+		return
+	}
+
+	switch lit.Token {
+	case scanner.STRING:
+		p.write(`"` + lit.Lit + `"`)
+	case scanner.CHAR:
+		p.write("'" + lit.Lit + "'")
+	case scanner.NIL, scanner.TRUE, scanner.FALSE:
+		p.write(lit.Token.Fmt())
+	default:
+		p.write(lit.Lit)
+	}
+}
+
+func (p *printer) typ(typ runtime.GDTypable) {
+	switch typ := typ.(type) {
+	case runtime.GDType:
+		tok, isToken := typeTokens[typ]
+		if !isToken {
+			p.write(typ.ToString())
+			return
+		}
+
+		// `byte` is an alias of `uint8`
+		if typ == runtime.GDByteType && p.peek() == scanner.TBYTE {
+			tok = scanner.TBYTE
+		}
+
+		p.token(tok)
+	case runtime.GDIdentRefType:
+		p.text(scanner.IDENT, typ.ToString())
+	case *runtime.GDNominalType:
+		p.text(scanner.IDENT, typ.ToString())
+	case runtime.GDUnionType:
+		p.token(scanner.LPAREN)
+		for i, t := range typ {
+			if i > 0 {
+				p.write(" ")
+				p.token(scanner.OR)
+				p.write(" ")
+			}
+
+			p.typ(t)
+		}
+
+		p.token(scanner.RPAREN)
+	case runtime.GDTupleType:
+		// The type of the empty tuple `(,)` has an untyped element
+		if len(typ) == 1 && typ[0] == runtime.GDUntypedType {
+			typ = nil
+		}
+
+		p.token(scanner.LPAREN)
+		for i, t := range typ {
+			if i > 0 {
+				p.token(scanner.COMMA)
+				p.write(" ")
+			}
+
+			p.typ(t)
+		}
+
+		if len(typ) < 2 {
+			p.token(scanner.COMMA)
+		}
+
+		p.token(scanner.RPAREN)
+	case *runtime.GDArrayType:
+		p.token(scanner.LBRACK)
+		p.typ(typ.SubType)
+		p.token(scanner.RBRACK)
+	case *runtime.GDSetType:
+		p.token(scanner.SET)
+		p.token(scanner.LSS)
+		p.typ(typ.SubType)
+		p.token(scanner.GTR)
+	case runtime.GDStructType:
+		p.list(scanner.LBRACE, scanner.RBRACE, len(typ), true, false, func(i int) {
+			attr := typ[i]
+			if attr.IsPub {
+				p.token(scanner.PUB)
+				p.write(" ")
+			}
+
+			if attr.IsConst {
+				p.token(scanner.CONST)
+				p.write(" ")
+			}
+
+			p.text(scanner.IDENT, attr.Ident.ToString())
+			p.token(scanner.COLON)
+			p.write(" ")
+			p.typ(attr.Type)
+		})
+	case *runtime.GDLambdaType:
+		p.token(scanner.FUNC)
+		p.signature(typ)
+	default:
+		p.write(typ.ToString())
+	}
+}
+
+func precedence(node ast.Node) int {
+	switch node := node.(type) {
+	case *ast.NodeCastExpr:
+		return precCast
+	case *ast.NodeMutCollectionOp:
+		return precMutCollection
+	case *ast.NodeTernaryIf:
+		return precTernary
+	case *ast.NodeCallExpr:
+		if isPipeline(node) {
+			return precPipe
+		}
+	case *ast.NodeExprOperation:
+		if _, isUnary := unaryOps[node.Op]; isUnary {
+			return precUnary
+		}
+
+		return binaryOps[node.Op].prec
+	}
+
+	return precPrimary
+}
+
+func isPipeline(call *ast.NodeCallExpr) bool {
+	if len(call.Args) == 0 {
+		return false
+	}
+
+	arg, callee := call.Args[0].GetPosition(), call.Expr.GetPosition()
+	return arg.IsValid() && callee.IsValid() && comparePositions(arg, callee) < 0
+}
+
+// Whether the expression has a membership check `in` that is not parenthesized
+func hasBareIn(node ast.Node) bool {
+	isBare := func(operand ast.Node, minPrec int) bool {
+		return operand != nil && precedence(operand) >= minPrec && hasBareIn(operand)
+	}
+
+	switch node := node.(type) {
+	case *ast.NodeExprOperation:
+		if _, isUnary := unaryOps[node.Op]; isUnary {
+			return false
+		}
+
+		prec := binaryOps[node.Op].prec
+		return node.Op == runtime.ExprOperationIn || isBare(node.L, prec) || isBare(node.R, prec+1)
+	case *ast.NodeCastExpr:
+		return isBare(node.Expr, precCast)
+	case *ast.NodeMutCollectionOp:
+		return isBare(node.L, precMutCollection) || isBare(node.R, precMutCollection+1)
+	case *ast.NodeTernaryIf:
+		return isBare(node.Expr, precTernary+1) || isBare(node.Then, precTernary+1) || isBare(node.Else, precTernary+1)
+	case *ast.NodeCallExpr:
+		return isPipeline(node) && (isBare(node.Args[0], precPipe) || isBare(node.Expr, precPipe+1))
+	}
+
+	return false
+}
+
+func comparePositions(a, b scanner.Position) int {
+	return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.ColStart, b.ColStart))
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package format
+
+import (
+	"bytes"
+	"gdlang/src/gd/scanner"
+	"strings"
+)
+
+const indentation = "    "
+
+type printer struct {
+	tokens []token
+	// Index of the next token of the source to be printed
+	cursor int
+	// Comments that were already printed
+	printed map[int]bool
+	out     bytes.Buffer
+	indent  int
+	// Number of new lines to write before the next text, two for a blank line
+	pending int
+	// The next line continues a line that was broken by a comment
+	continuation bool
+	// The last text opened a block or a list, so no blank line can follow it
+	afterOpen bool
+}
+
+func (p *printer) bytes() []byte {
+	p.flushAll()
+	if p.out.Len() > 0 {
+		p.out.WriteByte('\n')
+	}
+
+	return p.out.Bytes()
+}
+
+// Writes the text after the pending new lines and the indentation
+func (p *printer) write(text string) {
+	if p.pending > 0 {
+		if p.out.Len() > 0 {
+			p.out.WriteString(strings.Repeat("\n", p.pending))
+		}
+
+		indent := p.indent
+		if p.continuation {
+			indent++
+		}
+
+		p.out.WriteString(strings.Repeat(indentation, indent))
+		p.pending = 0
+		p.continuation = false
+	}
+
+	p.out.WriteString(text)
+	p.afterOpen = false
+}
+
+func (p *printer) newline() {
+	if p.pending == 0 {
+		p.pending = 1
+	}
+}
+
+// Writes a token of the source
+func (p *printer) token(tok scanner.Token) {
+	p.sync(tok)
+	p.write(tok.Fmt())
+}
+
+// Writes a token of the source with its own text, e.g. identifiers
+func (p *printer) text(tok scanner.Token, text string) {
+	p.sync(tok)
+	p.write(text)
+}
+
+// Moves the cursor to the next token of the source with the same kind,
+// the comments before the token are printed first. Returns the index of the token,
+// or -1 when it is not found since some tokens are not kept by the AST, e.g. parentheses
+func (p *printer) sync(tok scanner.Token) int {
+	for i := p.cursor; i < len(p.tokens); i++ {
+		if p.tokens[i].tok != tok {
+			continue
+		}
+
+		for j := p.cursor; j < i; j++ {
+			p.comment(j)
+		}
+
+		p.cursor = i + 1
+		if p.pending > 0 && !p.afterOpen && !isClosing(tok) && p.isBlankBefore(i) {
+			p.pending = 2
+		}
+
+		return i
+	}
+
+	return -1
+}
+
+// Prints the comment at the index, if it was not printed yet.
+// A comment that starts in the line of the previous token stays in that line
+func (p *printer) comment(i int) {
+	c := p.tokens[i]
+	if c.tok != scanner.COMMENT || p.printed[i] {
+		return
+	}
+
+	if p.printed == nil {
+		p.printed = make(map[int]bool)
+	}
+
+	p.printed[i] = true
+	text := trimComment(c.lit)
+
+	if p.out.Len() > 0 && i > 0 && p.tokens[i-1].endLine == c.line {
+		switch {
+		case p.pending > 0:
+			p.out.WriteString(" " + text)
+		case isLineComment(text):
+			p.writeInline(text)
+			p.pending = 1
+			p.continuation = true
+		default:
+			p.writeInline(text + " ")
+		}
+
+		return
+	}
+
+	continuation := p.continuation
+	if p.out.Len() > 0 {
+		if p.pending == 0 {
+			p.pending = 1
+			continuation = true
+		}
+
+		p.continuation = continuation
+		if !p.afterOpen && p.isBlankBefore(i) {
+			p.pending = 2
+		}
+	}
+
+	p.write(text)
+	p.pending = 1
+	p.continuation = continuation
+}
+
+// Writes a comment in the middle of a line
+func (p *printer) writeInline(text string) {
+	out := p.out.Bytes()
+	if len(out) > 0 && !strings.ContainsRune(" ([{", rune(out[len(out)-1])) {
+		p.out.WriteByte(' ')
+	}
+
+	p.out.WriteString(text)
+}
+
+// Prints the comments that are before the closing token of a block or a list,
+// so they are indented with the contents of the block or the list
+func (p *printer) flushComments() {
+	for i := p.cursor; i < len(p.tokens); i++ {
+		switch p.tokens[i].tok {
+		case scanner.COMMENT:
+			p.comment(i)
+		case scanner.COMMA, scanner.RPAREN, scanner.RBRACK:
+			continue
+		default:
+			return
+		}
+	}
+}
+
+func (p *printer) flushAll() {
+	for i := p.cursor; i < len(p.tokens); i++ {
+		p.comment(i)
+	}
+
+	p.cursor = len(p.tokens)
+}
+
+// Whether there are comments before the next token, e.g. in an empty block
+func (p *printer) hasComments() bool {
+	for i := p.cursor; i < len(p.tokens); i++ {
+		switch p.tokens[i].tok {
+		case scanner.COMMENT:
+			if !p.printed[i] {
+				return true
+			}
+		case scanner.COMMA:
+			continue
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// Whether the source has a blank line between the token and the previous one
+func (p *printer) isBlankBefore(i int) bool {
+	return i > 0 && p.tokens[i].line-p.tokens[i-1].endLine > 1
+}
+
+// Whether the next token of the source is in a line after the last printed token,
+// which breaks a list into one element per line
+func (p *printer) isNextOnNewLine() bool {
+	if p.cursor == 0 {
+		return false
+	}
+
+	for i := p.cursor; i < len(p.tokens); i++ {
+		if p.tokens[i].tok != scanner.COMMENT {
+			return p.tokens[i].line > p.tokens[p.cursor-1].endLine
+		}
+	}
+
+	return false
+}
+
+// Peeks the kind of the next token of the source, without printing the comments
+func (p *printer) peek() scanner.Token {
+	for i := p.cursor; i < len(p.tokens); i++ {
+		if p.tokens[i].tok != scanner.COMMENT {
+			return p.tokens[i].tok
+		}
+	}
+
+	return scanner.EOF
+}
+
+// Prints a list between the open and close tokens. When the list allows a trailing comma
+// and its first element starts in a new line, every element is printed in its own line.
+// A padded list has a space after the open token and before the close token, e.g. `{| 1, 2 |}`
+func (p *printer) list(open, close scanner.Token, size int, isBreakable, isPadded bool, item func(i int)) {
+	p.token(open)
+	if size == 0 && !p.hasComments() {
+		p.token(close)
+		return
+	}
+
+	if isBreakable && (size == 0 || p.isNextOnNewLine()) {
+		p.indent++
+		p.afterOpen = true
+		p.newline()
+		for i := range size {
+			item(i)
+			p.write(",")
+			p.newline()
+		}
+
+		p.flushComments()
+		p.indent--
+		p.token(close)
+		return
+	}
+
+	if isPadded {
+		p.write(" ")
+	}
+
+	for i := range size {
+		if i > 0 {
+			p.write(", ")
+		}
+
+		item(i)
+	}
+
+	// A comment before the close token breaks the line, so the trailing comma is kept
+	if isBreakable && p.hasComments() {
+		p.write(",")
+		p.flushComments()
+		p.continuation = false
+	}
+
+	if isPadded {
+		p.write(" ")
+	}
+
+	p.token(close)
+}
+
+func isClosing(tok scanner.Token) bool {
+	switch tok {
+	case scanner.RPAREN, scanner.RBRACK, scanner.RBRACE, scanner.RSET_BRACE:
+		return true
+	}
+
+	return false
+}
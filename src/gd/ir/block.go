@@ -40,6 +40,8 @@ func (b *GDIRBlock) BuildAssembly(padding string) string {
 }
 
 func (b *GDIRBlock) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) error {
+	ctx.EnterScope(bytecode)
+
 	err := Write(bytecode, cpu.BBegin)
 	if err != nil {
 		return err
@@ -75,6 +77,8 @@ func (b *GDIRBlock) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) erro
 		return err
 	}
 
+	ctx.ExitScope(bytecode)
+
 	return nil
 }
 
@@ -47,9 +47,9 @@ func TestBlockLen(t *testing.T) {
 	codeBytes := bytecode.Bytes()
 	expectedBytes := []byte{
 		byte(cpu.BBegin), 14, 0, // Block and length
-		byte(cpu.Tif),                                                                        // Tif
-		byte(runtime.GDStringTypeCode), byte(runtime.GDInt8TypeCode) /* int8 */, 2, 'n', 'o', // String
-		byte(runtime.GDStringTypeCode), byte(runtime.GDInt8TypeCode) /* int8 */, 2, 'o', 'k', // String
+		byte(cpu.Tif),                                                                                      // Tif
+		byte(runtime.GDStringTypeCode), byte(runtime.GDIntTypeCode) /* int */, 4 /* zig-zag 2 */, 'n', 'o', // String
+		byte(runtime.GDStringTypeCode), byte(runtime.GDIntTypeCode) /* int */, 4 /* zig-zag 2 */, 'o', 'k', // String
 		byte(runtime.GDBoolTypeCode), 1, // Bool true
 		byte(cpu.BEnd), // End block
 	}
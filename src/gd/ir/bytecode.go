@@ -21,8 +21,10 @@ package ir
 
 import (
 	"bytes"
+	"encoding/binary"
 	"gdlang/lib/runtime"
 	"gdlang/src/cpu"
+	"slices"
 	"unsafe"
 )
 
@@ -75,11 +77,25 @@ func WriteType(bytecode *bytes.Buffer, typ runtime.GDTypable) error {
 				return err
 			}
 		}
+	case *runtime.GDNominalType:
+		err := WriteIdent(bytecode, t.Ident)
+		if err != nil {
+			return err
+		}
+
+		err = WriteUInt32(bytecode, t.Decl)
+		if err != nil {
+			return err
+		}
+
+		return WriteType(bytecode, t.Type)
 	case runtime.GDIdent:
 		// Write the ident mode and raw value
 		return WriteIdent(bytecode, t)
 	case *runtime.GDArrayType:
 		return WriteType(bytecode, t.SubType)
+	case *runtime.GDSetType:
+		return WriteType(bytecode, t.SubType)
 	case runtime.GDUnionType:
 		err := WriteInt8(bytecode, int8(len(t)))
 		if err != nil {
@@ -140,6 +156,18 @@ func writeObject(bytecode *bytes.Buffer, obj runtime.GDObject) error {
 		err = WriteInt8(bytecode, int8(obj))
 	case runtime.GDInt16:
 		err = WriteInt16(bytecode, int16(obj))
+	case runtime.GDInt32:
+		err = WriteInt32(bytecode, int32(obj))
+	case runtime.GDInt64:
+		err = WriteInt64(bytecode, int64(obj))
+	case runtime.GDUInt8:
+		err = WriteByte(bytecode, byte(obj))
+	case runtime.GDUInt16:
+		err = WriteUInt16(bytecode, uint16(obj))
+	case runtime.GDUInt32:
+		err = WriteUInt32(bytecode, uint32(obj))
+	case runtime.GDUInt64:
+		err = WriteUInt64(bytecode, uint64(obj))
 	case runtime.GDFloat32:
 		err = WriteFloat32(bytecode, float32(obj))
 	case runtime.GDFloat64:
@@ -152,27 +180,22 @@ func writeObject(bytecode *bytes.Buffer, obj runtime.GDObject) error {
 		err = WriteBool(bytecode, bool(obj))
 	case runtime.GDString:
 		err = WriteString(bytecode, string(obj))
+	case *runtime.GDBigInt:
+		err = WriteString(bytecode, obj.ToString())
+	case *runtime.GDDecimal:
+		err = WriteString(bytecode, obj.ToString())
 	case runtime.GDChar:
 		err = WriteChar(bytecode, rune(obj))
 	case *runtime.GDTuple:
-		err = WriteByte(bytecode, byte(len(obj.Objects)))
-		if err != nil {
-			return err
-		}
-
-		for _, item := range obj.Objects {
-			err := writeObjectWithType(bytecode, item)
-			if err != nil {
-				return err
-			}
-		}
+		err = writeObjectsWithType(bytecode, obj.Objects)
 	case *runtime.GDStruct:
 		err = WriteByte(bytecode, byte(len(obj.Type)))
 		if err != nil {
 			return err
 		}
 
-		for _, item := range obj.Type {
+		// The attributes are read in reverse order
+		for _, item := range slices.Backward(obj.Type) {
 			symbol, err := obj.GetAttr(item.Ident)
 			if err != nil {
 				return err
@@ -183,23 +206,35 @@ func writeObject(bytecode *bytes.Buffer, obj runtime.GDObject) error {
 				return err
 			}
 		}
+	case *runtime.GDNominalObject:
+		err = writeObjectWithType(bytecode, obj.Object)
 	case *runtime.GDSpreadable:
 		err = writeObjectWithType(bytecode, obj.Iterable)
 	case *runtime.GDArray:
-		err = WriteByte(bytecode, byte(len(obj.Objects)))
+		err = writeObjectsWithType(bytecode, obj.Objects)
+	case *runtime.GDSet:
+		err = writeObjectsWithType(bytecode, obj.Objects)
+	}
+
+	return err
+}
+
+// Writes the length and the objects of a collection, the objects are
+// written in reverse order like the nodes of an iterable IR object
+func writeObjectsWithType(bytecode *bytes.Buffer, objects []runtime.GDObject) error {
+	err := WriteByte(bytecode, byte(len(objects)))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range slices.Backward(objects) {
+		err := writeObjectWithType(bytecode, item)
 		if err != nil {
 			return err
 		}
-
-		for _, item := range obj.Objects {
-			err := writeObjectWithType(bytecode, item)
-			if err != nil {
-				return err
-			}
-		}
 	}
 
-	return err
+	return nil
 }
 
 func writeObjectType(bytecode *bytes.Buffer, obj runtime.GDObject) error {
@@ -298,8 +333,9 @@ func WriteBool(bytecode *bytes.Buffer, val bool) error {
 	return bytecode.WriteByte(0)
 }
 
+// Ints are written as zig-zag varints, so small values take a few bytes
 func WriteInt(bytecode *bytes.Buffer, val int) error {
-	_, err := bytecode.Write(ItoBytes(val))
+	_, err := bytecode.Write(binary.AppendVarint(nil, int64(val)))
 	return err
 }
 
@@ -324,7 +360,17 @@ func WriteUInt32(bytecode *bytes.Buffer, val uint32) error {
 }
 
 func WriteInt32(bytecode *bytes.Buffer, val int32) error {
-	_, err := bytecode.Write(ItoBytes(int(val)))
+	_, err := bytecode.Write(I32toBytes(val))
+	return err
+}
+
+func WriteInt64(bytecode *bytes.Buffer, val int64) error {
+	_, err := bytecode.Write(I64toBytes(val))
+	return err
+}
+
+func WriteUInt64(bytecode *bytes.Buffer, val uint64) error {
+	_, err := bytecode.Write(UI64toBytes(val))
 	return err
 }
 
@@ -386,6 +432,18 @@ func UI32toBytes(i uint32) []byte {
 	return data[:]
 }
 
+// Int64
+
+func I64toBytes(i int64) []byte {
+	data := *(*[unsafe.Sizeof(i)]byte)(unsafe.Pointer(&i))
+	return data[:]
+}
+
+func UI64toBytes(i uint64) []byte {
+	data := *(*[unsafe.Sizeof(i)]byte)(unsafe.Pointer(&i))
+	return data[:]
+}
+
 // Int8
 
 func I8toBytes(i int8) []byte {
@@ -40,7 +40,13 @@ func (c *GDIRCastObject) BuildAssembly(padding string) string {
 func (c *GDIRCastObject) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) error {
 	ctx.AddMapping(bytecode, c.GetPosition())
 
-	err := Write(bytecode, cpu.CastObj, c.Type)
+	err := Write(bytecode, cpu.CastObj)
+	if err != nil {
+		return err
+	}
+
+	// Type refs are also idents, so the type must be written explicitly
+	err = WriteType(bytecode, c.Type)
 	if err != nil {
 		return err
 	}
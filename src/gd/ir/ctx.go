@@ -87,6 +87,26 @@ func (c *GDIRContext) AddMapping(bytecode *bytes.Buffer, pos scanner.Position) {
 	c.SrcMap.AddMapping(bytecode.Len(), pos)
 }
 
+func (c *GDIRContext) EnterFunction(bytecode *bytes.Buffer, name string, pos scanner.Position) {
+	c.SrcMap.EnterFunction(bytecode.Len(), name, pos)
+}
+
+func (c *GDIRContext) ExitFunction(bytecode *bytes.Buffer) {
+	c.SrcMap.ExitFunction(bytecode.Len())
+}
+
+func (c *GDIRContext) EnterScope(bytecode *bytes.Buffer) {
+	c.SrcMap.EnterScope(bytecode.Len())
+}
+
+func (c *GDIRContext) ExitScope(bytecode *bytes.Buffer) {
+	c.SrcMap.ExitScope(bytecode.Len())
+}
+
+func (c *GDIRContext) AddVariable(bytecode *bytes.Buffer, ident runtime.GDIdent, name string, typ runtime.GDTypable, pos scanner.Position) {
+	c.SrcMap.AddVariable(bytecode.Len(), ident, name, typ, pos)
+}
+
 func NewGDIRContext() *GDIRContext {
 	return &GDIRContext{NewGDIRSourceMap(), make(map[any]uint16), make([]GDIRLabelMark, 0)}
 }
@@ -63,6 +63,10 @@ func IRObjectWithoutTypeToString(obj runtime.GDObject) string {
 		return fmt.Sprintf("[%s]", runtime.JoinSlice(obj.Objects, func(object runtime.GDObject, _ int) string {
 			return IRObjectWithTypeToString(object)
 		}, ", "))
+	case *runtime.GDSet:
+		return fmt.Sprintf("{|%s|}", runtime.JoinSlice(obj.Objects, func(object runtime.GDObject, _ int) string {
+			return IRObjectWithTypeToString(object)
+		}, ", "))
 	case *runtime.GDTuple:
 		return fmt.Sprintf("(%s)", runtime.JoinSlice(obj.Objects, func(object runtime.GDObject, _ int) string {
 			return IRObjectWithTypeToString(object)
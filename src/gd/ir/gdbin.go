@@ -0,0 +1,231 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ir
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+)
+
+// Version of the bytecode written by the compiler, it must be
+// bumped whenever the VM can no longer run the previous binaries
+const GDBinVersion uint16 = 1
+
+// Magic number at the start of the binary files
+var GDBinMagic = [4]byte{'G', 'D', 'B', 'N'}
+
+// Size of the magic number, the version and the checksum
+const gdBinFixedHeaderSize = 10
+
+// Kind of a section, the values are written into the binaries,
+// so they must never be renumbered
+type GDBinSection byte
+
+const (
+	GDBinCodeSection GDBinSection = iota + 1
+	// Reserved for a constant pool, it is not implemented yet and
+	// it is never written, the objects are inline with the instructions
+	GDBinConstSection
+	GDBinSourceMapSection
+	GDBinMetadataSection
+)
+
+var ErrNotGDBin = errors.New("not a GDLang binary file, the magic number is missing, it might have been built by an older compiler")
+
+// Binary file run by the VM, the layout is:
+//
+//	magic [4]byte | version uint16 | crc32 uint32 | compiler version (uint16 length + bytes)
+//	section count byte | sections (kind byte, uint32 length, data)
+//
+// Numbers are little endian and the checksum covers everything after it.
+// The offsets of the jumps and of the source map are relative to the code section.
+type GDBin struct {
+	Version         uint16
+	CompilerVersion string
+	Code            []byte
+	// Optional source map, embedded by `gdc -embed-map`
+	SrcMap   *GDSourceMap
+	Metadata map[string]string
+}
+
+func (b *GDBin) Encode() ([]byte, error) {
+	body := &bytes.Buffer{}
+
+	body.Write(binary.LittleEndian.AppendUint16(nil, uint16(len(b.CompilerVersion))))
+	body.WriteString(b.CompilerVersion)
+
+	sections := []gdBinSectionData{{GDBinCodeSection, b.Code}}
+
+	if b.SrcMap != nil {
+		data, err := json.Marshal(b.SrcMap)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, gdBinSectionData{GDBinSourceMapSection, data})
+	}
+
+	if len(b.Metadata) > 0 {
+		data, err := json.Marshal(b.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, gdBinSectionData{GDBinMetadataSection, data})
+	}
+
+	body.WriteByte(byte(len(sections)))
+	for _, section := range sections {
+		body.WriteByte(byte(section.kind))
+		body.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(section.data))))
+		body.Write(section.data)
+	}
+
+	data := make([]byte, 0, gdBinFixedHeaderSize+body.Len())
+	data = append(data, GDBinMagic[:]...)
+	data = binary.LittleEndian.AppendUint16(data, b.Version)
+	data = binary.LittleEndian.AppendUint32(data, crc32.ChecksumIEEE(body.Bytes()))
+
+	return append(data, body.Bytes()...), nil
+}
+
+func DecodeGDBin(data []byte) (*GDBin, error) {
+	if len(data) < len(GDBinMagic) || !bytes.Equal(data[:len(GDBinMagic)], GDBinMagic[:]) {
+		return nil, ErrNotGDBin
+	}
+
+	if len(data) < gdBinFixedHeaderSize {
+		return nil, errors.New("truncated binary file, the header is incomplete")
+	}
+
+	// The version is checked first, since the layout changes between versions
+	bin := &GDBin{Version: binary.LittleEndian.Uint16(data[4:6])}
+	if bin.Version != GDBinVersion {
+		return nil, fmt.Errorf("unsupported bytecode version %d, expected version %d, the package must be compiled again", bin.Version, GDBinVersion)
+	}
+
+	body := data[gdBinFixedHeaderSize:]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(data[6:10]) {
+		return nil, errors.New("corrupted binary file, the checksum does not match")
+	}
+
+	reader := &gdBinReader{body, 0}
+
+	versionLen, err := reader.next(2)
+	if err != nil {
+		return nil, err
+	}
+
+	compilerVersion, err := reader.next(int(binary.LittleEndian.Uint16(versionLen)))
+	if err != nil {
+		return nil, err
+	}
+	bin.CompilerVersion = string(compilerVersion)
+
+	count, err := reader.next(1)
+	if err != nil {
+		return nil, err
+	}
+
+	for range count[0] {
+		header, err := reader.next(5)
+		if err != nil {
+			return nil, err
+		}
+
+		section, err := reader.next(int(binary.LittleEndian.Uint32(header[1:])))
+		if err != nil {
+			return nil, err
+		}
+
+		switch GDBinSection(header[0]) {
+		case GDBinCodeSection:
+			bin.Code = section
+		case GDBinSourceMapSection:
+			bin.SrcMap, err = decodeGDSourceMap(section)
+		case GDBinMetadataSection:
+			err = json.Unmarshal(section, &bin.Metadata)
+		}
+
+		// Unknown sections are skipped, they might be added without changing the version
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if bin.Code == nil {
+		return nil, errors.New("invalid binary file, the code section is missing")
+	}
+
+	return bin, nil
+}
+
+func ReadGDBin(path string) (*GDBin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	bin, err := DecodeGDBin(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return bin, nil
+}
+
+func WriteGDBin(path string, bin *GDBin) error {
+	data, err := bin.Encode()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+func NewGDBin(code []byte, compilerVersion string) *GDBin {
+	return &GDBin{Version: GDBinVersion, CompilerVersion: compilerVersion, Code: code, Metadata: make(map[string]string)}
+}
+
+type gdBinSectionData struct {
+	kind GDBinSection
+	data []byte
+}
+
+type gdBinReader struct {
+	data []byte
+	off  int
+}
+
+func (r *gdBinReader) next(n int) ([]byte, error) {
+	if r.off+n > len(r.data) {
+		return nil, errors.New("truncated binary file, a section is incomplete")
+	}
+
+	data := r.data[r.off : r.off+n]
+	r.off += n
+
+	return data, nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ir_test
+
+import (
+	"bytes"
+	"gdlang/src/gd/ir"
+	"gdlang/src/gd/scanner"
+	"strings"
+	"testing"
+)
+
+func TestGDBinRoundTrip(t *testing.T) {
+	srcMap := ir.NewGDIRSourceMap()
+	srcMap.AddMapping(0, scanner.Position{Filename: "main.gd", Line: 1, ColStart: 2, ColEnd: 4})
+
+	bin := ir.NewGDBin([]byte{1, 2, 3}, "1.2.0")
+	bin.SrcMap = srcMap
+	bin.Metadata["package"] = "main"
+
+	data, err := bin.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.HasPrefix(data, ir.GDBinMagic[:]) {
+		t.Errorf("Expected the magic number at the start, got %v", data[:4])
+	}
+
+	decoded, err := ir.DecodeGDBin(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Version != ir.GDBinVersion || decoded.CompilerVersion != "1.2.0" {
+		t.Errorf("Expected version %d of the compiler 1.2.0, got %d of %q", ir.GDBinVersion, decoded.Version, decoded.CompilerVersion)
+	}
+
+	if !bytes.Equal(decoded.Code, []byte{1, 2, 3}) {
+		t.Errorf("Expected the code [1 2 3], got %v", decoded.Code)
+	}
+
+	if decoded.Metadata["package"] != "main" {
+		t.Errorf("Expected the package main, got %v", decoded.Metadata)
+	}
+
+	if pos, ok := decoded.SrcMap.Lookup(0); !ok || pos.String() != "main.gd:1:2" {
+		t.Errorf("Expected the embedded source map to map main.gd:1:2, got %v", pos)
+	}
+
+	// The source map and the metadata are optional
+	data, err = ir.NewGDBin([]byte{}, "").Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err = ir.DecodeGDBin(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.SrcMap != nil || len(decoded.Code) != 0 {
+		t.Errorf("Expected an empty program without a source map, got %v", decoded)
+	}
+}
+
+func TestGDBinSectionKinds(t *testing.T) {
+	// The kinds are part of the file format
+	kinds := []ir.GDBinSection{ir.GDBinCodeSection, ir.GDBinConstSection, ir.GDBinSourceMapSection, ir.GDBinMetadataSection}
+	for i, kind := range kinds {
+		if kind != ir.GDBinSection(i+1) {
+			t.Errorf("Expected the section kind %d at %d, got %d", i+1, i, kind)
+		}
+	}
+
+	data, err := ir.NewGDBin([]byte{1}, "").Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	withMap := ir.NewGDBin([]byte{1}, "")
+	withMap.SrcMap = ir.NewGDIRSourceMap()
+	mapData, err := withMap.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The source map section starts right after the code section
+	if kind := mapData[len(data)]; kind != 3 {
+		t.Errorf("Expected the source map to be written as the section kind 3, got %d", kind)
+	}
+}
+
+func TestGDBinErrors(t *testing.T) {
+	valid, err := ir.NewGDBin([]byte{1, 2, 3}, "dev").Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patch := func(off int, b byte) []byte {
+		data := bytes.Clone(valid)
+		data[off] = b
+		return data
+	}
+
+	tests := []struct {
+		name   string
+		data   []byte
+		errMsg string
+	}{
+		{"raw bytecode", []byte{1, 0, 0}, "not a GDLang binary file"},
+		{"truncated header", valid[:8], "the header is incomplete"},
+		{"newer version", patch(4, byte(ir.GDBinVersion+1)), "unsupported bytecode version 2, expected version 1"},
+		{"corrupted code", patch(len(valid)-1, 9), "the checksum does not match"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ir.DecodeGDBin(test.data)
+			if err == nil || !strings.Contains(err.Error(), test.errMsg) {
+				t.Errorf("Expected an error containing %q, got %v", test.errMsg, err)
+			}
+		})
+	}
+}
@@ -27,8 +27,14 @@ import (
 	"gdlang/src/gd/ast"
 )
 
+// Name of the lambdas that are not declared as functions
+const lambdaName = "lambda"
+
 type GDIRLambda struct {
 	typ *runtime.GDLambdaType
+	// Name of the function and source names of the arguments, used by the source map
+	name     string
+	argNames []string
 	*GDIRBlock
 	GDIRBaseNode
 }
@@ -38,6 +44,19 @@ func (l *GDIRLambda) BuildAssembly(padding string) string {
 }
 
 func (l *GDIRLambda) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) error {
+	ctx.EnterFunction(bytecode, l.name, l.GetPosition())
+
+	// The arguments are declared in the scope of the function
+	ctx.EnterScope(bytecode)
+	for i, arg := range l.typ.ArgTypes {
+		argType := arg.Value
+		if l.typ.IsVariadic && i == len(l.typ.ArgTypes)-1 {
+			argType = runtime.NewGDArrayType(argType)
+		}
+
+		ctx.AddVariable(bytecode, arg.Key, l.argNames[i], argType, l.GetPosition())
+	}
+
 	// Write type
 	err := Write(bytecode, cpu.Lambda, l.typ)
 	if err != nil {
@@ -50,9 +69,31 @@ func (l *GDIRLambda) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) err
 		return err
 	}
 
+	ctx.ExitScope(bytecode)
+	ctx.ExitFunction(bytecode)
+
 	return nil
 }
 
+// Names the lambda after the function that declares it
+func (l *GDIRLambda) SetFunc(f *ast.NodeFunc) {
+	l.name = f.Ident.Lit
+	l.Node = f
+}
+
 func NewGDIRLambda(typ *runtime.GDLambdaType, node ast.Node) (*GDIRLambda, *GDIRObject) {
-	return &GDIRLambda{typ, NewGDIRBlock(), GDIRBaseNode{node}}, NewGDIRRegObject(cpu.RPop, node)
+	// The runtime arguments might be renamed, so the names
+	// are taken from the arguments declared in the source code
+	argNames := make([]string, len(typ.ArgTypes))
+	for i, arg := range typ.ArgTypes {
+		argNames[i] = arg.Key.ToString()
+	}
+
+	if l, isLambda := node.(*ast.NodeLambda); isLambda && len(l.Type.ArgTypes) == len(argNames) {
+		for i, arg := range l.Type.ArgTypes {
+			argNames[i] = arg.Key.ToString()
+		}
+	}
+
+	return &GDIRLambda{typ, lambdaName, argNames, NewGDIRBlock(), GDIRBaseNode{node}}, NewGDIRRegObject(cpu.RPop, node)
 }
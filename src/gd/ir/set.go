@@ -41,6 +41,10 @@ func (s *GDIRSet) BuildAssembly(padding string) string {
 func (s *GDIRSet) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) error {
 	ctx.AddMapping(bytecode, s.GetPosition())
 
+	if name, ok := s.sourceName(); ok {
+		ctx.AddVariable(bytecode, s.disc.ident, name, s.typ, s.GetPosition())
+	}
+
 	err := Write(bytecode, cpu.Set)
 	if err != nil {
 		return err
@@ -64,6 +68,19 @@ func (s *GDIRSet) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) error
 	return nil
 }
 
+// Name of the object in the source code, the objects
+// created by the compiler and the type aliases have no name
+func (s *GDIRSet) sourceName() (string, bool) {
+	switch node := s.Node.(type) {
+	case *ast.NodeSet:
+		return node.IdentWithType.Ident.Lit, true
+	case *ast.NodeFunc:
+		return node.Ident.Lit, true
+	}
+
+	return "", false
+}
+
 func NewGDIRSet(disc *GDIRDiscoverable, typ runtime.GDTypable, expr GDIRNode, node ast.Node) *GDIRSet {
 	return &GDIRSet{disc, typ, expr, GDIRBaseNode{node}}
 }
@@ -20,16 +20,122 @@
 package ir
 
 import (
+	"encoding/json"
+	"fmt"
+	"gdlang/lib/runtime"
 	"gdlang/src/gd/scanner"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
+// Version of the source map format written by the compiler
+const GDSourceMapVersion = 2
+
+// No parent scope or function
+const noSourceIndex = -1
+
 type GDSourceMap struct {
-	Version  byte          `json:"version"`
-	Sources  []string      `json:"sources"`
-	Mappings map[int][]int `json:"mappings"`
+	Version byte     `json:"version"`
+	Sources []string `json:"sources"`
+	// Mappings sorted by bytecode offset
+	Mappings  []GDSourceMapping  `json:"mappings"`
+	Functions []GDSourceFunction `json:"functions"`
+	Scopes    []GDSourceScope    `json:"scopes"`
+
+	files map[string]int
+	// Indexes of the functions and scopes being written
+	openFunctions, openScopes []int
+}
+
+// Position in the source code of the instructions from the bytecode offset
+type GDSourceMapping struct {
+	Offset   int `json:"offset"`
+	Source   int `json:"source"`
+	Line     int `json:"line"`
+	ColStart int `json:"colStart"`
+	ColEnd   int `json:"colEnd"`
+}
+
+// Function defined by a lambda instruction, from the offset of
+// the instruction to the end of its block
+type GDSourceFunction struct {
+	Name   string `json:"name"`
+	Start  int    `json:"start"`
+	End    int    `json:"end"`
+	Source int    `json:"source"`
+	Line   int    `json:"line"`
+	Col    int    `json:"col"`
+}
+
+// Scope of a block or of the arguments of a function,
+// the root scope has no parent and no function
+type GDSourceScope struct {
+	Start     int                `json:"start"`
+	End       int                `json:"end"`
+	Parent    int                `json:"parent"`
+	Function  int                `json:"function"`
+	Variables []GDSourceVariable `json:"variables"`
+}
+
+// Object declared in a scope, from the offset of its declaration
+type GDSourceVariable struct {
+	Name   string        `json:"name"`
+	Ident  GDSourceIdent `json:"ident"`
+	Type   string        `json:"type"`
+	Offset int           `json:"offset"`
+	Line   int           `json:"line"`
+	Col    int           `json:"col"`
+}
+
+// Runtime identifier of a variable, written as a number
+// for uint16 identifiers and as a string for the others
+type GDSourceIdent struct{ runtime.GDIdent }
+
+func (i GDSourceIdent) MarshalJSON() ([]byte, error) {
+	if i.GetMode() == runtime.GDUInt16IdentMode {
+		return json.Marshal(i.GetRawValue())
+	}
+
+	return json.Marshal(i.ToString())
+}
+
+func (i *GDSourceIdent) UnmarshalJSON(data []byte) error {
+	var value uint16
+	if json.Unmarshal(data, &value) == nil {
+		i.GDIdent = runtime.NewGDUInt16Ident(value)
+		return nil
+	}
+
+	var name string
+	err := json.Unmarshal(data, &name)
+	if err != nil {
+		return err
+	}
+
+	i.GDIdent = runtime.NewGDStringIdent(name)
+
+	return nil
+}
+
+// Position in the source code of a bytecode offset
+type GDSourcePos struct {
+	File      string
+	Line, Col int
+}
+
+func (p GDSourcePos) String() string { return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Col) }
 
-	fileCount int
-	files     map[string]int
+func (mf *GDSourceMap) sourceIndex(filename string) int {
+	source, ok := mf.files[filename]
+	if !ok {
+		source = len(mf.Sources)
+		mf.files[filename] = source
+		mf.Sources = append(mf.Sources, filename)
+	}
+
+	return source
 }
 
 func (mf *GDSourceMap) AddMapping(bytecodeOffset int, pos scanner.Position) {
@@ -37,15 +143,232 @@ func (mf *GDSourceMap) AddMapping(bytecodeOffset int, pos scanner.Position) {
 		return
 	}
 
-	if filePos, ok := mf.files[pos.Filename]; ok {
-		mf.Mappings[bytecodeOffset] = []int{filePos, pos.Line, pos.ColStart, pos.ColEnd}
-	} else {
-		mf.files[pos.Filename] = mf.fileCount
-		mf.Sources = append(mf.Sources, pos.Filename)
-		mf.fileCount++
+	mapping := GDSourceMapping{bytecodeOffset, mf.sourceIndex(pos.Filename), pos.Line, pos.ColStart, pos.ColEnd}
+
+	// The innermost node of an offset is the last one mapped
+	if last := len(mf.Mappings) - 1; last >= 0 && mf.Mappings[last].Offset == bytecodeOffset {
+		mf.Mappings[last] = mapping
+		return
+	}
+
+	mf.Mappings = append(mf.Mappings, mapping)
+}
+
+func (mf *GDSourceMap) EnterFunction(bytecodeOffset int, name string, pos scanner.Position) {
+	mf.openFunctions = append(mf.openFunctions, len(mf.Functions))
+
+	fn := GDSourceFunction{Name: name, Start: bytecodeOffset, Source: noSourceIndex, Line: pos.Line, Col: pos.ColStart}
+	if pos != scanner.ZeroPos {
+		fn.Source = mf.sourceIndex(pos.Filename)
+	}
+
+	mf.Functions = append(mf.Functions, fn)
+}
+
+func (mf *GDSourceMap) ExitFunction(bytecodeOffset int) {
+	last := len(mf.openFunctions) - 1
+	mf.Functions[mf.openFunctions[last]].End = bytecodeOffset
+	mf.openFunctions = mf.openFunctions[:last]
+}
+
+func (mf *GDSourceMap) EnterScope(bytecodeOffset int) {
+	scope := GDSourceScope{Start: bytecodeOffset, Parent: noSourceIndex, Function: noSourceIndex, Variables: make([]GDSourceVariable, 0)}
+	if len(mf.openScopes) > 0 {
+		scope.Parent = mf.openScopes[len(mf.openScopes)-1]
+	}
+
+	if len(mf.openFunctions) > 0 {
+		scope.Function = mf.openFunctions[len(mf.openFunctions)-1]
+	}
+
+	mf.openScopes = append(mf.openScopes, len(mf.Scopes))
+	mf.Scopes = append(mf.Scopes, scope)
+}
+
+func (mf *GDSourceMap) ExitScope(bytecodeOffset int) {
+	last := len(mf.openScopes) - 1
+	mf.Scopes[mf.openScopes[last]].End = bytecodeOffset
+	mf.openScopes = mf.openScopes[:last]
+}
+
+// Adds a variable to the innermost scope being written
+func (mf *GDSourceMap) AddVariable(bytecodeOffset int, ident runtime.GDIdent, name string, typ runtime.GDTypable, pos scanner.Position) {
+	if len(mf.openScopes) == 0 {
+		return
+	}
+
+	variable := GDSourceVariable{Name: name, Ident: GDSourceIdent{ident}, Offset: bytecodeOffset, Line: pos.Line, Col: pos.ColStart}
+	if typ != nil {
+		variable.Type = typ.ToString()
+	}
+
+	scope := &mf.Scopes[mf.openScopes[len(mf.openScopes)-1]]
+	scope.Variables = append(scope.Variables, variable)
+}
+
+// Closest mapping at or before the bytecode offset,
+// since not every instruction of the bytecode is mapped
+func (mf *GDSourceMap) MappingAt(bytecodeOffset int) (GDSourceMapping, bool) {
+	i, found := slices.BinarySearchFunc(mf.Mappings, bytecodeOffset, func(m GDSourceMapping, offset int) int {
+		return m.Offset - offset
+	})
+	if !found {
+		if i == 0 {
+			return GDSourceMapping{}, false
+		}
+		i--
+	}
+
+	mapping := mf.Mappings[i]
+	if mapping.Source < 0 || mapping.Source >= len(mf.Sources) {
+		return GDSourceMapping{}, false
+	}
+
+	return mapping, true
+}
+
+// Position in the source code of the bytecode offset
+func (mf *GDSourceMap) Lookup(bytecodeOffset int) (GDSourcePos, bool) {
+	mapping, ok := mf.MappingAt(bytecodeOffset)
+	if !ok {
+		return GDSourcePos{}, false
+	}
+
+	return GDSourcePos{mf.Sources[mapping.Source], mapping.Line, mapping.ColStart}, true
+}
+
+// Offsets of the mappings of a line, the file matches a source
+// by its full path or by the end of the path, e.g. `main.gd`
+func (mf *GDSourceMap) LineOffsets(file string, line int) []int {
+	offsets := make([]int, 0)
+	for _, mapping := range mf.Mappings {
+		source := mf.Sources[mapping.Source]
+		if mapping.Line == line && (source == file || strings.HasSuffix(source, string(os.PathSeparator)+file)) {
+			offsets = append(offsets, mapping.Offset)
+		}
+	}
+
+	return offsets
+}
+
+// Name of the function defined by the lambda instruction at the bytecode offset
+func (mf *GDSourceMap) FunctionName(bytecodeOffset int) (string, bool) {
+	for _, fn := range mf.Functions {
+		if fn.Start == bytecodeOffset {
+			return fn.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// Innermost function that contains the bytecode offset
+func (mf *GDSourceMap) FunctionAt(bytecodeOffset int) (*GDSourceFunction, bool) {
+	var inner *GDSourceFunction
+	for i, fn := range mf.Functions {
+		if fn.Start <= bytecodeOffset && bytecodeOffset < fn.End && (inner == nil || fn.Start >= inner.Start) {
+			inner = &mf.Functions[i]
+		}
+	}
+
+	return inner, inner != nil
+}
+
+// Scopes that contain the bytecode offset, from the innermost to the root
+func (mf *GDSourceMap) ScopesAt(bytecodeOffset int) []*GDSourceScope {
+	inner := noSourceIndex
+	for i, scope := range mf.Scopes {
+		if scope.Start <= bytecodeOffset && bytecodeOffset < scope.End && (inner == noSourceIndex || scope.Start >= mf.Scopes[inner].Start) {
+			inner = i
+		}
+	}
+
+	scopes := make([]*GDSourceScope, 0)
+	for i := inner; i != noSourceIndex; i = mf.Scopes[i].Parent {
+		scopes = append(scopes, &mf.Scopes[i])
 	}
+
+	return scopes
+}
+
+// Source name of a runtime identifier, the innermost
+// declaration visible from the bytecode offset is used
+func (mf *GDSourceMap) VariableName(bytecodeOffset int, ident runtime.GDIdent) (string, bool) {
+	for _, scope := range mf.ScopesAt(bytecodeOffset) {
+		for _, variable := range scope.Variables {
+			if variable.Ident.GetMode() == ident.GetMode() && variable.Ident.GetRawValue() == ident.GetRawValue() {
+				return variable.Name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Source name of an argument of a function, the arguments are declared in the first
+// scope of their function, so they are not visible from where the function is used
+func (mf *GDSourceMap) ArgumentName(ident runtime.GDIdent) (string, bool) {
+	for _, scope := range mf.Scopes {
+		if scope.Function == noSourceIndex || scope.Start != mf.Functions[scope.Function].Start {
+			continue
+		}
+
+		for _, variable := range scope.Variables {
+			if variable.Ident.GetMode() == ident.GetMode() && variable.Ident.GetRawValue() == ident.GetRawValue() {
+				return variable.Name, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Path of the source map written next to a binary file
+func GDSourceMapPathOf(gdbinPath string) string {
+	return strings.TrimSuffix(gdbinPath, filepath.Ext(gdbinPath)) + ".gdmap"
+}
+
+func ReadGDSourceMap(path string) (*GDSourceMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeGDSourceMap(data)
+}
+
+func decodeGDSourceMap(data []byte) (*GDSourceMap, error) {
+	// The version is checked first, since the layout changes between versions
+	var header struct {
+		Version byte `json:"version"`
+	}
+	err := json.Unmarshal(data, &header)
+	if err != nil {
+		return nil, err
+	}
+
+	if header.Version != GDSourceMapVersion {
+		return nil, fmt.Errorf("unsupported source map version %d, expected version %d", header.Version, GDSourceMapVersion)
+	}
+
+	srcMap := NewGDIRSourceMap()
+	err = json.Unmarshal(data, srcMap)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(srcMap.Mappings, func(a, b GDSourceMapping) int { return a.Offset - b.Offset })
+
+	return srcMap, nil
 }
 
 func NewGDIRSourceMap() *GDSourceMap {
-	return &GDSourceMap{Version: 1, Mappings: make(map[int][]int), Sources: make([]string, 0), files: make(map[string]int)}
+	return &GDSourceMap{
+		Version:   GDSourceMapVersion,
+		Sources:   make([]string, 0),
+		Mappings:  make([]GDSourceMapping, 0),
+		Functions: make([]GDSourceFunction, 0),
+		Scopes:    make([]GDSourceScope, 0),
+		files:     make(map[string]int),
+	}
 }
@@ -0,0 +1,185 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package ir_test
+
+import (
+	"encoding/json"
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/ir"
+	"gdlang/src/gd/scanner"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestSourceMapLookup(t *testing.T) {
+	srcMap := ir.NewGDIRSourceMap()
+	srcMap.AddMapping(0, scanner.Position{Filename: "main.gd", Line: 1, ColStart: 2, ColEnd: 4})
+	srcMap.AddMapping(10, scanner.Position{Filename: "util.gd", Line: 3, ColStart: 5, ColEnd: 8})
+	srcMap.AddMapping(20, scanner.Position{Filename: "main.gd", Line: 6, ColStart: 1, ColEnd: 2})
+	// The innermost node of the same offset replaces the mapping
+	srcMap.AddMapping(20, scanner.Position{Filename: "main.gd", Line: 7, ColStart: 1, ColEnd: 2})
+
+	// The first mapping of each file is also kept
+	if len(srcMap.Mappings) != 3 {
+		t.Errorf("Expected 3 mappings, got %d", len(srcMap.Mappings))
+	}
+
+	if len(srcMap.Sources) != 2 {
+		t.Errorf("Expected 2 sources, got %v", srcMap.Sources)
+	}
+
+	tests := []struct {
+		offset   int
+		expected string
+	}{
+		{0, "main.gd:1:2"},
+		{5, "main.gd:1:2"},
+		{10, "util.gd:3:5"},
+		{19, "util.gd:3:5"},
+		{25, "main.gd:7:1"},
+	}
+
+	for _, test := range tests {
+		pos, ok := srcMap.Lookup(test.offset)
+		if !ok || pos.String() != test.expected {
+			t.Errorf("Expected %s at offset %d, got %s", test.expected, test.offset, pos)
+		}
+	}
+}
+
+func TestSourceMapLookupBeforeFirstMapping(t *testing.T) {
+	srcMap := ir.NewGDIRSourceMap()
+	srcMap.AddMapping(4, scanner.Position{Filename: "main.gd", Line: 1, ColStart: 1, ColEnd: 2})
+
+	if pos, ok := srcMap.Lookup(2); ok {
+		t.Errorf("Expected no position at offset 2, got %s", pos)
+	}
+}
+
+// Builds the source map of a function `add(a, b)` with a local `c` inside the root scope
+func newFunctionSourceMap() *ir.GDSourceMap {
+	pos := scanner.Position{Filename: "main.gd", Line: 1, ColStart: 6, ColEnd: 8}
+	intType := runtime.GDTypable(runtime.GDIntType)
+
+	srcMap := ir.NewGDIRSourceMap()
+	srcMap.EnterScope(0)
+	srcMap.EnterFunction(2, "add", pos)
+	srcMap.EnterScope(2)
+	srcMap.AddVariable(2, runtime.NewGDUInt16Ident(1), "a", intType, pos)
+	srcMap.AddVariable(2, runtime.NewGDUInt16Ident(2), "b", intType, pos)
+	srcMap.EnterScope(8)
+	srcMap.AddVariable(10, runtime.NewGDStringIdent("c"), "c", intType, pos)
+	srcMap.ExitScope(20)
+	srcMap.ExitScope(20)
+	srcMap.ExitFunction(20)
+	srcMap.AddVariable(20, runtime.NewGDUInt16Ident(3), "add", nil, pos)
+	srcMap.ExitScope(30)
+
+	return srcMap
+}
+
+func TestSourceMapFunctionsAndScopes(t *testing.T) {
+	srcMap := newFunctionSourceMap()
+
+	fn, ok := srcMap.FunctionAt(12)
+	if !ok || fn.Name != "add" || fn.Start != 2 || fn.End != 20 {
+		t.Errorf("Expected the function `add` from 2 to 20, got %+v", fn)
+	}
+
+	if fn, ok := srcMap.FunctionAt(25); ok {
+		t.Errorf("Expected no function at offset 25, got %+v", fn)
+	}
+
+	scopes := srcMap.ScopesAt(12)
+	if len(scopes) != 3 || scopes[0].Function != 0 || scopes[2].Parent != -1 {
+		t.Errorf("Expected 3 nested scopes at offset 12, got %+v", scopes)
+	}
+
+	for _, test := range []struct {
+		offset   int
+		ident    runtime.GDIdent
+		expected string
+	}{
+		{12, runtime.NewGDUInt16Ident(2), "b"},
+		{12, runtime.NewGDStringIdent("c"), "c"},
+		{12, runtime.NewGDUInt16Ident(3), "add"},
+		{25, runtime.NewGDUInt16Ident(3), "add"},
+	} {
+		name, ok := srcMap.VariableName(test.offset, test.ident)
+		if !ok || name != test.expected {
+			t.Errorf("Expected %q for %s at offset %d, got %q", test.expected, test.ident.ToString(), test.offset, name)
+		}
+	}
+
+	if name, ok := srcMap.VariableName(25, runtime.NewGDUInt16Ident(1)); ok {
+		t.Errorf("Expected the argument to be out of scope, got %q", name)
+	}
+
+	// The arguments are named from anywhere, but not the locals of the functions
+	if name, ok := srcMap.ArgumentName(runtime.NewGDUInt16Ident(1)); !ok || name != "a" {
+		t.Errorf("Expected the argument `a`, got %q", name)
+	}
+
+	if name, ok := srcMap.ArgumentName(runtime.NewGDStringIdent("c")); ok {
+		t.Errorf("Expected `c` not to be an argument, got %q", name)
+	}
+}
+
+func TestReadSourceMap(t *testing.T) {
+	tmpDir := t.TempDir()
+	mapPath := path.Join(tmpDir, "main.gdmap")
+
+	data, err := json.Marshal(newFunctionSourceMap())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.WriteFile(mapPath, data, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srcMap, err := ir.ReadGDSourceMap(mapPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The uint16 identifiers are numbers and the others strings
+	variables := srcMap.Scopes[1].Variables
+	if variables[0].Ident.GDIdent != runtime.NewGDUInt16Ident(1) {
+		t.Errorf("Expected the uint16 ident 1, got %#v", variables[0].Ident.GDIdent)
+	}
+
+	variables = srcMap.Scopes[2].Variables
+	if variables[0].Ident.GDIdent != runtime.NewGDStringIdent("c") {
+		t.Errorf("Expected the string ident c, got %#v", variables[0].Ident.GDIdent)
+	}
+
+	err = os.WriteFile(mapPath, []byte(`{"version":1,"sources":[],"mappings":{}}`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ir.ReadGDSourceMap(mapPath)
+	if err == nil || err.Error() != "unsupported source map version 1, expected version 2" {
+		t.Errorf("Expected an unsupported version error, got %v", err)
+	}
+}
@@ -50,7 +50,7 @@ func (t *GDIRTypeAlias) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext)
 		return err
 	}
 
-	err = Write(bytecode, t.typ)
+	err = WriteType(bytecode, t.typ)
 	if err != nil {
 		return err
 	}
@@ -30,6 +30,7 @@ type GDIRUse struct {
 	ident   runtime.GDIdent
 	mode    runtime.GDPackageMode
 	imports []runtime.GDIdent
+	alias   runtime.GDIdent // The objects are imported as `alias.object` when it is set
 	GDIRBaseNode
 }
 
@@ -37,6 +38,10 @@ func (u *GDIRUse) BuildAssembly(padding string) string {
 	imports := runtime.JoinSlice(u.imports, func(importIdent runtime.GDIdent, _ int) string {
 		return importIdent.ToString()
 	}, ", ")
+	if u.alias != nil {
+		imports += " as " + u.alias.ToString()
+	}
+
 	return fmt.Sprintf("%s%s %s %s %s", padding, cpu.GetCPUInstName(cpu.Use), runtime.PackageModeMap[u.mode], u.ident.ToString(), imports)
 }
 
@@ -70,9 +75,18 @@ func (u *GDIRUse) BuildBytecode(bytecode *bytes.Buffer, ctx *GDIRContext) error
 		}
 	}
 
+	err = WriteBool(bytecode, u.alias != nil)
+	if err != nil {
+		return err
+	}
+
+	if u.alias != nil {
+		return WriteIdent(bytecode, u.alias)
+	}
+
 	return nil
 }
 
-func NewGDIRUse(mode runtime.GDPackageMode, ident runtime.GDIdent, imports []runtime.GDIdent) *GDIRUse {
-	return &GDIRUse{ident, mode, imports, GDIRBaseNode{}}
+func NewGDIRUse(mode runtime.GDPackageMode, ident runtime.GDIdent, imports []runtime.GDIdent, alias runtime.GDIdent) *GDIRUse {
+	return &GDIRUse{ident, mode, imports, alias, GDIRBaseNode{}}
 }
@@ -53,12 +53,14 @@ type Scanner struct {
 	mode Mode         // scanning mode
 
 	// scanning state
-	ch         rune // current character
-	offset     int  // character offset
-	rdOffset   int  // reading offset (position after current character)
-	lineOffset int  // current line offset
-	insertSemi bool // insert a semicolon before next newline
-	nlPos      Pos  // position of newline in preceding comment
+	ch         rune  // current character
+	offset     int   // character offset
+	rdOffset   int   // reading offset (position after current character)
+	lineOffset int   // current line offset
+	insertSemi bool  // insert a semicolon before next newline
+	nlPos      Pos   // position of newline in preceding comment
+	prevTok    Token // previous token
+	typeArgs   int   // number of open type argument lists, e.g. `set<set<int>>`
 
 	// public state - ok to modify
 	ErrorCount int // number of errors encountered
@@ -81,6 +83,8 @@ func (s *Scanner) Init(file *File, src []byte, err ErrorHandler, mode Mode) erro
 	s.rdOffset = 0
 	s.lineOffset = 0
 	s.insertSemi = false
+	s.prevTok = ILLEGAL
+	s.typeArgs = 0
 	s.ErrorCount = 0
 
 	s.next()
@@ -102,6 +106,8 @@ func (s *Scanner) Dispose() {
 	s.rdOffset = 0
 	s.lineOffset = 0
 	s.insertSemi = false
+	s.prevTok = ILLEGAL
+	s.typeArgs = 0
 	s.ErrorCount = 0
 }
 
@@ -136,7 +142,8 @@ scanAgain:
 			switch tok {
 			case IDENT, RETURN, BREAK, NIL, TRUE, FALSE:
 				insertSemi = true
-			case TINT, TFLOAT, TCOMPLEX, TSTRING, TCHAR, TBOOL, TANY:
+			case TINT, TFLOAT, TCOMPLEX, TSTRING, TCHAR, TBOOL, TANY, TBIGINT, TDECIMAL,
+				TINT8, TINT16, TINT32, TINT64, TUINT8, TUINT16, TUINT32, TUINT64, TBYTE:
 				insertSemi = true
 			}
 		} else {
@@ -202,15 +209,19 @@ scanAgain:
 			tok = RBRACK
 		case '{':
 			tok = LBRACE
+			if s.ch == '|' {
+				s.next()
+				tok = LSET_BRACE
+			}
 		case '}':
 			insertSemi = true
 			tok = RBRACE
 		case '+':
-			tok = s.switch2(ADD, ADD_ASSIGN)
+			tok = s.switchOverflow(ADD, ADD_ASSIGN, ADD_WRAP, ADD_SAT)
 		case '-':
-			tok = s.switch2(SUB, SUB_ASSIGN)
+			tok = s.switchOverflow(SUB, SUB_ASSIGN, SUB_WRAP, SUB_SAT)
 		case '*':
-			tok = s.switch2(MUL, MUL_ASSIGN)
+			tok = s.switchOverflow(MUL, MUL_ASSIGN, MUL_WRAP, MUL_SAT)
 		case '/':
 			if s.ch == '/' || s.ch == '*' {
 				// comment
@@ -242,14 +253,28 @@ scanAgain:
 		case '%':
 			tok = s.switch2(REM, REM_ASSIGN)
 		case '<':
-			tok = s.switch3(LSS, LEQ, '<', LSHIFT)
+			if s.prevTok == SET {
+				s.typeArgs++
+				tok = LSS
+			} else {
+				tok = s.switch3(LSS, LEQ, '<', LSHIFT)
+			}
 		case '>':
-			tok = s.switch3(GTR, GEQ, '>', RSHIFT)
+			// The closing `>` of a type argument list ends the type,
+			// so `>>` in `set<set<int>>` are two tokens
+			if s.typeArgs > 0 {
+				s.typeArgs--
+				insertSemi = true
+				tok = GTR
+			} else {
+				tok = s.switch3(GTR, GEQ, '>', RSHIFT)
+			}
 		case '=':
 			tok = s.switch3(ASSIGN, EQL, '>', ARROW)
 		case '!':
 			tok = s.switch2(NOT, NEQ)
 		case '&':
+			tok = AND
 			if s.ch == '&' {
 				s.next()
 				tok = LAND
@@ -259,6 +284,13 @@ scanAgain:
 			if s.ch == '|' {
 				s.next()
 				tok = LOR
+			} else if s.ch == '>' {
+				s.next()
+				tok = PIPE
+			} else if s.ch == '}' {
+				s.next()
+				insertSemi = true
+				tok = RSET_BRACE
 			}
 		default:
 			// next reports unexpected BOMs - don't repeat
@@ -280,6 +312,7 @@ scanAgain:
 		s.insertSemi = insertSemi
 	}
 
+	s.prevTok = tok
 	offsE = s.file.Pos(s.offset)
 
 	return
@@ -594,10 +627,20 @@ func (s *Scanner) scanNumber() (Token, string) {
 		s.error(s.offset, "hexadecimal mantissa requires a 'p' exponent")
 	}
 
-	// suffix 'i'
-	if s.ch == 'i' {
+	// suffixes 'i', 'n' and 'd'
+	switch {
+	case s.ch == 'i':
 		tok = IMAG
 		s.next()
+	case s.ch == 'n':
+		if tok != INT {
+			s.error(s.offset, "'n' suffix requires an integer literal")
+		}
+		tok = BIGINT
+		s.next()
+	case s.ch == 'd' && (prefix == 0 || prefix == '0'):
+		tok = DECIMAL
+		s.next()
 	}
 
 	lit := string(s.src[offs:s.offset])
@@ -723,6 +766,19 @@ func (s *Scanner) switch3(tok0, tok1 Token, ch2 rune, tok2 Token) Token {
 	return tok0
 }
 
+// Arithmetic operators followed by '%' wrap around on overflow, and by '|' saturate
+func (s *Scanner) switchOverflow(tok0, tok1, tokWrap, tokSat Token) Token {
+	switch s.ch {
+	case '%':
+		s.next()
+		return tokWrap
+	case '|':
+		s.next()
+		return tokSat
+	}
+	return s.switch2(tok0, tok1)
+}
+
 func (s *Scanner) next() {
 	if s.rdOffset < len(s.src) {
 		s.offset = s.rdOffset
@@ -92,7 +92,18 @@ var errors = []struct {
 	{"077", INT, 0, 0, "077", ""},
 	{"078.", FLOAT, 0, 0, "078.", ""},
 	{"0.i", IMAG, 0, 0, "0.i", ""},
+	{"123n", BIGINT, 0, 0, "123n", ""},
+	{"0x1fn", BIGINT, 0, 0, "0x1fn", ""},
+	{"1.5n", BIGINT, 4, 4, "1.5n", "'n' suffix requires an integer literal"},
+	{"1.10d", DECIMAL, 0, 0, "1.10d", ""},
+	{"08d", DECIMAL, 0, 0, "08d", ""},
 	{"*", MUL, 0, 0, "", ""},
+	{"|>", PIPE, 0, 0, "", ""},
+	{"+%", ADD_WRAP, 0, 0, "", ""},
+	{"*|", MUL_SAT, 0, 0, "", ""},
+	{"{|", LSET_BRACE, 0, 0, "", ""},
+	{"|}", RSET_BRACE, 0, 0, "", ""},
+	{"&", AND, 0, 0, "", ""},
 	{"0..i", FLOAT, 0, 0, "0.", ""},
 	{"07801234567.", FLOAT, 0, 0, "07801234567.", ""},
 	{"078e0", FLOAT, 0, 0, "078e0", ""},
@@ -221,6 +232,20 @@ func TestTokenPositionStartEnd(t *testing.T) {
 				{INT, "10000000000", Position{"test.gd", 1, 19, 29}},
 			},
 		},
+		// The closing `>` of a set type is not a shift operator
+		{
+			"set<set<int>> >> 1", []tokenLitPos{
+				{SET, "", Position{"test.gd", 1, 1, 3}},
+				{LSS, "", Position{"test.gd", 1, 4, 4}},
+				{SET, "", Position{"test.gd", 1, 5, 7}},
+				{LSS, "", Position{"test.gd", 1, 8, 8}},
+				{TINT, "", Position{"test.gd", 1, 9, 11}},
+				{GTR, "", Position{"test.gd", 1, 12, 12}},
+				{GTR, "", Position{"test.gd", 1, 13, 13}},
+				{RSHIFT, "", Position{"test.gd", 1, 15, 16}},
+				{INT, "1", Position{"test.gd", 1, 18, 18}},
+			},
+		},
 		// Test use
 		{
 			"use a.b", []tokenLitPos{
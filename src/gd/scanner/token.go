@@ -34,6 +34,8 @@ const (
 	BOOL    // true, false
 	STRING  // "abc"
 	IMAG    // 123.45i
+	BIGINT  // 12345n
+	DECIMAL // 123.45d
 	CHAR    // 'a'
 	COMMENT // // or /* */
 
@@ -54,13 +56,22 @@ const (
 	QUO_ASSIGN // /=
 	REM_ASSIGN // %=
 
+	ADD_WRAP // +%
+	SUB_WRAP // -%
+	MUL_WRAP // *%
+	ADD_SAT  // +|
+	SUB_SAT  // -|
+	MUL_SAT  // *|
+
 	ARROW // =>
 
 	LAND // &&
 	LOR  // ||
 
 	NOT    // !
+	AND    // &
 	OR     // |
+	PIPE   // |>
 	RSHIFT // >>
 	LSHIFT // <<
 
@@ -87,6 +98,9 @@ const (
 	SEMICOLON // ;
 	COLON     // :
 
+	LSET_BRACE // {|
+	RSET_BRACE // |}
+
 	// Keywords
 
 	keyword_beg
@@ -103,6 +117,7 @@ const (
 	RETURN
 	BREAK
 	TYPEALIAS
+	TYPE
 	AS
 
 	TANY     // any
@@ -112,6 +127,18 @@ const (
 	TCOMPLEX // complex
 	TSTRING  // string
 	TCHAR    // char
+	TBIGINT  // bigint
+	TDECIMAL // decimal
+
+	TINT8   // int8
+	TINT16  // int16
+	TINT32  // int32
+	TINT64  // int64
+	TUINT8  // uint8
+	TUINT16 // uint16
+	TUINT32 // uint32
+	TUINT64 // uint64
+	TBYTE   // byte
 
 	TRUE  // true
 	FALSE // false
@@ -130,6 +157,8 @@ var Tokens = [...]string{
 	FLOAT:   "FLOAT",
 	STRING:  "STRING",
 	IMAG:    "COMPLEX",
+	BIGINT:  "BIGINT",
+	DECIMAL: "DECIMAL",
 	CHAR:    "CHAR",
 	COMMENT: "COMMENT",
 
@@ -148,13 +177,22 @@ var Tokens = [...]string{
 	QUO_ASSIGN: "/=",
 	REM_ASSIGN: "%=",
 
+	ADD_WRAP: "+%",
+	SUB_WRAP: "-%",
+	MUL_WRAP: "*%",
+	ADD_SAT:  "+|",
+	SUB_SAT:  "-|",
+	MUL_SAT:  "*|",
+
 	ARROW: "=>",
 
 	LAND: "&&",
 	LOR:  "||",
 
 	NOT:    "!",
+	AND:    "&",
 	OR:     "|",
+	PIPE:   "|>",
 	LSHIFT: "<<",
 	RSHIFT: ">>",
 
@@ -181,6 +219,9 @@ var Tokens = [...]string{
 	SEMICOLON: ";",
 	COLON:     ":",
 
+	LSET_BRACE: "{|",
+	RSET_BRACE: "|}",
+
 	USE:       "use",
 	SET:       "set",
 	CONST:     "const",
@@ -193,6 +234,7 @@ var Tokens = [...]string{
 	RETURN:    "return",
 	BREAK:     "break",
 	TYPEALIAS: "typealias",
+	TYPE:      "type",
 	AS:        "as",
 
 	TANY:     "any",
@@ -202,6 +244,18 @@ var Tokens = [...]string{
 	TCOMPLEX: "complex",
 	TSTRING:  "string",
 	TCHAR:    "char",
+	TBIGINT:  "bigint",
+	TDECIMAL: "decimal",
+
+	TINT8:   "int8",
+	TINT16:  "int16",
+	TINT32:  "int32",
+	TINT64:  "int64",
+	TUINT8:  "uint8",
+	TUINT16: "uint16",
+	TUINT32: "uint32",
+	TUINT64: "uint64",
+	TBYTE:   "byte",
 
 	TRUE:  "true",
 	FALSE: "false",
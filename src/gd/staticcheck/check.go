@@ -21,6 +21,7 @@ package staticcheck
 
 import (
 	"errors"
+	"fmt"
 	"gdlang/lib/builtin"
 	"gdlang/lib/runtime"
 	"gdlang/lib/tools"
@@ -28,6 +29,9 @@ import (
 	"gdlang/src/gd/analysis"
 	"gdlang/src/gd/ast"
 	"gdlang/src/gd/scanner"
+	"path/filepath"
+	"slices"
+	"strings"
 )
 
 type (
@@ -52,7 +56,12 @@ func (t *StaticCheck) EvalAtom(a *ast.NodeLiteral, stack *runtime.GDSymbolStack)
 	case scanner.STRING:
 		obj = runtime.GDString(a.Lit)
 	case scanner.CHAR:
-		obj = runtime.GDChar(a.Lit[0])
+		char, err := runtime.GDCharFromString(runtime.GDString(a.Lit).Escape())
+		if err != nil {
+			return nil, comn.WrapSyntaxErr(err, a.Position)
+		}
+
+		obj = char
 	case scanner.INT:
 		intVal, err := runtime.NewGDIntNumberFromString(a.Lit)
 		if err != nil {
@@ -80,6 +89,20 @@ func (t *StaticCheck) EvalAtom(a *ast.NodeLiteral, stack *runtime.GDSymbolStack)
 		}
 
 		obj = floatVal
+	case scanner.BIGINT:
+		bigIntVal, err := runtime.NewGDBigIntFromString(a.Lit)
+		if err != nil {
+			return nil, comn.WrapSyntaxErr(err, a.Position)
+		}
+
+		obj = bigIntVal
+	case scanner.DECIMAL:
+		decimalVal, err := runtime.NewGDDecimalFromString(a.Lit)
+		if err != nil {
+			return nil, comn.WrapSyntaxErr(err, a.Position)
+		}
+
+		obj = decimalVal
 	default:
 		panic("unexpected literal token: " + a.Lit)
 	}
@@ -97,6 +120,10 @@ func (t *StaticCheck) EvalIdent(i *ast.NodeIdent, stack *runtime.GDSymbolStack)
 		return nil, comn.WrapFatalErr(err, i.GetPosition())
 	}
 
+	if _, isAlias := symbol.Object.(*packageAlias); isAlias {
+		return nil, comn.AnalysisErr(fmt.Sprintf(comn.PackageAliasAsValueErrMsg, i.Lit), i.GetPosition())
+	}
+
 	obj := runtime.NewGDIdObject(ident, symbol.Object)
 
 	i.SetInferredIdent(ident)
@@ -202,6 +229,11 @@ func (t *StaticCheck) EvalExprOp(e *ast.NodeExprOperation, stack *runtime.GDSymb
 		}
 	}
 
+	// Operations between struct types might be overloaded by an operator function
+	if obj, isOverloaded, err := t.evalOperatorFuncCall(e, leftObj, rightObj, stack); isOverloaded || err != nil {
+		return obj, err
+	}
+
 	// Unary operation
 	if e.R == nil {
 		return leftObj, nil
@@ -314,6 +346,13 @@ func (t *StaticCheck) EvalFunc(f *ast.NodeFunc, stack *runtime.GDSymbolStack) (r
 	}
 
 	ident := runtime.NewGDStringIdent(f.Ident.Lit)
+	if f.IsOperator {
+		ident, err = t.operatorFuncIdent(f, stack)
+		if err != nil {
+			return nil, comn.WrapFatalErr(err, f.Ident.Position)
+		}
+	}
+
 	symbol, err := stack.AddSymbol(ident, f.IsPub, true, f.Type, lambda)
 	if err != nil {
 		return nil, comn.WrapFatalErr(err, f.Ident.Position)
@@ -338,6 +377,169 @@ func (t *StaticCheck) EvalFunc(f *ast.NodeFunc, stack *runtime.GDSymbolStack) (r
 	return lambda, nil
 }
 
+// Operator functions are identified by the operation and the resolved types of the arguments,
+// in that way the operator is found from the operand types of an expression operation.
+func (t *StaticCheck) operatorFuncIdent(f *ast.NodeFunc, stack *runtime.GDSymbolStack) (runtime.GDIdent, error) {
+	opName := runtime.ExprOperationMap[f.Op]
+
+	expectedArgs := uint(2)
+	if runtime.IsUnaryOperation(f.Op) {
+		expectedArgs = 1
+	}
+
+	if f.Type.IsVariadic || uint(len(f.Type.ArgTypes)) != expectedArgs {
+		return nil, runtime.InvalidOperatorFuncArgsErr(opName, expectedArgs, uint(len(f.Type.ArgTypes)))
+	}
+
+	hasStructArg := false
+	argTypes := make([]runtime.GDTypable, len(f.Type.ArgTypes))
+	for i, arg := range f.Type.ArgTypes {
+		typ, err := runtime.UnwrapIdentType(arg.Value, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		if isStructType(typ, stack) {
+			hasStructArg = true
+		}
+
+		argTypes[i] = typ
+	}
+
+	// Operations between builtin types can't be overloaded
+	if !hasStructArg {
+		return nil, runtime.InvalidOperatorFuncTypesErr(opName)
+	}
+
+	switch f.Op {
+	case runtime.ExprOperationEqual, runtime.ExprOperationNotEqual,
+		runtime.ExprOperationLess, runtime.ExprOperationLessEqual,
+		runtime.ExprOperationGreater, runtime.ExprOperationGreaterEqual,
+		runtime.ExprOperationNot:
+		err := runtime.EqualTypes(runtime.GDBoolType, f.Type.ReturnType, stack)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return runtime.OperatorIdent(f.Op, argTypes...), nil
+}
+
+// Resolves the operator function for an operation where at least one of the operands is a struct,
+// it returns false if there is no operator function that overloads the operation.
+// The overloads are matched by the assignability of the operands to their arguments,
+// and the ones declared in the inner scopes shadow the outer ones.
+func (t *StaticCheck) evalOperatorFuncCall(e *ast.NodeExprOperation, leftObj, rightObj runtime.GDObject, stack *runtime.GDSymbolStack) (runtime.GDObject, bool, error) {
+	if !runtime.IsOverloadableOperation(e.Op) {
+		return nil, false, nil
+	}
+
+	operands := []runtime.GDObject{runtime.Unwrap(leftObj)}
+	if e.R != nil {
+		operands = append(operands, runtime.Unwrap(rightObj))
+	}
+
+	hasStructOperand := false
+	types := make([]runtime.GDTypable, len(operands))
+	for i, operand := range operands {
+		if isStructType(operand.GetType(), stack) {
+			hasStructOperand = true
+		}
+
+		types[i] = operand.GetType()
+	}
+
+	if !hasStructOperand {
+		return nil, false, nil
+	}
+
+	ident, symbol, err := resolveOperatorFunc(e.Op, types, stack)
+	if err != nil {
+		return nil, true, comn.WrapFatalErr(err, e.GetPosition())
+	}
+
+	if symbol == nil {
+		return nil, false, nil
+	}
+
+	lambdaType := symbol.Type.(*runtime.GDLambdaType)
+	obj, err := runtime.ZObjectForType(lambdaType.ReturnType, stack)
+	if err != nil {
+		return nil, true, comn.WrapFatalErr(err, e.GetPosition())
+	}
+
+	e.SetInferredIdent(ident)
+	e.SetRuntimeIdent(symbol.Ident)
+	e.SetInferredObject(obj)
+
+	return obj, true, nil
+}
+
+// Operator function of the innermost scope whose arguments accept the types of the operands,
+// more than one match within the same scope is ambiguous
+func resolveOperatorFunc(op runtime.ExprOperationType, types []runtime.GDTypable, stack *runtime.GDSymbolStack) (runtime.GDIdent, *runtime.GDSymbol, error) {
+	prefix := runtime.OperatorIdentPrefix(op)
+	for scope := stack; scope != nil; scope = scope.Parent {
+		// Symbols are stored in a map, sort them to keep the resolution deterministic
+		names := make([]string, 0)
+		for key := range scope.Symbols {
+			if name, isName := key.(string); isName && strings.HasPrefix(name, prefix) {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+
+		var matchIdent runtime.GDIdent
+		var match *runtime.GDSymbol
+		for _, name := range names {
+			symbol := scope.Symbols[name]
+			lambdaType, isLambdaType := symbol.Type.(*runtime.GDLambdaType)
+			if !isLambdaType || len(lambdaType.ArgTypes) != len(types) || !acceptsOperands(lambdaType, types, stack) {
+				continue
+			}
+
+			ident := runtime.NewGDStringIdent(name)
+			if match != nil {
+				return nil, nil, runtime.AmbiguousOperatorFuncErr(runtime.ExprOperationMap[op], matchIdent, ident)
+			}
+
+			matchIdent, match = ident, symbol
+		}
+
+		if match != nil {
+			return matchIdent, match, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+func acceptsOperands(lambdaType *runtime.GDLambdaType, types []runtime.GDTypable, stack *runtime.GDSymbolStack) bool {
+	for i, arg := range lambdaType.ArgTypes {
+		if runtime.CanBeAssign(arg.Value, types[i], stack) != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Struct types, and nominal types of a struct, can be operands of the operator functions
+func isStructType(typ runtime.GDTypable, stack *runtime.GDSymbolStack) bool {
+	typ, err := runtime.UnwrapIdentType(typ, stack)
+	if err != nil {
+		return false
+	}
+
+	if nominalType, isNominal := typ.(*runtime.GDNominalType); isNominal {
+		return isStructType(nominalType.Type, stack)
+	}
+
+	_, isStruct := typ.(runtime.GDStructType)
+
+	return isStruct
+}
+
 func (t *StaticCheck) EvalTuple(tu *ast.NodeTuple, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	if len(tu.Nodes) == 0 {
 		tuple := runtime.NewGDTuple()
@@ -434,6 +636,26 @@ func (t *StaticCheck) EvalArray(a *ast.NodeArray, stack *runtime.GDSymbolStack)
 	return array, nil
 }
 
+func (t *StaticCheck) EvalSetLiteral(s *ast.NodeSetLiteral, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
+	objects := make([]runtime.GDObject, len(s.Nodes))
+	for i, expr := range s.Nodes {
+		exprObj, err := t.EvalNode(expr, stack)
+		if err != nil {
+			return nil, err
+		}
+
+		objects[i] = exprObj
+	}
+
+	// The elements are hashed at runtime, so the
+	// set only takes the type computed from the objects
+	set := runtime.NewGDSetWithType(runtime.ComputeTypeFromObjects(objects))
+	s.SetInferredType(set.GetType())
+	s.SetInferredObject(set)
+
+	return set, nil
+}
+
 func (t *StaticCheck) EvalReturn(r *ast.NodeReturn, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	// Return with not expression
 	// For example: `return`
@@ -468,7 +690,7 @@ func (t *StaticCheck) EvalIterIdxExpr(a *ast.NodeIterIdxExpr, stack *runtime.GDS
 		return nil, comn.WrapFatalErr(err, a.IdxExpr.GetPosition())
 	}
 
-	if iterable, isIterable := runtime.Unwrap(exprObj).(runtime.GDIterableCollection); isIterable {
+	if iterable, isIterable := runtime.UnwrapNominal(exprObj).(runtime.GDIterableCollection); isIterable {
 		obj, err := runtime.ZObjectForType(iterable.GetIterableType(), stack)
 		if err != nil {
 			return nil, comn.WrapFatalErr(err, a.GetPosition())
@@ -508,6 +730,11 @@ func (t *StaticCheck) EvalCallExpr(c *ast.NodeCallExpr, stack *runtime.GDSymbolS
 		if err != nil {
 			return nil, comn.WrapFatalErr(err, arg.GetPosition())
 		}
+
+		err = checkAttrModifiers(argObj.GetType(), funcType.ArgTypeAtIndex(i), filepath.Dir(arg.GetPosition().Filename), stack, make(map[string]bool))
+		if err != nil {
+			return nil, comn.WrapFatalErr(err, arg.GetPosition())
+		}
 	}
 
 	obj, err := runtime.ZObjectForType(funcType.ReturnType, stack)
@@ -524,6 +751,10 @@ func (t *StaticCheck) EvalSafeDotExpr(s *ast.NodeSafeDotExpr, stack *runtime.GDS
 		idxExpr := ast.NewNodeIterIdxExpr(s.IsNilSafe, s.Expr, ast.NewNodeLiteral(identExpr))
 		return t.EvalIterIdxExpr(idxExpr, stack)
 	case *ast.NodeIdent:
+		if alias := packageAliasOf(s.Expr, stack); alias != nil {
+			return t.evalPackageMember(s, alias, identExpr, stack)
+		}
+
 		obj, err := t.EvalNode(s.Expr, stack)
 		if err != nil {
 			return nil, err
@@ -532,7 +763,7 @@ func (t *StaticCheck) EvalSafeDotExpr(s *ast.NodeSafeDotExpr, stack *runtime.GDS
 		attrIdent := runtime.NewGDStringIdent(identExpr.Lit)
 		s.SetInferredIdent(attrIdent)
 
-		obj = runtime.Unwrap(obj)
+		obj = runtime.UnwrapNominal(obj)
 		if obj == runtime.GDZNil {
 			if s.IsNilSafe {
 				return runtime.GDZNil, nil
@@ -541,17 +772,33 @@ func (t *StaticCheck) EvalSafeDotExpr(s *ast.NodeSafeDotExpr, stack *runtime.GDS
 			}
 		}
 
-		if attributable, isAttributable := obj.(runtime.GDAttributable); isAttributable {
+		attributable, isAttributable := obj.(runtime.GDAttributable)
+		if !isAttributable {
+			// Builtin types expose their native methods as attributes
+			attributable, isAttributable = runtime.NativeMethodsOf(obj)
+		}
+
+		if isAttributable {
 			symbol, err := attributable.GetAttr(attrIdent)
 			if err != nil {
 				return nil, comn.WrapFatalErr(err, s.GetPosition())
 			}
 
+			// Non public attributes are only accessible from the package where they are declared
+			if attrType, isStructAttr := structAttrType(attributable, attrIdent); isStructAttr {
+				if !attrType.IsAccessibleFrom(filepath.Dir(s.GetPosition().Filename)) {
+					return nil, comn.WrapFatalErr(runtime.PrivateAttributeErr(identExpr.Lit), identExpr.GetPosition())
+				}
+			}
+
 			zObj, err := runtime.ZObjectForType(symbol.Type, stack)
 			if err != nil {
 				return nil, comn.WrapFatalErr(err, s.GetPosition())
 			}
 
+			s.SetInferredType(symbol.Type)
+			s.SetInferredObject(zObj)
+
 			return runtime.NewGDAttrIdObject(attrIdent, zObj, attributable), nil
 		}
 
@@ -561,6 +808,16 @@ func (t *StaticCheck) EvalSafeDotExpr(s *ast.NodeSafeDotExpr, stack *runtime.GDS
 	return nil, nil
 }
 
+// Returns the attribute type with its modifiers when the attributable object is a struct
+func structAttrType(attributable runtime.GDAttributable, ident runtime.GDIdent) (runtime.GDStructAttrType, bool) {
+	if structObj, isStruct := attributable.(*runtime.GDStruct); isStruct {
+		attrType, err := structObj.Type.GetAttr(ident)
+		return attrType, err == nil
+	}
+
+	return runtime.GDStructAttrType{}, false
+}
+
 func (t *StaticCheck) EvalSets(s *ast.NodeSets, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	for _, node := range s.Nodes {
 		_, err := t.EvalNode(node, stack)
@@ -628,6 +885,11 @@ func (t *StaticCheck) EvalSet(s *ast.NodeSet, stack *runtime.GDSymbolStack) (run
 		return nil, comn.WrapFatalErr(err, s.GetPosition())
 	}
 
+	err = checkAttrModifiers(exprObj.GetType(), inferredType, filepath.Dir(s.GetPosition().Filename), stack, make(map[string]bool))
+	if err != nil {
+		return nil, comn.WrapFatalErr(err, s.GetPosition())
+	}
+
 	exprObj, err = runtime.TypeCoercion(exprObj, inferredType, stack)
 	if err != nil {
 		return nil, comn.WrapFatalErr(err, s.GetPosition())
@@ -637,7 +899,7 @@ func (t *StaticCheck) EvalSet(s *ast.NodeSet, stack *runtime.GDSymbolStack) (run
 		switch s.Expr.(type) {
 		case *ast.NodeSharedExpr:
 		default:
-			s.Expr.SetInferredType(inferredType)
+			s.Expr.SetInferredType(exprInferredType(inferredType, exprObj, stack))
 			s.Expr.SetInferredObject(exprObj)
 		}
 	}
@@ -676,6 +938,26 @@ func (t *StaticCheck) EvalSet(s *ast.NodeSet, stack *runtime.GDSymbolStack) (run
 	return runtime.NewGDIdObject(ident, exprObj), nil
 }
 
+// Objects assigned to `any` or to a union type keep their own type,
+// the wider type only belongs to the symbol
+func exprInferredType(inferredType runtime.GDTypable, exprObj runtime.GDObject, stack *runtime.GDSymbolStack) runtime.GDTypable {
+	typ, err := runtime.UnwrapIdentType(inferredType, stack)
+	if err != nil {
+		return inferredType
+	}
+
+	switch typ.(type) {
+	case runtime.GDUnionType:
+		return exprObj.GetType()
+	}
+
+	if typ == runtime.GDAnyType {
+		return exprObj.GetType()
+	}
+
+	return inferredType
+}
+
 func (t *StaticCheck) EvalUpdateSet(u *ast.NodeUpdateSet, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	assignObj, err := t.EvalNode(u.Expr, stack)
 	if err != nil {
@@ -702,7 +984,9 @@ func (t *StaticCheck) EvalUpdateSet(u *ast.NodeUpdateSet, stack *runtime.GDSymbo
 
 		// Collectable
 		mutable, isMutableType := runtime.Unwrap(expressionObj).(runtime.GDMutableCollection)
-		if isMutableType {
+		if _, isSet := mutable.(*runtime.GDSet); isSet {
+			return nil, comn.WrapFatalErr(runtime.SetIndexAssignmentErr, identExpr.GetPosition())
+		} else if isMutableType {
 			err := runtime.CanBeAssign(mutable.GetIterableType(), assignObj.GetType(), stack)
 			if err != nil {
 				return nil, comn.WrapFatalErr(runtime.WrongTypesErr(expressionObj.GetType(), assignObj.GetType()), u.Expr.GetPosition())
@@ -727,11 +1011,20 @@ func (t *StaticCheck) EvalUpdateSet(u *ast.NodeUpdateSet, stack *runtime.GDSymbo
 				return nil, comn.WrapFatalErr(err, identExpr.GetPosition())
 			}
 
+			err = checkAttrModifiers(assignObj.GetType(), symbol.Type, filepath.Dir(u.GetPosition().Filename), stack, make(map[string]bool))
+			if err != nil {
+				return nil, comn.WrapFatalErr(err, u.Expr.GetPosition())
+			}
+
 			err = symbol.SetObject(assignObj, stack)
 			if err != nil {
 				return nil, comn.WrapFatalErr(err, u.Expr.GetPosition())
 			}
 		case *runtime.GDAttrIdObject:
+			if attrType, isStructAttr := structAttrType(expr.GDAttributable, expr.Ident); isStructAttr && attrType.IsConst {
+				return nil, comn.WrapFatalErr(runtime.SetConstAttributeErr(expr.Ident.ToString()), identExpr.GetPosition())
+			}
+
 			_, err := expr.SetAttr(expr.Ident, assignObj)
 			if err != nil {
 				return nil, comn.WrapFatalErr(err, u.Expr.GetPosition())
@@ -824,7 +1117,7 @@ func (t *StaticCheck) EvalForIn(f *ast.NodeForIn, stack *runtime.GDSymbolStack)
 
 	f.SetInferredType(exprObj.GetType())
 
-	iterable, isIterable := runtime.Unwrap(exprObj).(runtime.GDIterableCollection)
+	iterable, isIterable := runtime.UnwrapNominal(exprObj).(runtime.GDIterableCollection)
 	if !isIterable {
 		return nil, comn.WrapFatalErr(runtime.InvalidIterableTypeErr(exprObj.GetType()), f.Expr.GetPosition())
 	}
@@ -885,13 +1178,13 @@ func (t *StaticCheck) EvalForIn(f *ast.NodeForIn, stack *runtime.GDSymbolStack)
 
 		f.InferredIterable = set
 	case sListLen > 1:
-		idxSet, err := updateSymbolType(sets[0], runtime.GDIntType, iterableZObj)
+		idxSet, err := updateSymbolType(sets[0], runtime.GDIntType, runtime.GDZInt)
 		if err != nil {
 			return nil, comn.WrapFatalErr(err, sets[0].GetPosition())
 		}
 		f.InferredIndex = idxSet
 
-		iterSet, err := updateSymbolType(sets[1], iterable.GetIterableType(), runtime.GDInt8(0))
+		iterSet, err := updateSymbolType(sets[1], iterable.GetIterableType(), iterableZObj)
 		if err != nil {
 			return nil, comn.WrapFatalErr(err, sets[1].GetPosition())
 		}
@@ -934,6 +1227,40 @@ func (t *StaticCheck) EvalForIf(f *ast.NodeForIf, stack *runtime.GDSymbolStack)
 	return nil, nil
 }
 
+func (t *StaticCheck) EvalComprehension(c *ast.NodeComprehension, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
+	// The accumulator is only visible inside the comprehension
+	compStack := stack.NewSymbolStack(runtime.BlockCtx)
+	defer compStack.Dispose()
+
+	accIdent := runtime.NewGDStringIdent(c.Acc.Lit)
+	acc := runtime.NewGDEmptyArray()
+	symbol, err := compStack.AddSymbol(accIdent, false, false, acc.GetType(), acc)
+	if err != nil {
+		return nil, comn.WrapFatalErr(err, c.GetPosition())
+	}
+	symbol.Ident = t.NewIdent()
+
+	_, err = t.EvalForIn(c.ForIn, compStack)
+	if err != nil {
+		return nil, err
+	}
+
+	// The element type is inferred from the expression added to the accumulator
+	arrayType, isArrayType := symbol.Type.(*runtime.GDArrayType)
+	if !isArrayType {
+		return nil, comn.WrapFatalErr(runtime.InvalidIterableTypeErr(symbol.Type), c.GetPosition())
+	}
+
+	array := runtime.NewGDArrayWithTypeAndObjects(arrayType, []runtime.GDObject{})
+
+	c.SetInferredIdent(accIdent)
+	c.SetRuntimeIdent(symbol.Ident)
+	c.SetInferredType(arrayType)
+	c.SetInferredObject(array)
+
+	return array, nil
+}
+
 func (t *StaticCheck) EvalCollectableOp(c *ast.NodeMutCollectionOp, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	exprLObj, err := t.EvalNode(c.L, stack)
 	if err != nil {
@@ -945,6 +1272,10 @@ func (t *StaticCheck) EvalCollectableOp(c *ast.NodeMutCollectionOp, stack *runti
 		return nil, err
 	}
 
+	if set, isSet := runtime.Unwrap(exprLObj).(*runtime.GDSet); isSet {
+		return t.evalSetCollectableOp(c, set, exprRObj, stack)
+	}
+
 	if mutCollection, isMutCollection := runtime.Unwrap(exprLObj).(runtime.GDMutableCollection); isMutCollection {
 		switch c.Op {
 		case ast.MutableCollectionAddOp:
@@ -997,6 +1328,35 @@ func (t *StaticCheck) EvalCollectableOp(c *ast.NodeMutCollectionOp, stack *runti
 	return nil, comn.WrapFatalErr(runtime.InvalidMutableCollectionTypeErr(exprLObj.GetType()), c.GetPosition())
 }
 
+// The elements of a set are added and removed by value,
+// removing an element results in whether the element was in the set
+func (t *StaticCheck) evalSetCollectableOp(c *ast.NodeMutCollectionOp, set *runtime.GDSet, exprRObj runtime.GDObject, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
+	err := runtime.CanBeAssign(set.GetIterableType(), exprRObj.GetType(), stack)
+	if err != nil {
+		return nil, comn.WrapFatalErr(err, c.GetPosition())
+	}
+
+	switch c.Op {
+	case ast.MutableCollectionAddOp:
+		// The type of an empty set is inferred from the first element
+		if ident, ok := c.L.(*ast.NodeIdent); ok {
+			symbol, err := stack.GetSymbol(runtime.NewGDStringIdent(ident.Lit))
+			if err != nil {
+				return nil, comn.WrapFatalErr(err, ident.GetPosition())
+			}
+
+			err = symbol.SetType(runtime.NewGDSetType(exprRObj.GetType()), stack)
+			if err != nil {
+				return nil, comn.WrapFatalErr(err, c.GetPosition())
+			}
+		}
+
+		return exprRObj, nil
+	default:
+		return runtime.GDBool(false), nil
+	}
+}
+
 func (t *StaticCheck) EvalTypeAlias(ta *ast.NodeTypeAlias, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	ident := runtime.NewGDStringIdent(ta.Ident.Lit)
 	_, err := stack.AddSymbol(ident, ta.IsPub, true, ta.Type, nil)
@@ -1004,18 +1364,56 @@ func (t *StaticCheck) EvalTypeAlias(ta *ast.NodeTypeAlias, stack *runtime.GDSymb
 		return nil, comn.WrapFatalErr(err, ta.GetPosition())
 	}
 
+	err = checkAliasCycle(ident, ta.Type, stack)
+	if err != nil {
+		return nil, comn.WrapFatalErr(err, ta.GetPosition())
+	}
+
 	ta.SetInferredIdent(ident)
 
 	return nil, nil
 }
 
+// An alias that only leads to other aliases and back to itself never reaches a type.
+// The aliases declared later are not followed yet, the cycle is found by the last one.
+func checkAliasCycle(ident runtime.GDIdent, typ runtime.GDTypable, stack *runtime.GDSymbolStack) error {
+	visited := map[string]bool{ident.ToString(): true}
+	for {
+		switch aliasType := typ.(type) {
+		case *runtime.GDNominalType:
+			typ = aliasType.Type
+			continue
+		case runtime.GDIdent:
+			if visited[aliasType.ToString()] {
+				return runtime.CyclicTypeAliasErr(ident.ToString())
+			}
+			visited[aliasType.ToString()] = true
+
+			symbol, err := stack.GetSymbol(aliasType)
+			if err != nil {
+				return nil
+			}
+
+			typ = symbol.Type
+			continue
+		}
+
+		return nil
+	}
+}
+
 func (t *StaticCheck) EvalCastExpr(c *ast.NodeCastExpr, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	exprObj, err := t.EvalNode(c.Expr, stack)
 	if err != nil {
 		return nil, err
 	}
 
-	castObj, err := exprObj.CastToType(c.Type, stack)
+	err = checkAttrModifiers(exprObj.GetType(), c.Type, filepath.Dir(c.GetPosition().Filename), stack, make(map[string]bool))
+	if err != nil {
+		return nil, comn.WrapFatalErr(err, c.GetPosition())
+	}
+
+	castObj, err := runtime.CastObject(exprObj, c.Type, stack)
 	if err != nil {
 		return nil, comn.WrapFatalErr(err, c.GetPosition())
 	}
@@ -1026,6 +1424,91 @@ func (t *StaticCheck) EvalCastExpr(c *ast.NodeCastExpr, stack *runtime.GDSymbolS
 	return castObj, nil
 }
 
+// The modifiers of the attributes declared in another package can't be changed by a cast
+// or by an assignment to a declared type, e.g. of an argument, otherwise a private attribute
+// could be made public, or a constant one mutable. A private attribute of another package
+// can only be read or set through a type of the same package, so a struct literal can't set it.
+// The pairs of types already checked are skipped, since the types might be recursive.
+func checkAttrModifiers(fromType, toType runtime.GDTypable, pkgPath string, stack *runtime.GDSymbolStack, visits map[string]bool) error {
+	visit := fromType.ToString() + " as " + toType.ToString()
+	if visits[visit] {
+		return nil
+	}
+	visits[visit] = true
+
+	fromType, err := unwrapCastType(fromType, stack)
+	if err != nil {
+		return err
+	}
+
+	toType, err = unwrapCastType(toType, stack)
+	if err != nil {
+		return err
+	}
+
+	switch fromType := fromType.(type) {
+	case runtime.GDStructType:
+		toType, isStruct := toType.(runtime.GDStructType)
+		if !isStruct {
+			return nil
+		}
+
+		for _, fromAttr := range fromType {
+			toAttr, err := toType.GetAttr(fromAttr.Ident)
+			if err != nil {
+				continue
+			}
+
+			if fromAttr.PkgPath != "" && fromAttr.PkgPath != pkgPath && (fromAttr.IsPub != toAttr.IsPub || fromAttr.IsConst != toAttr.IsConst) {
+				return runtime.AttributeModifiersCastErr(fromAttr.Ident.ToString())
+			}
+
+			isPrivate := !fromAttr.IsAccessibleFrom(pkgPath) || !toAttr.IsAccessibleFrom(pkgPath)
+			if isPrivate && fromAttr.PkgPath != toAttr.PkgPath {
+				return runtime.PrivateAttributeErr(fromAttr.Ident.ToString())
+			}
+
+			err = checkAttrModifiers(fromAttr.Type, toAttr.Type, pkgPath, stack, visits)
+			if err != nil {
+				return err
+			}
+		}
+	case *runtime.GDArrayType:
+		if toType, isArray := toType.(*runtime.GDArrayType); isArray {
+			return checkAttrModifiers(fromType.SubType, toType.SubType, pkgPath, stack, visits)
+		}
+	case *runtime.GDSetType:
+		if toType, isSet := toType.(*runtime.GDSetType); isSet {
+			return checkAttrModifiers(fromType.SubType, toType.SubType, pkgPath, stack, visits)
+		}
+	case runtime.GDTupleType:
+		if toType, isTuple := toType.(runtime.GDTupleType); isTuple && len(toType) == len(fromType) {
+			for i, typ := range fromType {
+				err := checkAttrModifiers(typ, toType[i], pkgPath, stack, visits)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// Casts convert nominal objects to their underlying type, so the modifiers are compared on it
+func unwrapCastType(typ runtime.GDTypable, stack *runtime.GDSymbolStack) (runtime.GDTypable, error) {
+	typ, err := runtime.UnwrapIdentType(typ, stack)
+	if err != nil {
+		return nil, err
+	}
+
+	if nominalType, isNominal := typ.(*runtime.GDNominalType); isNominal {
+		return unwrapCastType(nominalType.Type, stack)
+	}
+
+	return typ, nil
+}
+
 // Register a new package in the symbol stack
 // NOTE: Package must exist before evaluation
 // Those checks are performed during the dependency analysis
@@ -1041,7 +1524,14 @@ func (t *StaticCheck) EvalPackage(p *ast.NodePackage, stack *runtime.GDSymbolSta
 
 				ident := runtime.NewGDStringIdent(identNode.Lit)
 				if symbol, err := pkg.GetMember(ident); err == nil {
-					err := stack.AddSymbolStack(ident, symbol)
+					stackIdent := packageImportIdent(p, ident)
+
+					// The same object might be imported by several source files
+					if imported, err := stack.GetSymbol(stackIdent); err == nil && imported == symbol {
+						continue
+					}
+
+					err := stack.AddSymbolStack(stackIdent, symbol)
 					if err != nil {
 						return nil, comn.WrapFatalErr(err, p.GetPosition())
 					}
@@ -1050,9 +1540,37 @@ func (t *StaticCheck) EvalPackage(p *ast.NodePackage, stack *runtime.GDSymbolSta
 		}
 	}
 
+	if p.Alias != nil {
+		alias := newPackageAlias(p)
+		err := stack.AddSymbolStack(alias.ident, runtime.NewGDSymbol(false, true, runtime.GDAnyType, alias))
+		if err != nil {
+			return nil, comn.WrapFatalErr(err, p.Alias.GetPosition())
+		}
+	}
+
 	return nil, nil
 }
 
+// Objects of a package alias are resolved to the symbols of the package
+func (t *StaticCheck) evalPackageMember(s *ast.NodeSafeDotExpr, alias *packageAlias, identExpr *ast.NodeIdent, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
+	ident, isMember := alias.members[identExpr.Lit]
+	if !isMember {
+		return nil, comn.AnalysisErr(fmt.Sprintf(comn.PublicObjectNotFoundErrMsg, identExpr.Lit, alias.ident.ToString()), identExpr.GetPosition())
+	}
+
+	symbol, err := stack.GetSymbol(ident)
+	if err != nil {
+		return nil, comn.WrapFatalErr(err, identExpr.GetPosition())
+	}
+
+	s.InferredPackageMember = true
+	s.SetInferredIdent(ident)
+	s.SetRuntimeIdent(symbol.Ident)
+	s.SetInferredObject(symbol.Object)
+
+	return runtime.NewGDIdObject(ident, symbol.Object), nil
+}
+
 func (t *StaticCheck) evalIfNode(i *ast.NodeIf, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
 	err := t.checkIfConditions(i.Conditions, stack)
 	if err != nil {
@@ -1105,6 +1623,11 @@ func (t *StaticCheck) evalBlock(b *ast.NodeBlock, stack *runtime.GDSymbolStack)
 					return nil, comn.WrapFatalErr(err, node.GetPosition())
 				}
 
+				err = checkAttrModifiers(obj.GetType(), b.ReturnType, filepath.Dir(node.GetPosition().Filename), stack, make(map[string]bool))
+				if err != nil {
+					return nil, comn.WrapFatalErr(err, node.GetPosition())
+				}
+
 				node.SetInferredType(inferredType)
 				if node.Expr != nil {
 					node.Expr.SetInferredType(inferredType)
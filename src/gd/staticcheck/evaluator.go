@@ -36,6 +36,7 @@ type Evaluator[T interface{}, E interface{}] interface {
 	EvalTuple(t *ast.NodeTuple, stack E) (T, error)
 	EvalStruct(s *ast.NodeStruct, stack E) (T, error)
 	EvalArray(a *ast.NodeArray, stack E) (T, error)
+	EvalSetLiteral(s *ast.NodeSetLiteral, stack E) (T, error)
 	EvalReturn(r *ast.NodeReturn, stack E) (T, error)
 	EvalIterIdxExpr(a *ast.NodeIterIdxExpr, stack E) (T, error)
 	EvalCallExpr(c *ast.NodeCallExpr, stack E) (T, error)
@@ -48,6 +49,7 @@ type Evaluator[T interface{}, E interface{}] interface {
 	EvalTernaryIf(t *ast.NodeTernaryIf, stack E) (T, error)
 	EvalForIn(f *ast.NodeForIn, stack E) (T, error)
 	EvalForIf(f *ast.NodeForIf, stack E) (T, error)
+	EvalComprehension(c *ast.NodeComprehension, stack E) (T, error)
 	EvalCollectableOp(c *ast.NodeMutCollectionOp, stack E) (T, error)
 	EvalTypeAlias(t *ast.NodeTypeAlias, stack E) (T, error)
 	EvalCastExpr(c *ast.NodeCastExpr, stack E) (T, error)
@@ -88,6 +90,8 @@ func (e *ExpressionEvaluator[T, E]) EvalNode(node ast.Node, stack E) (T, error)
 		return e.EvalStruct(node, stack)
 	case *ast.NodeArray:
 		return e.EvalArray(node, stack)
+	case *ast.NodeSetLiteral:
+		return e.EvalSetLiteral(node, stack)
 	case *ast.NodeReturn:
 		return e.EvalReturn(node, stack)
 	case *ast.NodeSafeDotExpr:
@@ -116,6 +120,8 @@ func (e *ExpressionEvaluator[T, E]) EvalNode(node ast.Node, stack E) (T, error)
 		return e.EvalForIn(node, stack)
 	case *ast.NodeForIf:
 		return e.EvalForIf(node, stack)
+	case *ast.NodeComprehension:
+		return e.EvalComprehension(node, stack)
 	case *ast.NodeMutCollectionOp:
 		return e.EvalCollectableOp(node, stack)
 	case *ast.NodeBreak:
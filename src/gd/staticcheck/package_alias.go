@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package staticcheck
+
+import (
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/ast"
+)
+
+// A package imported with an alias, e.g. `use http as h`.
+// The alias only exists during the static check, since the
+// objects of the package are compiled as their own idents.
+type packageAlias struct {
+	ident runtime.GDIdent
+	// Ident in the stack of each public object of the package
+	members map[string]runtime.GDIdent
+}
+
+func (a *packageAlias) GetType() runtime.GDTypable    { return runtime.GDAnyType }
+func (a *packageAlias) GetSubType() runtime.GDTypable { return nil }
+func (a *packageAlias) ToString() string              { return a.ident.ToString() }
+func (a *packageAlias) CastToType(typ runtime.GDTypable, _ *runtime.GDSymbolStack) (runtime.GDObject, error) {
+	return nil, runtime.InvalidCastingWrongTypeErr(typ, a.GetType())
+}
+
+func newPackageAlias(p *ast.NodePackage) *packageAlias {
+	members := make(map[string]runtime.GDIdent, len(p.Imports))
+	for _, node := range p.Imports {
+		identNode := node.(*ast.NodeIdent)
+		members[identNode.Lit] = packageImportIdent(p, runtime.NewGDStringIdent(identNode.Lit))
+	}
+
+	return &packageAlias{runtime.NewGDStringIdent(p.Alias.Lit), members}
+}
+
+// Ident of an imported object in the stack, the objects of builtin
+// packages imported with an alias are qualified by the alias, e.g. `h.get`
+func packageImportIdent(p *ast.NodePackage, ident runtime.GDIdent) runtime.GDIdent {
+	if p.Alias != nil && p.InferredMode == runtime.PackageModeBuiltin {
+		return runtime.NewGDPackageMemberIdent(runtime.NewGDStringIdent(p.Alias.Lit), ident)
+	}
+
+	return ident
+}
+
+// Returns the package alias when the node is the ident of an alias, e.g. `h` in `h.get()`
+func packageAliasOf(node ast.Node, stack *runtime.GDSymbolStack) *packageAlias {
+	identNode, isIdentNode := node.(*ast.NodeIdent)
+	if !isIdentNode {
+		return nil
+	}
+
+	symbol, err := stack.GetSymbol(runtime.NewGDStringIdent(identNode.Lit))
+	if err != nil {
+		return nil
+	}
+
+	alias, _ := symbol.Object.(*packageAlias)
+	return alias
+}
@@ -0,0 +1,331 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lsp
+
+import (
+	"errors"
+	"fmt"
+	"gdlang/lib/builtin"
+	"gdlang/lib/runtime"
+	"gdlang/src/comn"
+	"gdlang/src/gd/analysis"
+	"gdlang/src/gd/ast"
+	"gdlang/src/gd/scanner"
+	"gdlang/src/gd/staticcheck"
+	"slices"
+)
+
+// Result of checking the packages of the workspace, the nodes
+// of the source files keep the types inferred by the static check
+type checkResult struct {
+	analyzer *analysis.PackageDependenciesAnalyzer
+	// Source files by path
+	files map[string]*analysis.SourceFile
+}
+
+// Checks the packages from the root of the workspace, the open documents
+// replace the files on disk. The result is nil when the packages could not be built,
+// otherwise it is kept even if the static check fails, since most of the nodes are typed
+func check(root string, overlay map[string][]byte) (result *checkResult, err error) {
+	// The analysis panics on some invalid trees, which must not stop the server
+	defer func() {
+		if r := recover(); r != nil {
+			err = comn.AnalysisErr(fmt.Sprint(r), scanner.ZeroPos)
+		}
+	}()
+
+	analyzer := analysis.NewPackageDependenciesAnalyzer()
+	analyzer.Overlay = overlay
+	err = analyzer.Analyze(root, analysis.PackageDependenciesAnalyzerOptions{IsMainOptional: true})
+	if err != nil {
+		return nil, err
+	}
+
+	result = &checkResult{analyzer, make(map[string]*analysis.SourceFile)}
+	for _, file := range analyzer.SourceFiles() {
+		result.files[file.Path] = file
+	}
+
+	stack := runtime.NewGDSymbolStack()
+	defer stack.Dispose()
+
+	err = builtin.ImportCoreBuiltins(stack)
+	if err != nil {
+		return result, err
+	}
+
+	return result, staticcheck.NewStaticCheck(analyzer).Check(stack)
+}
+
+func (r *checkResult) fileOf(path string) (*analysis.SourceFile, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	file, isChecked := r.files[path]
+	return file, isChecked
+}
+
+// Declaration of the ident at the end of the path of nodes, the local objects
+// are looked up in the enclosing scopes and then in the members of the source file
+func (r *checkResult) definitionOf(file *analysis.SourceFile, nodes []ast.Node) ast.Node {
+	ident, isIdent := nodes[len(nodes)-1].(*ast.NodeIdent)
+	if !isIdent {
+		return nil
+	}
+
+	switch parent := nodes[len(nodes)-2].(type) {
+	case *ast.NodeIdentWithType, *ast.NodeTypeAlias, *ast.NodeFunc:
+		return ident
+	case *ast.NodeStructAttr:
+		if parent.Ident == ident {
+			return ident
+		}
+	case *ast.NodePackage:
+		if slices.Contains(parent.Imports, nodes[len(nodes)-1]) {
+			return declIdentOf(r.packageMember(r.analyzer.GetPackage(parent.InferredPath), ident.Lit))
+		} else if parent.Alias == ident {
+			return ident
+		}
+
+		return nil
+	case *ast.NodeSafeDotExpr:
+		if parent.Ident == nodes[len(nodes)-1] {
+			return r.attrDefinition(file, parent)
+		}
+	}
+
+	for i := len(nodes) - 2; i >= 0; i-- {
+		var decl *ast.NodeIdent
+		switch node := nodes[i].(type) {
+		case *ast.NodeBlock:
+			// Only the objects declared before the statement are visible
+			for _, stmt := range node.Nodes {
+				if stmt == nodes[i+1] {
+					break
+				}
+
+				if stmtDecl := declaredIdent(stmt, ident.Lit); stmtDecl != nil {
+					decl = stmtDecl
+				}
+			}
+		case *ast.NodeForIn:
+			decl = declaredIdent(node.Sets, ident.Lit)
+		case *ast.NodeForIf:
+			decl = declaredIdent(node.Sets, ident.Lit)
+		case *ast.NodeComprehension:
+			decl = declaredIdent(node.Sets, ident.Lit)
+		case *ast.NodeFunc:
+			// Arguments have no node, the function is the closest declaration
+			isArg := slices.ContainsFunc(node.Type.ArgTypes, func(arg runtime.GDLambdaArgType) bool {
+				return arg.Key.ToString() == ident.Lit
+			})
+
+			if isArg {
+				return node.Ident
+			}
+		}
+
+		if decl != nil {
+			return decl
+		}
+	}
+
+	switch ref := r.analyzer.GetNodeReference(runtime.NewGDStringIdent(ident.Lit), file).(type) {
+	case *analysis.NodeWithSourceFile:
+		return declIdentOf(ref.Node)
+	case *analysis.PackageAlias:
+		return ref.Node.Alias
+	}
+
+	return nil
+}
+
+// Declaration of a public object of a source package
+func (r *checkResult) packageMember(pkg analysis.Package, name string) ast.Node {
+	sourcePkg, isSourcePkg := pkg.(*analysis.SourcePackage)
+	if !isSourcePkg {
+		return nil
+	}
+
+	member, err := sourcePkg.GetMember(runtime.NewGDStringIdent(name))
+	if err != nil {
+		return nil
+	}
+
+	return member.Node
+}
+
+// Objects of a package alias are declared in the package, and the attributes
+// of a struct are declared by the type alias of the struct, since they have no node
+func (r *checkResult) attrDefinition(file *analysis.SourceFile, dot *ast.NodeSafeDotExpr) ast.Node {
+	attrIdent := dot.Ident.(*ast.NodeIdent)
+	if dot.InferredPackageMember {
+		aliasIdent, isIdent := dot.Expr.(*ast.NodeIdent)
+		if !isIdent {
+			return nil
+		}
+
+		alias, isAlias := r.analyzer.GetNodeReference(runtime.NewGDStringIdent(aliasIdent.Lit), file).(*analysis.PackageAlias)
+		if !isAlias {
+			return nil
+		}
+
+		return declIdentOf(r.packageMember(alias.Package, attrIdent.Lit))
+	}
+
+	structObj, isStruct := runtime.Unwrap(dot.Expr.InferredObject()).(*runtime.GDStruct)
+	if !isStruct {
+		return nil
+	}
+
+	for _, sourceFile := range r.analyzer.SourceFiles() {
+		for _, node := range sourceFile.Root.Nodes {
+			typeAlias, isTypeAlias := node.(*ast.NodeTypeAlias)
+			if isTypeAlias && sameAttrs(typeAlias.Type, structObj.Type) {
+				return typeAlias.Ident
+			}
+		}
+	}
+
+	return nil
+}
+
+// The closest object with the name declared or used before the position,
+// the text after the last check might not be valid so the scopes are not known
+func (r *checkResult) objectBefore(file *analysis.SourceFile, name string, line, col int) runtime.GDObject {
+	var obj runtime.GDObject
+	var objPos scanner.Position
+	ast.Inspect(file.Root, func(node ast.Node) bool {
+		var ident *ast.NodeIdent
+		switch node := node.(type) {
+		case *ast.NodeIdent:
+			ident = node
+		case *ast.NodeSet:
+			ident = node.IdentWithType.Ident
+		}
+
+		if ident == nil || ident.Lit != name || node.InferredObject() == nil {
+			return true
+		}
+
+		pos := ident.GetPosition()
+		isBefore := pos.Line < line || (pos.Line == line && pos.ColEnd < col)
+		isCloser := pos.Line > objPos.Line || (pos.Line == objPos.Line && pos.ColStart > objPos.ColStart)
+		if isBefore && isCloser {
+			obj, objPos = node.InferredObject(), pos
+		}
+
+		return true
+	})
+
+	if obj != nil {
+		return obj
+	}
+
+	switch ref := r.analyzer.GetNodeReference(runtime.NewGDStringIdent(name), file).(type) {
+	case *analysis.NodeWithSourceFile:
+		return ref.Node.InferredObject()
+	case *runtime.GDSymbol:
+		return ref.Object
+	}
+
+	return nil
+}
+
+// Ident of the object declared by the node with the name
+func declaredIdent(node ast.Node, name string) *ast.NodeIdent {
+	switch node := node.(type) {
+	case *ast.NodeSets:
+		var decl *ast.NodeIdent
+		for _, set := range node.Nodes {
+			if setDecl := declaredIdent(set, name); setDecl != nil {
+				decl = setDecl
+			}
+		}
+
+		return decl
+	case *ast.NodeSet:
+		if node.IdentWithType.Ident.Lit == name {
+			return node.IdentWithType.Ident
+		}
+	}
+
+	return nil
+}
+
+// Ident of a member of a source file
+func declIdentOf(node ast.Node) ast.Node {
+	switch node := node.(type) {
+	case *ast.NodeSet:
+		return node.IdentWithType.Ident
+	case *ast.NodeFunc:
+		return node.Ident
+	case *ast.NodeTypeAlias:
+		return node.Ident
+	}
+
+	return node
+}
+
+// Struct types with the same attribute names
+func sameAttrs(typ runtime.GDTypable, structType runtime.GDStructType) bool {
+	aliasType, isStruct := typ.(runtime.GDStructType)
+	if !isStruct || len(aliasType) != len(structType) {
+		return false
+	}
+
+	for _, attr := range structType {
+		if _, err := aliasType.GetAttr(attr.Ident); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Errors of the check by source path, the syntax errors of a file are joined
+func errorsByPath(err error) map[string][]comn.Error {
+	errs := make(map[string][]comn.Error)
+
+	var collect func(err error)
+	collect = func(err error) {
+		if joined, isJoined := err.(interface{ Unwrap() []error }); isJoined {
+			for _, err := range joined.Unwrap() {
+				collect(err)
+			}
+
+			return
+		}
+
+		var checkErr comn.Error
+		if !errors.As(err, &checkErr) {
+			checkErr = comn.AnalysisErr(err.Error(), scanner.ZeroPos)
+		}
+
+		errs[checkErr.Position.Filename] = append(errs[checkErr.Position.Filename], checkErr)
+	}
+
+	if err != nil {
+		collect(err)
+	}
+
+	return errs
+}
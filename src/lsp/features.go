@@ -0,0 +1,348 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lsp
+
+import (
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/analysis"
+	"gdlang/src/gd/ast"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// The objects imported by a `use` directive, e.g. `use a.b {x, `
+	useImportsRegexp = regexp.MustCompile(`^\s*use\s+([A-Za-z_][\w.]*)\s*\{([^}]*)$`)
+	// The attributes of an object, e.g. `user.address.`
+	attrAccessRegexp = regexp.MustCompile(`(?:^|[^\w.])([A-Za-z_]\w*(?:\.[A-Za-z_]\w*)*)\.\w*$`)
+)
+
+// Source file of a document and the path of nodes to the ident or literal at the position
+func (s *GDLSPServer) nodePathAt(params TextDocumentPositionParams) (*analysis.SourceFile, []ast.Node) {
+	path := pathOf(params.TextDocument.URI)
+	file, isChecked := s.result.fileOf(path)
+	if !isChecked {
+		return nil, nil
+	}
+
+	line, col := s.scannerPos(path, params.Position)
+
+	var nodes, found []ast.Node
+	ast.Inspect(file.Root, func(node ast.Node) bool {
+		if node == nil {
+			nodes = nodes[:len(nodes)-1]
+			return false
+		}
+
+		nodes = append(nodes, node)
+		switch node.(type) {
+		case *ast.NodeIdent, *ast.NodeLiteral:
+			pos := node.GetPosition()
+			if pos.Line == line && pos.ColStart <= col && col <= pos.ColEnd {
+				found = slices.Clone(nodes)
+			}
+		}
+
+		return true
+	})
+
+	return file, found
+}
+
+func (s *GDLSPServer) hover(params TextDocumentPositionParams) *Hover {
+	_, nodes := s.nodePathAt(params)
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	var prefix string
+	var typed ast.Node = nodes[len(nodes)-1]
+	if ident, isIdent := typed.(*ast.NodeIdent); isIdent {
+		prefix = ident.Lit + ": "
+
+		// Declarations are typed by their parent nodes
+		switch parent := nodes[len(nodes)-2].(type) {
+		case *ast.NodeIdentWithType:
+			typed = nodes[len(nodes)-3]
+		case *ast.NodeFunc:
+			if parent.IsOperator {
+				prefix = runtime.ExprOperationMap[parent.Op] + ": "
+			}
+
+			typed = parent
+		case *ast.NodeTypeAlias:
+			typed = parent
+		case *ast.NodeSafeDotExpr:
+			if parent.Ident == typed {
+				typed = parent
+			}
+		case *ast.NodeStructAttr:
+			if parent.Ident == typed {
+				typed = parent.Expr
+			}
+		case *ast.NodePackage:
+			if !slices.Contains(parent.Imports, typed) {
+				return nil
+			}
+
+			switch pkg := s.result.analyzer.GetPackage(parent.InferredPath).(type) {
+			case *analysis.SourcePackage:
+				typed = s.result.packageMember(pkg, ident.Lit)
+			case *analysis.BuiltInPackage:
+				symbol, err := pkg.GetMember(runtime.NewGDStringIdent(ident.Lit))
+				if err != nil {
+					return nil
+				}
+
+				return s.hoverOf(nodes, prefix, symbol.Type)
+			}
+		}
+
+		if typeAlias, isTypeAlias := typed.(*ast.NodeTypeAlias); isTypeAlias {
+			return s.hoverOf(nodes, "typealias "+ident.Lit+" = ", typeAlias.Type)
+		}
+	}
+
+	if typed == nil {
+		return nil
+	}
+
+	return s.hoverOf(nodes, prefix, typeOf(typed))
+}
+
+func (s *GDLSPServer) hoverOf(nodes []ast.Node, prefix string, typ runtime.GDTypable) *Hover {
+	if typ == nil {
+		return nil
+	}
+
+	return &Hover{
+		Contents: MarkupContent{"markdown", "```gdlang\n" + prefix + typ.ToString() + "\n```"},
+		Range:    s.rangeOf(nodes[len(nodes)-1].GetPosition()),
+	}
+}
+
+func (s *GDLSPServer) definition(params TextDocumentPositionParams) *Location {
+	file, nodes := s.nodePathAt(params)
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	decl := s.result.definitionOf(file, nodes)
+	if decl == nil {
+		return nil
+	}
+
+	pos := decl.GetPosition()
+	return &Location{uriOf(pos.Filename), s.rangeOf(pos)}
+}
+
+func (s *GDLSPServer) completion(params TextDocumentPositionParams) []CompletionItem {
+	path := pathOf(params.TextDocument.URI)
+	file, isChecked := s.result.fileOf(path)
+	if !isChecked {
+		return nil
+	}
+
+	line, col := s.scannerPos(path, params.Position)
+	text := s.lineText(path, line)[:col-1]
+
+	if match := useImportsRegexp.FindStringSubmatch(text); match != nil {
+		pkg := s.result.analyzer.GetPackage(strings.ReplaceAll(match[1], ".", "/"))
+		imported := make(map[string]bool)
+		for _, ident := range strings.Split(match[2], ",") {
+			imported[strings.TrimSpace(ident)] = true
+		}
+
+		return packageItems(pkg, imported)
+	}
+
+	match := attrAccessRegexp.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+
+	idents := strings.Split(match[1], ".")
+	if alias, isAlias := s.result.analyzer.GetNodeReference(runtime.NewGDStringIdent(idents[0]), file).(*analysis.PackageAlias); isAlias {
+		if len(idents) > 1 {
+			return nil
+		}
+
+		return packageItems(alias.Package, nil)
+	}
+
+	obj := s.result.objectBefore(file, idents[0], line, col)
+	for _, ident := range idents[1:] {
+		attributable, isAttributable := runtime.Unwrap(obj).(runtime.GDAttributable)
+		if !isAttributable {
+			return nil
+		}
+
+		symbol, err := attributable.GetAttr(runtime.NewGDStringIdent(ident))
+		if err != nil {
+			return nil
+		}
+
+		obj = symbol.Object
+	}
+
+	structObj, isStruct := runtime.Unwrap(obj).(*runtime.GDStruct)
+	if !isStruct {
+		return nil
+	}
+
+	var items []CompletionItem
+	for _, attr := range structObj.Type {
+		if attr.IsAccessibleFrom(filepath.Dir(path)) {
+			items = append(items, CompletionItem{attr.Ident.ToString(), completionKindField, attr.Type.ToString()})
+		}
+	}
+
+	return items
+}
+
+func (s *GDLSPServer) documentSymbols(params DocumentSymbolParams) []DocumentSymbol {
+	file, isChecked := s.result.fileOf(pathOf(params.TextDocument.URI))
+	if !isChecked {
+		return nil
+	}
+
+	symbol := func(name string, kind SymbolKind, typ runtime.GDTypable, ident *ast.NodeIdent) DocumentSymbol {
+		var detail string
+		if typ != nil {
+			detail = typ.ToString()
+		}
+
+		rng := s.rangeOf(ident.GetPosition())
+		return DocumentSymbol{Name: name, Detail: detail, Kind: kind, Range: rng, SelectionRange: rng}
+	}
+
+	symbols := make([]DocumentSymbol, 0)
+	for _, node := range file.Root.Nodes {
+		switch node := node.(type) {
+		case *ast.NodeFunc:
+			if node.IsOperator {
+				symbols = append(symbols, symbol(runtime.ExprOperationMap[node.Op], symbolKindOperator, node.Type, node.Ident))
+			} else {
+				symbols = append(symbols, symbol(node.Ident.Lit, symbolKindFunction, node.Type, node.Ident))
+			}
+		case *ast.NodeTypeAlias:
+			structType, isStruct := node.Type.(runtime.GDStructType)
+			if !isStruct {
+				symbols = append(symbols, symbol(node.Ident.Lit, symbolKindTypeParameter, node.Type, node.Ident))
+				continue
+			}
+
+			// The attributes have no position, they are placed at the type alias
+			typeSymbol := symbol(node.Ident.Lit, symbolKindStruct, nil, node.Ident)
+			for _, attr := range structType {
+				typeSymbol.Children = append(typeSymbol.Children, symbol(attr.Ident.ToString(), symbolKindField, attr.Type, node.Ident))
+			}
+
+			symbols = append(symbols, typeSymbol)
+		case *ast.NodeSets:
+			for _, set := range node.Nodes {
+				set := set.(*ast.NodeSet)
+
+				kind := symbolKindVariable
+				if set.IsConst {
+					kind = symbolKindConstant
+				}
+
+				symbols = append(symbols, symbol(set.IdentWithType.Ident.Lit, kind, typeOf(set), set.IdentWithType.Ident))
+			}
+		}
+	}
+
+	return symbols
+}
+
+// Type inferred by the static check for the node
+func typeOf(node ast.Node) runtime.GDTypable {
+	if typ := node.InferredType(); typ != nil {
+		return typ
+	}
+
+	if obj := node.InferredObject(); obj != nil {
+		return obj.GetType()
+	}
+
+	return nil
+}
+
+// Public objects of a package that are not imported yet
+func packageItems(pkg analysis.Package, imported map[string]bool) []CompletionItem {
+	var items []CompletionItem
+	switch pkg := pkg.(type) {
+	case *analysis.SourcePackage:
+		for _, ident := range pkg.PublicMembers() {
+			if imported[ident.ToString()] {
+				continue
+			}
+
+			member, _ := pkg.GetMember(ident)
+
+			kind := completionKindVariable
+			typ := typeOf(member.Node)
+			switch node := member.Node.(type) {
+			case *ast.NodeFunc:
+				kind = completionKindFunction
+			case *ast.NodeTypeAlias:
+				kind = completionKindTypeParameter
+				typ = node.Type
+			case *ast.NodeSet:
+				if node.IsConst {
+					kind = completionKindConstant
+				}
+			}
+
+			items = append(items, completionItem(ident.ToString(), kind, typ))
+		}
+	case *analysis.BuiltInPackage:
+		for _, ident := range pkg.PublicMembers() {
+			if imported[ident.ToString()] {
+				continue
+			}
+
+			symbol, _ := pkg.GetMember(ident)
+
+			kind := completionKindVariable
+			if _, isLambda := symbol.Type.(*runtime.GDLambdaType); isLambda {
+				kind = completionKindFunction
+			} else if symbol.IsConst {
+				kind = completionKindConstant
+			}
+
+			items = append(items, completionItem(ident.ToString(), kind, symbol.Type))
+		}
+	}
+
+	return items
+}
+
+func completionItem(label string, kind CompletionItemKind, typ runtime.GDTypable) CompletionItem {
+	var detail string
+	if typ != nil {
+		detail = typ.ToString()
+	}
+
+	return CompletionItem{label, kind, detail}
+}
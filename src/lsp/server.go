@@ -0,0 +1,367 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gdlang/src/comn"
+	"gdlang/src/gd/scanner"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Language server of a client, the requests are handled one at a time
+// and the workspace is checked again every time a document changes
+type GDLSPServer struct {
+	in  *bufio.Reader
+	out io.Writer
+	// Directory of the main package, by default the directory of the first document
+	root string
+	// Text of the open documents by path
+	documents map[string]string
+	// Last check of the workspace where the packages could be built
+	result *checkResult
+	// Paths with diagnostics, they are cleared when the errors are fixed
+	diagnosed map[string]bool
+}
+
+// Handles the messages until the client exits
+func (s *GDLSPServer) Serve() error {
+	for {
+		content, err := ReadMessage(s.in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		var msg Message
+		err = json.Unmarshal(content, &msg)
+		if err != nil {
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+
+		result, err := s.handle(&msg)
+		// Notifications have no response
+		if msg.ID == nil {
+			continue
+		}
+
+		s.respond(msg.ID, result, err)
+	}
+}
+
+func (s *GDLSPServer) handle(msg *Message) (any, error) {
+	switch msg.Method {
+	case "initialize":
+		var params InitializeParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		if params.RootURI != "" {
+			s.root = pathOf(params.RootURI)
+		}
+
+		return InitializeResult{
+			Capabilities: ServerCapabilities{
+				TextDocumentSync:       TextDocumentSyncOptions{OpenClose: true, Change: textDocumentSyncFull},
+				HoverProvider:          true,
+				DefinitionProvider:     true,
+				CompletionProvider:     CompletionOptions{TriggerCharacters: []string{".", "{", ","}},
+				DocumentSymbolProvider: true,
+			},
+			ServerInfo: ServerInfo{"gdlsp"},
+		}, nil
+	case "initialized", "shutdown":
+		return nil, nil
+	case "textDocument/didOpen":
+		var params DidOpenTextDocumentParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		path := pathOf(params.TextDocument.URI)
+		if s.root == "" {
+			s.root = filepath.Dir(path)
+		}
+
+		s.documents[path] = params.TextDocument.Text
+		s.check()
+
+		return nil, nil
+	case "textDocument/didChange":
+		var params DidChangeTextDocumentParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		// The full content of the document is sent, so only the last change matters
+		if len(params.ContentChanges) > 0 {
+			s.documents[pathOf(params.TextDocument.URI)] = params.ContentChanges[len(params.ContentChanges)-1].Text
+			s.check()
+		}
+
+		return nil, nil
+	case "textDocument/didClose":
+		var params DidCloseTextDocumentParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		delete(s.documents, pathOf(params.TextDocument.URI))
+		s.check()
+
+		return nil, nil
+	case "textDocument/didSave":
+		// Other files of the workspace might have changed on disk
+		s.check()
+		return nil, nil
+	case "textDocument/hover":
+		var params TextDocumentPositionParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.hover(params), nil
+	case "textDocument/definition":
+		var params TextDocumentPositionParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.definition(params), nil
+	case "textDocument/completion":
+		var params TextDocumentPositionParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.completion(params), nil
+	case "textDocument/documentSymbol":
+		var params DocumentSymbolParams
+		err := s.decode(msg, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.documentSymbols(params), nil
+	}
+
+	return nil, &ResponseError{methodNotFoundErrCode, fmt.Sprintf("unsupported method `%s`", msg.Method)}
+}
+
+func (s *GDLSPServer) decode(msg *Message, params any) error {
+	if len(msg.Params) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(msg.Params, params)
+}
+
+// Checks the workspace and publishes the diagnostics of the source files
+func (s *GDLSPServer) check() {
+	if s.root == "" {
+		return
+	}
+
+	overlay := make(map[string][]byte, len(s.documents))
+	for path, text := range s.documents {
+		overlay[path] = []byte(text)
+	}
+
+	result, err := check(s.root, overlay)
+	if result != nil {
+		s.result = result
+	}
+
+	errs := errorsByPath(err)
+	// Errors without a source file are shown in all the open documents
+	if unknownErrs, hasUnknown := errs[""]; hasUnknown {
+		delete(errs, "")
+		for path := range s.documents {
+			errs[path] = append(errs[path], unknownErrs...)
+		}
+	}
+
+	// The diagnostics of the fixed files are cleared
+	for path := range s.diagnosed {
+		if _, hasErrs := errs[path]; !hasErrs {
+			errs[path] = nil
+		}
+	}
+
+	paths := make([]string, 0, len(errs))
+	for path := range errs {
+		paths = append(paths, path)
+	}
+
+	slices.Sort(paths)
+
+	s.diagnosed = make(map[string]bool)
+	for _, path := range paths {
+		diagnostics := make([]Diagnostic, len(errs[path]))
+		for i, err := range errs[path] {
+			severity := diagnosticSeverityError
+			if err.Severity == comn.Warning {
+				severity = diagnosticSeverityWarning
+			}
+
+			diagnostics[i] = Diagnostic{s.rangeOf(err.Position), severity, "gdlsp", err.Msg}
+		}
+
+		if len(diagnostics) > 0 {
+			s.diagnosed[path] = true
+		}
+
+		s.notify("textDocument/publishDiagnostics", PublishDiagnosticsParams{uriOf(path), diagnostics})
+	}
+}
+
+func (s *GDLSPServer) respond(id *json.RawMessage, result any, err error) {
+	resp := Response{JSONRPC: jsonRPCVersion, ID: id, Result: result}
+	if err != nil {
+		var respErr *ResponseError
+		if !errors.As(err, &respErr) {
+			respErr = &ResponseError{requestFailedErrCode, err.Error()}
+		}
+
+		resp.Result = nil
+		resp.Error = respErr
+	} else if result == nil {
+		resp.Result = json.RawMessage("null")
+	}
+
+	_ = WriteMessage(s.out, resp)
+}
+
+func (s *GDLSPServer) notify(method string, params any) {
+	_ = WriteMessage(s.out, Notification{jsonRPCVersion, method, params})
+}
+
+// Text of a line of a source file, from the open document or from the disk
+func (s *GDLSPServer) lineText(path string, line int) string {
+	text, isOpen := s.documents[path]
+	if !isOpen {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return ""
+		}
+
+		text = string(content)
+	}
+
+	lines := strings.Split(text, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	return strings.TrimSuffix(lines[line-1], "\r")
+}
+
+// Range of a position of the scanner, where columns are counted in bytes
+// starting at 1 and the end column is the last byte of the node
+func (s *GDLSPServer) rangeOf(pos scanner.Position) Range {
+	if !pos.IsValid() {
+		return Range{}
+	}
+
+	line := s.lineText(pos.Filename, pos.Line)
+	return Range{
+		Start: Position{pos.Line - 1, utf16Len(line, pos.ColStart-1)},
+		End:   Position{pos.Line - 1, utf16Len(line, pos.ColEnd)},
+	}
+}
+
+// Line and column of the scanner at a position of the client
+func (s *GDLSPServer) scannerPos(path string, pos Position) (int, int) {
+	line := s.lineText(path, pos.Line+1)
+
+	units := 0
+	for offset, r := range line {
+		if units >= pos.Character {
+			return pos.Line + 1, offset + 1
+		}
+
+		units += utf16.RuneLen(r)
+	}
+
+	return pos.Line + 1, len(line) + 1
+}
+
+// Number of UTF-16 code units of the first bytes of the line
+func utf16Len(line string, bytes int) int {
+	bytes = max(0, min(bytes, len(line)))
+
+	units := 0
+	for _, r := range line[:bytes] {
+		if r == utf8.RuneError {
+			units++
+			continue
+		}
+
+		units += utf16.RuneLen(r)
+	}
+
+	return units
+}
+
+func pathOf(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+
+	return filepath.Clean(filepath.FromSlash(u.Path))
+}
+
+func uriOf(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// Creates a server of a client, the root of the workspace is set by the initialize request
+func NewGDLSPServer(in io.Reader, out io.Writer) *GDLSPServer {
+	return &GDLSPServer{
+		in:        bufio.NewReader(in),
+		out:       out,
+		documents: make(map[string]string),
+		diagnosed: make(map[string]bool),
+	}
+}
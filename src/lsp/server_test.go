@@ -0,0 +1,409 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package lsp_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"gdlang/src/lsp"
+	"gdlang/src/test_helper"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const mainSrc = `use geometry {Point, origin}
+use geometry as geo
+
+typealias User = {pub name: string, age: int}
+
+set admin: User = {name: "root", age: 30}
+
+func greet(u: User) => string {
+	set msg = "hello " + u.name
+	return msg
+}
+
+pub func main() {
+	set p: Point = origin()
+	print(greet(admin), p.x, geo.origin())
+}`
+
+const pointSrc = `pub typealias Point = {pub x: int, pub y: int}
+
+pub func origin() => Point {
+	return {x: 0, y: 0}
+}
+
+pub set unit = 1
+`
+
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *lsp.ResponseError
+}
+
+// Scripted client of a server, the messages are read in their
+// own goroutine so the notifications never block the server
+type testClient struct {
+	t             *testing.T
+	out           io.Writer
+	messages      chan message
+	notifications []message
+	id            int
+	root          string
+}
+
+func (c *testClient) uri(name string) string {
+	return "file://" + filepath.Join(c.root, name)
+}
+
+// Sends a request and waits for its response, the notifications
+// received in the meantime are kept for later
+func (c *testClient) request(method string, params any, result any) message {
+	c.id++
+	err := lsp.WriteMessage(c.out, map[string]any{"jsonrpc": "2.0", "id": c.id, "method": method, "params": params})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	for msg := range c.messages {
+		if msg.ID == nil {
+			c.notifications = append(c.notifications, msg)
+			continue
+		}
+
+		if *msg.ID != c.id {
+			c.t.Fatalf("Expected the response of %s, got %+v", method, msg)
+		}
+
+		if result != nil && msg.Error == nil {
+			err = json.Unmarshal(msg.Result, result)
+			if err != nil {
+				c.t.Fatal(err)
+			}
+		}
+
+		return msg
+	}
+
+	c.t.Fatalf("Expected the response of %s, the server was closed", method)
+	return message{}
+}
+
+func (c *testClient) notify(method string, params any) {
+	err := lsp.WriteMessage(c.out, map[string]any{"jsonrpc": "2.0", "method": method, "params": params})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *testClient) open(name, text string) {
+	c.notify("textDocument/didOpen", map[string]any{"textDocument": map[string]any{"uri": c.uri(name), "languageId": "gdlang", "version": 1, "text": text}})
+}
+
+func (c *testClient) change(name, text string) {
+	c.notify("textDocument/didChange", map[string]any{"textDocument": map[string]any{"uri": c.uri(name), "version": 2}, "contentChanges": []any{map[string]any{"text": text}}})
+}
+
+// Waits for the next diagnostics published for the document
+func (c *testClient) diagnostics(name string) []lsp.Diagnostic {
+	var params lsp.PublishDiagnosticsParams
+	isDiagnostics := func(msg message) bool {
+		if msg.Method != "textDocument/publishDiagnostics" {
+			return false
+		}
+
+		err := json.Unmarshal(msg.Params, &params)
+		return err == nil && params.URI == c.uri(name)
+	}
+
+	for i, msg := range c.notifications {
+		if isDiagnostics(msg) {
+			c.notifications = slices.Delete(c.notifications, i, i+1)
+			return params.Diagnostics
+		}
+	}
+
+	for msg := range c.messages {
+		if isDiagnostics(msg) {
+			return params.Diagnostics
+		}
+
+		c.notifications = append(c.notifications, msg)
+	}
+
+	c.t.Fatalf("Expected the diagnostics of %s, the server was closed", name)
+	return nil
+}
+
+// Parameters of a position in a document, at an offset of the first occurrence of the text
+func (c *testClient) at(name, src, text string, offset int) lsp.TextDocumentPositionParams {
+	index := strings.Index(src, text)
+	if index < 0 {
+		c.t.Fatalf("Text %q was not found in the source", text)
+	}
+
+	before := src[:index+offset]
+	line := strings.Count(before, "\n")
+	character := len(before) - strings.LastIndex(before, "\n") - 1
+
+	return lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: c.uri(name)},
+		Position:     lsp.Position{Line: line, Character: character},
+	}
+}
+
+// Serves the workspace of the main source and the geometry package to the client
+func runServer(t *testing.T, script func(c *testClient)) {
+	tree := test_helper.NRDir(
+		test_helper.NMFile(mainSrc),
+		test_helper.NDir("geometry", test_helper.NFile("point.gd", pointSrc)),
+	)
+
+	test_helper.BuildPackageTree(tree, func(tmpDir string) error {
+		clientIn, serverOut := io.Pipe()
+		serverIn, clientOut := io.Pipe()
+
+		server := lsp.NewGDLSPServer(serverIn, serverOut)
+		served := make(chan error)
+		go func() { served <- server.Serve() }()
+
+		messages := make(chan message, 64)
+		go func() {
+			defer close(messages)
+
+			in := bufio.NewReader(clientIn)
+			for {
+				content, err := lsp.ReadMessage(in)
+				if err != nil {
+					return
+				}
+
+				var msg message
+				err = json.Unmarshal(content, &msg)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				messages <- msg
+			}
+		}()
+
+		c := &testClient{t: t, out: clientOut, messages: messages, root: tmpDir}
+		c.request("initialize", map[string]any{"rootUri": "file://" + tmpDir}, nil)
+		c.notify("initialized", map[string]any{})
+
+		script(c)
+
+		c.request("shutdown", nil, nil)
+		c.notify("exit", nil)
+		if err := <-served; err != nil {
+			t.Error(err)
+		}
+
+		_ = serverOut.Close()
+		for range messages {
+		}
+
+		return nil
+	})
+}
+
+func TestServerDiagnostics(t *testing.T) {
+	runServer(t, func(c *testClient) {
+		c.open("main.gd", mainSrc)
+		c.request("textDocument/hover", c.at("main.gd", mainSrc, "admin", 0), nil)
+		if len(c.notifications) != 0 {
+			t.Fatalf("Expected no diagnostics for a valid source, got %+v", c.notifications)
+		}
+
+		// Diagnostics are published on change, before the document is saved
+		invalidSrc := strings.Replace(mainSrc, `"hello " + u.name`, `"hello " + u.id`, 1)
+		c.change("main.gd", invalidSrc)
+		diagnostics := c.diagnostics("main.gd")
+		if len(diagnostics) != 1 || !strings.Contains(diagnostics[0].Message, "`id`") || diagnostics[0].Range.Start.Line != 8 {
+			t.Fatalf("Expected an error for the unknown attribute, got %+v", diagnostics)
+		}
+
+		c.change("main.gd", mainSrc+"\nset = 1")
+		diagnostics = c.diagnostics("main.gd")
+		if len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 16 {
+			t.Fatalf("Expected a syntax error, got %+v", diagnostics)
+		}
+
+		// The diagnostics are cleared when the errors are fixed
+		c.change("main.gd", mainSrc)
+		diagnostics = c.diagnostics("main.gd")
+		if len(diagnostics) != 0 {
+			t.Fatalf("Expected the diagnostics to be cleared, got %+v", diagnostics)
+		}
+	})
+}
+
+func TestServerHover(t *testing.T) {
+	tests := []struct {
+		text     string
+		offset   int
+		expected string
+	}{
+		{"admin:", 0, "admin: User"},
+		{"u.name", 2, "name: string"},
+		{"msg =", 0, "msg: string"},
+		{"greet(u", 0, "greet: (u: User) => string"},
+		{"User =", 0, "typealias User = {pub name: string, age: int}"},
+		{"origin()\n", 0, "origin: () => Point"},
+		{"geo.origin", 4, "origin: () => Point"},
+		{"{Point", 1, "typealias Point = {pub x: int, pub y: int}"},
+		{"30", 0, "int"},
+	}
+
+	runServer(t, func(c *testClient) {
+		c.open("main.gd", mainSrc)
+		for _, test := range tests {
+			var hover *lsp.Hover
+			c.request("textDocument/hover", c.at("main.gd", mainSrc, test.text, test.offset), &hover)
+
+			expected := "```gdlang\n" + test.expected + "\n```"
+			if hover == nil || hover.Contents.Value != expected {
+				t.Errorf("Expected the hover of %q to be %q, got %+v", test.text, expected, hover)
+			}
+		}
+
+		var hover *lsp.Hover
+		c.request("textDocument/hover", c.at("main.gd", mainSrc, "use", 0), &hover)
+		if hover != nil {
+			t.Errorf("Expected no hover for a keyword, got %+v", hover)
+		}
+	})
+}
+
+func TestServerDefinition(t *testing.T) {
+	tests := []struct {
+		text     string
+		offset   int
+		file     string
+		expected lsp.Position
+	}{
+		// Local objects, arguments and globals
+		{"return msg", 7, "main.gd", lsp.Position{Line: 8, Character: 5}},
+		{"u.name", 0, "main.gd", lsp.Position{Line: 7, Character: 5}},
+		{"greet(admin", 6, "main.gd", lsp.Position{Line: 5, Character: 4}},
+		// The attributes of a struct are declared by its type alias
+		{"u.name", 2, "main.gd", lsp.Position{Line: 3, Character: 10}},
+		// Objects of other packages
+		{"origin()\n", 0, "geometry/point.gd", lsp.Position{Line: 2, Character: 9}},
+		{"geo.origin", 4, "geometry/point.gd", lsp.Position{Line: 2, Character: 9}},
+		{"{Point", 1, "geometry/point.gd", lsp.Position{Line: 0, Character: 14}},
+		{"geo.origin", 0, "main.gd", lsp.Position{Line: 1, Character: 16}},
+	}
+
+	runServer(t, func(c *testClient) {
+		c.open("main.gd", mainSrc)
+		for _, test := range tests {
+			var location *lsp.Location
+			c.request("textDocument/definition", c.at("main.gd", mainSrc, test.text, test.offset), &location)
+			if location == nil || location.URI != c.uri(test.file) || location.Range.Start != test.expected {
+				t.Errorf("Expected the definition of %q at %s %+v, got %+v", test.text, test.file, test.expected, location)
+			}
+		}
+	})
+}
+
+func TestServerCompletion(t *testing.T) {
+	labels := func(items []lsp.CompletionItem) []string {
+		var labels []string
+		for _, item := range items {
+			labels = append(labels, item.Label)
+		}
+
+		return labels
+	}
+
+	runServer(t, func(c *testClient) {
+		c.open("main.gd", mainSrc)
+
+		// The text being typed is completed from the last valid check
+		src := strings.Replace(mainSrc, "use geometry {Point, origin}", "use geometry {Point, ", 1)
+		src = strings.Replace(src, "return msg", "return u.", 1)
+		src = strings.Replace(src, "print(greet(admin), p.x, geo.origin())", "print(p.\n\tgeo.", 1)
+		c.change("main.gd", src)
+
+		tests := []struct {
+			text     string
+			offset   int
+			expected []string
+		}{
+			{"{Point, ", 8, []string{"origin", "unit"}},
+			{"u.\n", 2, []string{"age", "name"}},
+			{"p.\n", 2, []string{"x", "y"}},
+			{"geo.", 4, []string{"Point", "origin", "unit"}},
+		}
+
+		for _, test := range tests {
+			var items []lsp.CompletionItem
+			c.request("textDocument/completion", c.at("main.gd", src, test.text, test.offset), &items)
+
+			actual := labels(items)
+			slices.Sort(actual)
+			if !slices.Equal(actual, test.expected) {
+				t.Errorf("Expected the completion of %q to be %v, got %v", test.text, test.expected, actual)
+			}
+		}
+	})
+}
+
+func TestServerDocumentSymbols(t *testing.T) {
+	runServer(t, func(c *testClient) {
+		c.open("main.gd", mainSrc)
+
+		var symbols []lsp.DocumentSymbol
+		c.request("textDocument/documentSymbol", map[string]any{"textDocument": map[string]any{"uri": c.uri("main.gd")}}, &symbols)
+
+		var actual []string
+		for _, symbol := range symbols {
+			actual = append(actual, symbol.Name+" "+symbol.Detail)
+		}
+
+		expected := []string{"User ", "admin User", "greet (u: User) => string", "main () => nil"}
+		if !slices.Equal(actual, expected) {
+			t.Fatalf("Expected the symbols %v, got %v", expected, actual)
+		}
+
+		if len(symbols[0].Children) != 2 || symbols[0].Children[1].Name != "age" {
+			t.Errorf("Expected the attributes of the struct as children, got %+v", symbols[0].Children)
+		}
+	})
+}
+
+func TestServerUnsupportedMethod(t *testing.T) {
+	runServer(t, func(c *testClient) {
+		resp := c.request("textDocument/rename", map[string]any{}, nil)
+		if resp.Error == nil || resp.Error.Message != "unsupported method `textDocument/rename`" {
+			t.Errorf("Expected an unsupported method error, got %+v", resp)
+		}
+	})
+}
@@ -0,0 +1,237 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package repl
+
+import (
+	"errors"
+	"fmt"
+	"gdlang/lib/builtin"
+	"gdlang/lib/runtime"
+	"gdlang/src/comn"
+	"gdlang/src/gd/analysis"
+	"gdlang/src/gd/ast"
+	"gdlang/src/gd/scanner"
+	"strings"
+)
+
+const (
+	entryFilename = "repl"
+	// Statements are only allowed inside functions, so the entries are parsed
+	// inside a function and the nodes of its block are evaluated at the top level
+	stmtsPrefix = "func main() {\n"
+	stmtsSuffix = "\n}"
+	// Expressions that are not statements, e.g. `1 + 2`, are parsed as a returned value
+	exprPrefix = "func main() {\nreturn ("
+	exprSuffix = ")\n}"
+)
+
+const returnOutsideFuncErrMsg = "`return` statement is not allowed here, it can only be used inside a function"
+
+// An entry of the REPL parsed into the nodes to evaluate
+type entry struct {
+	nodes []ast.Node
+	// Expression whose result is printed, nil for the entries of statements
+	expr ast.Node
+	// Source added before the entry to parse it
+	prefix string
+}
+
+// Positions of the errors are moved back to the source of the entry
+func (e *entry) err(err error) error {
+	var entryErr comn.Error
+	if e.prefix == "" || !errors.As(err, &entryErr) || !entryErr.Position.IsValid() {
+		return err
+	}
+
+	entryErr.Position.Line -= strings.Count(e.prefix, "\n")
+	if entryErr.Position.Line == 1 {
+		cols := len(e.prefix) - strings.LastIndex(e.prefix, "\n") - 1
+		entryErr.Position.ColStart = max(entryErr.Position.ColStart-cols, 1)
+		entryErr.Position.ColEnd = max(entryErr.Position.ColEnd-cols, 1)
+	}
+
+	return entryErr
+}
+
+// Parses the entry, which can be `use` directives followed by declarations,
+// statements, or an expression
+func parseEntry(src string) (*entry, error) {
+	if strings.HasPrefix(strings.TrimSpace(src), "use ") {
+		root, err := parse(src)
+		if err != nil {
+			return nil, err
+		}
+
+		err = resolvePackages(root.Packages)
+		if err != nil {
+			return nil, err
+		}
+
+		return &entry{nodes: append(root.Packages, root.Nodes...)}, nil
+	}
+
+	stmts := &entry{prefix: stmtsPrefix}
+	nodes, stmtsErr := parseBlock(stmts.prefix + src + stmtsSuffix)
+	if stmtsErr == nil {
+		stmts.nodes = nodes
+		if len(nodes) == 1 && isExpr(nodes[0]) {
+			stmts.expr = nodes[0]
+		}
+
+		return stmts, nil
+	}
+
+	expr := &entry{prefix: exprPrefix}
+	nodes, err := parseBlock(expr.prefix + src + exprSuffix)
+	if err == nil && len(nodes) == 1 {
+		if ret, isReturn := nodes[0].(*ast.NodeReturn); isReturn && ret.Expr != nil {
+			expr.nodes, expr.expr = []ast.Node{ret.Expr}, ret.Expr
+			return expr, nil
+		}
+	}
+
+	// The entry is not an expression, so the error of the statements is reported
+	return nil, stmts.err(stmtsErr)
+}
+
+func parse(src string) (*ast.NodeFile, error) {
+	fileSet := scanner.NewFileSet()
+	file, err := fileSet.AddFile(entryFilename, fileSet.Base(), len(src))
+	if err != nil {
+		return nil, err
+	}
+
+	builder := ast.NAstBuilderProc()
+	defer builder.Dispose()
+
+	err = builder.Init(file, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.Build()
+}
+
+// Nodes of the block of the function that wraps the entry
+func parseBlock(src string) ([]ast.Node, error) {
+	root, err := parse(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(root.Nodes) != 1 {
+		return nil, comn.WrapSyntaxErr(errors.New("expected a statement or an expression"), scanner.ZeroPos)
+	}
+
+	fn, isFunc := root.Nodes[0].(*ast.NodeFunc)
+	if !isFunc {
+		return nil, comn.WrapSyntaxErr(errors.New("expected a statement or an expression"), root.Nodes[0].GetPosition())
+	}
+
+	return fn.Block.Nodes, nil
+}
+
+// Only the builtin packages can be imported, since the entries don't belong to a package folder
+func resolvePackages(packages []ast.Node) error {
+	for _, node := range packages {
+		nodePackage, isNodePackage := node.(*ast.NodePackage)
+		if !isNodePackage {
+			panic("Invalid node type: expected *ast.NodePackage")
+		}
+
+		pkg, found := builtin.Packages[nodePackage.GetPath()]
+		if !found {
+			return analysis.ErrorAt(nodePackage.GetPosition()).PackageNotFound(nodePackage.GetName())
+		}
+
+		// Wildcards and aliases import all the public objects of the package
+		if nodePackage.IsWildcard || nodePackage.Alias != nil {
+			idents := pkg.PublicMembers()
+			nodePackage.Imports = make([]ast.Node, len(idents))
+			for i, ident := range idents {
+				nodePackage.Imports[i] = ast.NewNodeIdent(ast.NewNodeTokenInfo(scanner.IDENT, nodePackage.GetPosition(), ident.ToString()))
+			}
+		}
+
+		for _, node := range nodePackage.Imports {
+			identNode, isIdentNode := node.(*ast.NodeIdent)
+			if !isIdentNode {
+				panic("Invalid node type: expected *ast.NodeIdent")
+			}
+
+			_, err := pkg.GetMember(runtime.NewGDStringIdent(identNode.Lit))
+			if err != nil {
+				return analysis.ErrorAt(identNode.GetPosition()).PackageObjectWasNotFound(identNode.Lit, nodePackage.GetName())
+			}
+		}
+
+		nodePackage.InferredPath = nodePackage.GetPath()
+		nodePackage.InferredMode = runtime.PackageModeBuiltin
+	}
+
+	return nil
+}
+
+// Expressions print their result, the other nodes are statements
+func isExpr(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.NodeSets, *ast.NodeSet, *ast.NodeFunc, *ast.NodeTypeAlias, *ast.NodeUpdateSet,
+		*ast.NodeIfElse, *ast.NodeForIn, *ast.NodeForIf, *ast.NodeReturn, *ast.NodeBreak,
+		*ast.NodeLabel, *ast.NodeBlock:
+		return false
+	}
+
+	return true
+}
+
+// Checks if the brackets of the entry are not closed yet, so more lines are read
+func isIncomplete(src string) bool {
+	fileSet := scanner.NewFileSet()
+	file, err := fileSet.AddFile(entryFilename, fileSet.Base(), len(src))
+	if err != nil {
+		return false
+	}
+
+	var s scanner.Scanner
+	err = s.Init(file, []byte(src), nil, 0)
+	if err != nil {
+		return false
+	}
+	defer s.Dispose()
+
+	depth := 0
+	for {
+		_, _, tok, _ := s.Scan()
+		switch tok {
+		case scanner.EOF:
+			return depth > 0
+		case scanner.LBRACE, scanner.LPAREN, scanner.LBRACK, scanner.LSET_BRACE:
+			depth++
+		case scanner.RBRACE, scanner.RPAREN, scanner.RBRACK, scanner.RSET_BRACE:
+			depth--
+		}
+	}
+}
+
+func recoverErr(err *error) {
+	if r := recover(); r != nil {
+		*err = comn.AnalysisErr(fmt.Sprint(r), scanner.ZeroPos)
+	}
+}
@@ -0,0 +1,372 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package repl
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"gdlang/lib/builtin"
+	"gdlang/lib/runtime"
+	"gdlang/src/comn"
+	"gdlang/src/compiler"
+	"gdlang/src/gd/ast"
+	"gdlang/src/gd/ir"
+	"gdlang/src/gd/staticcheck"
+	"gdlang/src/vm"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const replHelp = `Commands:
+  :type <expr>   prints the type of the expression without evaluating it
+  :asm <code>    prints the bytecode listing of the code without evaluating it
+  :help          prints the commands
+  :quit          exits the REPL
+`
+
+// The result of an expression is set to an object that can't be declared in the source
+var resultIdent = runtime.NewGDStringIdent("$result")
+
+var QuitErr = errors.New("quit")
+
+// Top level of an entry, the instructions are not wrapped into a block,
+// so the objects declared by the entry are kept in the global stack of the VM
+type entryBlock struct {
+	nodes []ir.GDIRNode
+	ir.GDIRBaseNode
+}
+
+func (b *entryBlock) AddNode(node ...ir.GDIRNode) { b.nodes = append(b.nodes, node...) }
+
+func (b *entryBlock) BuildAssembly(padding string) string {
+	return runtime.JoinSlice(b.nodes, func(node ir.GDIRNode, _ int) string {
+		return node.BuildAssembly(padding)
+	}, "\n")
+}
+
+// Read-eval-print loop, each entry is checked and compiled against the objects
+// of the previous entries, and its bytecode is run by a long-lived VM process
+type GDREPL struct {
+	compiler *compiler.GDCompiler
+	checker  *staticcheck.StaticCheck
+	// Objects of the static check declared by the previous entries
+	stack *runtime.GDSymbolStack
+	proc  *vm.GDVMProc
+	// Bytecode of all the entries, since the functions
+	// declared by an entry are called by the later entries
+	code *bytes.Buffer
+	in   *bufio.Scanner
+	out  io.Writer
+}
+
+// Reads and evaluates the entries until the input ends or `:quit` is entered
+func (r *GDREPL) Run() error {
+	for {
+		src, ok := r.read()
+		if !ok {
+			return nil
+		}
+
+		err := r.command(src)
+		if err == QuitErr {
+			return nil
+		} else if err != nil {
+			r.printf("%s\n", err.Error())
+		}
+	}
+}
+
+// Reads an entry, the lines are read until the brackets of the entry are closed
+func (r *GDREPL) read() (string, bool) {
+	r.printf("gd> ")
+
+	var lines []string
+	for r.in.Scan() {
+		lines = append(lines, r.in.Text())
+		src := strings.Join(lines, "\n")
+		if !isIncomplete(src) {
+			return src, true
+		}
+
+		r.printf("... ")
+	}
+
+	r.printf("\n")
+	return "", false
+}
+
+func (r *GDREPL) command(src string) error {
+	src = strings.TrimSpace(src)
+	if !strings.HasPrefix(src, ":") {
+		res, err := r.Eval(src)
+		if err == nil && res != "" {
+			r.printf("%s\n", res)
+		}
+
+		return err
+	}
+
+	cmd, arg := src, ""
+	if i := strings.IndexFunc(src, unicode.IsSpace); i >= 0 {
+		cmd, arg = src[:i], strings.TrimSpace(src[i:])
+	}
+
+	var res string
+	var err error
+	switch cmd {
+	case ":type", ":t":
+		res, err = r.TypeOf(arg)
+	case ":asm", ":a":
+		res, err = r.Assembly(arg)
+	case ":help", ":h":
+		r.printf(replHelp)
+	case ":quit", ":q":
+		return QuitErr
+	default:
+		r.printf("Unknown command `%s`, type `:help` to list the commands\n", cmd)
+	}
+
+	if err == nil && res != "" {
+		r.printf("%s\n", res)
+	}
+
+	return err
+}
+
+// Evaluates an entry, the result of an expression is returned with its type
+func (r *GDREPL) Eval(src string) (res string, err error) {
+	defer recoverErr(&err)
+
+	if strings.TrimSpace(src) == "" {
+		return "", nil
+	}
+
+	e, err := parseEntry(src)
+	if err != nil {
+		return "", err
+	}
+
+	// The objects of the entry are declared when the whole entry is valid
+	stack := r.stack.NewSymbolStack(runtime.GlobalCtx)
+	obj, err := r.check(e, stack)
+	if err != nil {
+		return "", err
+	}
+
+	hasResult := e.expr != nil && obj != nil && obj.GetType() != runtime.GDNilType
+	block, err := r.compile(e, hasResult)
+	if err != nil {
+		return "", err
+	}
+
+	r.declare(stack)
+
+	err = r.run(block)
+	if err != nil || !hasResult {
+		return "", err
+	}
+
+	symbol, err := r.proc.Stack.GetLocalSymbol(resultIdent)
+	if err != nil {
+		return "", err
+	}
+
+	delete(r.proc.Stack.Symbols, resultIdent.GetRawValue())
+
+	return formatResult(symbol.Object), nil
+}
+
+// Type of the expression inferred by the static check, the expression is not evaluated
+func (r *GDREPL) TypeOf(src string) (typ string, err error) {
+	defer recoverErr(&err)
+
+	e, err := parseEntry(src)
+	if err != nil {
+		return "", err
+	}
+
+	if e.expr == nil {
+		return "", errors.New("expected an expression")
+	}
+
+	obj, err := r.check(e, r.stack.NewSymbolStack(runtime.BlockCtx))
+	if err != nil {
+		return "", err
+	}
+
+	if inferredType := e.expr.InferredType(); inferredType != nil {
+		return inferredType.ToString(), nil
+	}
+
+	return obj.GetType().ToString(), nil
+}
+
+// Disassembly listing of the bytecode of the entry, the same one printed by
+// `gdvm -dis` and read by `gdasm`, the entry is not evaluated
+func (r *GDREPL) Assembly(src string) (asm string, err error) {
+	defer recoverErr(&err)
+
+	e, err := parseEntry(src)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = r.check(e, r.stack.NewSymbolStack(runtime.BlockCtx))
+	if err != nil {
+		return "", err
+	}
+
+	block, err := r.compile(e, false)
+	if err != nil {
+		return "", err
+	}
+
+	code := &bytes.Buffer{}
+	ctx := ir.NewGDIRContext()
+	for _, node := range block.nodes {
+		err = node.BuildBytecode(code, ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	listing, err := vm.NewGDVMDisassembler(code.Bytes(), nil).Disassemble()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(listing, "\n"), nil
+}
+
+func (r *GDREPL) Dispose() {
+	r.proc.Dispose()
+	r.stack.Dispose()
+	r.compiler.Dispose()
+}
+
+// Checks the nodes of the entry, the object of the last node is returned
+func (r *GDREPL) check(e *entry, stack *runtime.GDSymbolStack) (runtime.GDObject, error) {
+	var obj runtime.GDObject
+	for _, node := range e.nodes {
+		switch node := node.(type) {
+		case *ast.NodeReturn:
+			return nil, e.err(comn.CompilerErr(returnOutsideFuncErrMsg, node.GetPosition()))
+		case *ast.NodeBreak:
+			return nil, e.err(comn.CompilerErr(comn.MisplacedBreakErrMsg, node.GetPosition()))
+		}
+
+		nodeObj, err := r.checker.EvalNode(node, stack)
+		if err != nil {
+			return nil, e.err(err)
+		}
+
+		obj = nodeObj
+	}
+
+	return obj, nil
+}
+
+// Compiles the nodes of the entry, the result of the expression is set to the result object
+func (r *GDREPL) compile(e *entry, hasResult bool) (*entryBlock, error) {
+	block := &entryBlock{}
+	for _, node := range e.nodes {
+		reg, err := r.compiler.EvalNode(node, block)
+		if err != nil {
+			return nil, e.err(err)
+		}
+
+		if node == e.expr && hasResult {
+			disc := ir.NewGDIRDiscoverable(false, false, resultIdent, node)
+			block.AddNode(ir.NewGDIRSet(disc, runtime.GDAnyType, reg, node))
+		}
+	}
+
+	return block, nil
+}
+
+// Adds the objects of the entry to the global stack, replacing the objects with the same name
+func (r *GDREPL) declare(stack *runtime.GDSymbolStack) {
+	for key, symbol := range stack.Symbols {
+		if _, isDeclared := r.stack.Symbols[key]; isDeclared {
+			// The objects set with their name, e.g. the builtins, are replaced in the VM as well
+			delete(r.proc.Stack.Symbols, key)
+		}
+
+		r.stack.Symbols[key] = symbol
+	}
+}
+
+// Appends the bytecode of the entry to the bytecode of the previous entries and runs it
+func (r *GDREPL) run(block *entryBlock) error {
+	start := r.code.Len()
+	ctx := ir.NewGDIRContext()
+	for _, node := range block.nodes {
+		err := node.BuildBytecode(r.code, ctx)
+		if err != nil {
+			r.code.Truncate(start)
+			return err
+		}
+	}
+
+	r.proc.Buff = r.code.Bytes()
+	r.proc.Off = uint(start)
+
+	return r.proc.Run()
+}
+
+func (r *GDREPL) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(r.out, format, args...)
+}
+
+// Result of an expression with its type, as `typeof` prints it
+func formatResult(obj runtime.GDObject) string {
+	value := obj.ToString()
+	switch obj := obj.(type) {
+	case runtime.GDString:
+		value = strconv.Quote(string(obj))
+	case runtime.GDChar:
+		value = strconv.QuoteRune(rune(obj))
+	}
+
+	return value + ": " + obj.GetType().ToString()
+}
+
+func NewGDREPL(in io.Reader, out io.Writer) (*GDREPL, error) {
+	stack := runtime.NewGDSymbolStack()
+	err := builtin.ImportCoreBuiltins(stack)
+	if err != nil {
+		return nil, err
+	}
+
+	proc := vm.NewGDVMProc()
+	err = proc.Init(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c := compiler.NewGDCompiler()
+	checker := staticcheck.NewStaticCheck(c.PackageDependenciesAnalyzer)
+
+	return &GDREPL{c, checker, stack, proc, &bytes.Buffer{}, bufio.NewScanner(in), out}, nil
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package repl_test
+
+import (
+	"bytes"
+	"gdlang/src/comn"
+	"gdlang/src/gd/asm"
+	"gdlang/src/repl"
+	"gdlang/src/vm"
+	"strings"
+	"testing"
+)
+
+func init() {
+	comn.PrettyPrintErrors = false
+}
+
+func newREPL(t *testing.T, input string) (*repl.GDREPL, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	r, err := repl.NewGDREPL(strings.NewReader(input), out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(r.Dispose)
+
+	return r, out
+}
+
+func TestEval(t *testing.T) {
+	r, _ := newREPL(t, "")
+
+	tests := []struct {
+		src      string
+		expected string
+		errMsg   string
+	}{
+		{`1 + 2`, "3: int", ""},
+		{`set x = 10`, "", ""},
+		{`x * 2`, "20: int", ""},
+		{`"abc".upper()`, `"ABC": string`, ""},
+		{`'c'`, `'c': char`, ""},
+		{`(x, 1.5)`, "(10, 1.5): (int, float)", ""},
+		{"func sq(n: int) => int {\n\treturn n * n\n}", "", ""},
+		{`sq(x)`, "100: int", ""},
+		{"set i = 0\nfor if i < 3 {\n\ti += 1\n}", "", ""},
+		{`i`, "3: int", ""},
+		{"set total = 0\nfor set v in [1, 2, 3, 4] {\n\tif v == 3 {\n\t\tbreak\n\t}\n\ttotal += v\n}", "", ""},
+		{`total`, "3: int", ""},
+		{`x > 5 ? "big" : "small"`, `"big": string`, ""},
+		{`[v * 2 for v in [1, 2] if v > 1]`, "[4]: [int]", ""},
+		{`2 in [1, 2]`, "true: bool", ""},
+		{`use math {abs}`, "", ""},
+		{`abs(-3)`, "3: int", ""},
+		{`use math as m`, "", ""},
+		{`m.abs(-4)`, "4: int", ""},
+		{"typealias Point = {x: int, y: int}\nset p: Point = {x: 1, y: 2}", "", ""},
+		{`p.x + p.y`, "3: int", ""},
+		// Objects declared again replace the previous ones
+		{`set x = "text"`, "", ""},
+		{`x`, `"text": string`, ""},
+		{`sq(3)`, "9: int", ""},
+		{`nil`, "", ""},
+		// An invalid entry doesn't declare any object
+		{"set y = 1\nset z: string = y", "", "expected `string` but got `int`"},
+		{`y`, "", "object `y` was not found"},
+		{`unknown`, "", "repl 1:1-7"},
+		{`1 +`, "", "syntax error"},
+		{`return 1`, "", "`return` statement is not allowed here"},
+		{`break`, "", "`break` statement is not allowed here"},
+		{`use foo {bar}`, "", "package `foo` was not found"},
+		{`use math {nope}`, "", "public object `nope` was not found in package `math`"},
+		{`1 / 0`, "", "division by zero"},
+		// The REPL keeps working after a runtime error
+		{`sq(4)`, "16: int", ""},
+	}
+
+	for _, test := range tests {
+		res, err := r.Eval(test.src)
+		if err != nil {
+			if test.errMsg == "" {
+				t.Errorf("Expected no error for %q but got %q", test.src, err.Error())
+			} else if !strings.Contains(err.Error(), test.errMsg) {
+				t.Errorf("Expected an error to contain %q for %q but got %q", test.errMsg, test.src, err.Error())
+			}
+		} else if test.errMsg != "" {
+			t.Errorf("Expected an error to contain %q for %q but got no error", test.errMsg, test.src)
+		} else if res != test.expected {
+			t.Errorf("Expected %q for %q but got %q", test.expected, test.src, res)
+		}
+	}
+}
+
+func TestTypeOf(t *testing.T) {
+	r, _ := newREPL(t, "")
+
+	_, err := r.Eval("set xs: [int] = [1, 2]")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		src      string
+		expected string
+		errMsg   string
+	}{
+		{`1 + 1.5`, "float", ""},
+		{`xs`, "[int]", ""},
+		{`(1, "a")`, "(int, string)", ""},
+		{`set a = 1`, "", "expected an expression"},
+	}
+
+	for _, test := range tests {
+		typ, err := r.TypeOf(test.src)
+		if err != nil {
+			if test.errMsg == "" || !strings.Contains(err.Error(), test.errMsg) {
+				t.Errorf("Unexpected error for %q: %q", test.src, err.Error())
+			}
+		} else if typ != test.expected {
+			t.Errorf("Expected the type %q for %q but got %q", test.expected, test.src, typ)
+		}
+	}
+
+}
+
+func TestAssembly(t *testing.T) {
+	r, _ := newREPL(t, "")
+
+	listing, err := r.Assembly("set w = 1 + 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(listing, "op + (int: 2) (int: 1)") {
+		t.Errorf("Expected the listing of the entry but got\n%s", listing)
+	}
+
+	// The listing is the one read by gdasm
+	code, err := asm.Assemble("repl.gdasm", []byte(listing))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disasm, err := vm.NewGDVMDisassembler(code, nil).Disassemble()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.TrimSuffix(disasm, "\n") != listing {
+		t.Errorf("Expected the assembled listing to be disassembled back to\n%s\nbut got\n%s", listing, disasm)
+	}
+
+	// The entry is not evaluated, so its objects are not declared
+	_, err = r.Eval(`w`)
+	if err == nil {
+		t.Errorf("Expected the objects of `:asm` not to be declared")
+	}
+}
+
+func TestRun(t *testing.T) {
+	input := strings.Join([]string{
+		"func add(a: int, b: int) => int {",
+		"\treturn a + b",
+		"}",
+		"add(1, 2)",
+		":type add",
+		":asm 1 + 2",
+		"add(true, 1)",
+		":nope",
+		":quit",
+		"add(3, 4)",
+	}, "\n")
+
+	r, out := newREPL(t, input)
+	err := r.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Join([]string{
+		"gd> ... ... gd> 3: int",
+		"gd> (a: int, b: int) => int",
+		"gd> 00000  op + (int: 2) (int: 1)",
+		"gd> ",
+	}, "\n")
+
+	if !strings.HasPrefix(out.String(), expected) {
+		t.Errorf("Expected the output to start with\n%s\nbut got\n%s", expected, out.String())
+	}
+
+	for _, msg := range []string{"invalid argument type", "Unknown command `:nope`"} {
+		if !strings.Contains(out.String(), msg) {
+			t.Errorf("Expected the output to contain %q but got\n%s", msg, out.String())
+		}
+	}
+
+	// The entries after `:quit` are not evaluated
+	if strings.Contains(out.String(), "7: int") {
+		t.Errorf("Expected the REPL to stop at `:quit` but got\n%s", out.String())
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vm
+
+import (
+	"bufio"
+	"fmt"
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/ir"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const debuggerHelp = `Commands:
+  break, b <file>:<line>   adds a breakpoint
+  clear <file>:<line>      removes a breakpoint
+  continue, c              runs until the next breakpoint
+  step, s                  steps into the next line
+  next, n                  steps over the next line
+  out, o                   steps out of the current function
+  locals, l                prints the objects of the current scope
+  backtrace, bt            prints the frames of the functions being called
+  help, h                  prints the commands
+  quit, q                  stops the program
+`
+
+// Command-line debugger of the VM, the commands are
+// read from the input until the program continues
+type GDVMDebugger struct {
+	*GDVMStepper
+	in  *bufio.Scanner
+	out io.Writer
+	// Lines of the source files, read when they are printed
+	sources map[string][]string
+}
+
+func (d *GDVMDebugger) OnInst(stack *runtime.GDSymbolStack) error {
+	mapping, _, stop := d.Next()
+	if !stop {
+		return nil
+	}
+
+	d.printLocation(mapping)
+
+	return d.prompt(stack)
+}
+
+// Reads the commands until one of them resumes the program
+func (d *GDVMDebugger) prompt(stack *runtime.GDSymbolStack) error {
+	for {
+		d.printf("(gddb) ")
+		if !d.in.Scan() {
+			// Without more commands the program runs to the end
+			d.printf("\n")
+			d.ClearBreakpoints()
+			return nil
+		}
+
+		fields := strings.Fields(d.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		cmd, args := fields[0], fields[1:]
+		switch cmd {
+		case "break", "b":
+			if location, file, line, ok := d.parseLocation(args); ok {
+				if d.AddBreakpoint(file, line) {
+					d.printf("Breakpoint at %s\n", location)
+				} else {
+					d.printf("No code at `%s`\n", location)
+				}
+			}
+		case "clear":
+			if location, file, line, ok := d.parseLocation(args); ok {
+				if d.ClearBreakpoint(file, line) {
+					d.printf("Cleared breakpoint at %s\n", location)
+				} else {
+					d.printf("No code at `%s`\n", location)
+				}
+			}
+		case "continue", "c":
+			return nil
+		case "step", "s":
+			d.Step(StepIn)
+			return nil
+		case "next", "n":
+			d.Step(StepOver)
+			return nil
+		case "out", "o":
+			d.Step(StepOut)
+			return nil
+		case "locals", "l":
+			d.printLocals(stack)
+		case "backtrace", "bt":
+			d.printf("%s\n", strings.Join(d.proc.traceLines(), "\n"))
+		case "help", "h":
+			d.printf(debuggerHelp)
+		case "quit", "q":
+			return DebuggerQuitErr
+		default:
+			d.printf("Unknown command `%s`, type `help` to list the commands\n", cmd)
+		}
+	}
+}
+
+// Parses a `file:line` location
+func (d *GDVMDebugger) parseLocation(args []string) (string, string, int, bool) {
+	if len(args) != 1 {
+		d.printf("Expected a location as `file:line`\n")
+		return "", "", 0, false
+	}
+
+	location := args[0]
+	sep := strings.LastIndex(location, ":")
+	line, err := strconv.Atoi(location[sep+1:])
+	if sep <= 0 || err != nil {
+		d.printf("Invalid location `%s`, expected `file:line`\n", location)
+		return "", "", 0, false
+	}
+
+	return location, location[:sep], line, true
+}
+
+// Prints the objects of the stack chain, the innermost objects shadow the outer ones
+func (d *GDVMDebugger) printLocals(stack *runtime.GDSymbolStack) {
+	seen := make(map[string]bool)
+	for ; stack != nil; stack = stack.Parent {
+		lines := make([]string, 0, len(stack.Symbols))
+		for key, symbol := range stack.Symbols {
+			name, ok := d.SymbolName(d.proc.CInstOffset, key)
+			if !ok || seen[name] {
+				continue
+			}
+
+			typ := symbol.Type
+			if typ == nil {
+				typ = symbol.Object.GetType()
+			}
+
+			seen[name] = true
+			lines = append(lines, fmt.Sprintf("  %s: %s = %s", name, d.TypeName(typ), d.ObjectString(symbol.Object)))
+		}
+
+		slices.Sort(lines)
+		for _, line := range lines {
+			d.printf("%s\n", line)
+		}
+	}
+}
+
+func (d *GDVMDebugger) printLocation(mapping ir.GDSourceMapping) {
+	name := d.proc.FrameStates()[0].Name
+	file := d.srcMap.Sources[mapping.Source]
+	d.printf("Stopped at `%s` (%s:%d:%d)\n", name, file, mapping.Line, mapping.ColStart)
+
+	lines, ok := d.sources[file]
+	if !ok {
+		data, err := os.ReadFile(file)
+		if err == nil {
+			lines = strings.Split(string(data), "\n")
+		}
+		d.sources[file] = lines
+	}
+
+	if mapping.Line > 0 && mapping.Line <= len(lines) {
+		d.printf("%4d | %s\n", mapping.Line, lines[mapping.Line-1])
+	}
+}
+
+func (d *GDVMDebugger) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(d.out, format, args...)
+}
+
+// Attaches a debugger to the process, the program stops before its first line
+func NewGDVMDebugger(proc *GDVMProc, srcMap *ir.GDSourceMap, in io.Reader, out io.Writer) *GDVMDebugger {
+	debugger := &GDVMDebugger{NewGDVMStepper(proc, srcMap), bufio.NewScanner(in), out, make(map[string][]string)}
+	proc.Debugger = debugger
+
+	return debugger
+}
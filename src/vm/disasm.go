@@ -0,0 +1,764 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vm
+
+import (
+	"gdlang/lib/runtime"
+	"gdlang/src/cpu"
+	"gdlang/src/gd/ir"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Idents written without quotes in the listing, the
+// other idents are quoted like the string objects
+var bareIdentRegex = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$.]*$`)
+
+// Words of the listing that can be confused with an ident
+var disasmKeywords = []string{"pub", "const", "as"}
+
+// Disassembler of the bytecode, the instructions are decoded with
+// the reader primitives without being evaluated, so the objects
+// referenced by the operands are printed by their idents
+type GDVMDisassembler struct {
+	// Optional source map to print the source positions
+	SrcMap *ir.GDSourceMap
+	*GDVMReader
+	// Empty stack to read the types, so type references are kept
+	stack *runtime.GDSymbolStack
+	// Types of the aliases, to read the objects of the type references
+	aliases map[any]runtime.GDTypable
+	lines   []disasmLine
+	// Offsets of the instructions and of the jump labels
+	insts, labels map[uint]bool
+	// Offsets of the end instructions of the blocks being decoded
+	blocks []uint
+}
+
+type disasmLine struct {
+	off   uint
+	depth int
+	text  string
+}
+
+// Decodes the whole bytecode into a listing with an instruction per line,
+// each line starts with the offset of the instruction and the jump
+// targets are printed as labels, e.g. `L00042:`, before their instruction
+func (d *GDVMDisassembler) Disassemble() (string, error) {
+	for d.Off < uint(len(d.Buff)) {
+		off := d.Off
+		d.insts[off] = true
+
+		err := d.decodeInst()
+		if err != nil {
+			return "", DisassembleErr(err, off)
+		}
+	}
+
+	if len(d.blocks) > 0 {
+		return "", DisassembleErr(EOFErr, d.Off)
+	}
+
+	for label := range d.labels {
+		if !d.insts[label] && label != uint(len(d.Buff)) {
+			return "", DisassembleErr(InvalidLabelErr(label), label)
+		}
+	}
+
+	var sb strings.Builder
+	var lastPos string
+	for _, line := range d.lines {
+		if d.labels[line.off] {
+			sb.WriteString(labelName(line.off) + ":\n")
+		}
+
+		sb.WriteString(runtime.Sprintf("%@  %@%@", padOffset(line.off), strings.Repeat("  ", line.depth), line.text))
+
+		// Positions are printed when they change, most of the
+		// instructions of an expression share the same position
+		if comment := d.sourceComment(line); comment != "" && comment != lastPos {
+			sb.WriteString("  ; " + comment)
+			lastPos = comment
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if d.labels[uint(len(d.Buff))] {
+		sb.WriteString(labelName(uint(len(d.Buff))) + ":\n")
+	}
+
+	return sb.String(), nil
+}
+
+// Only the instructions that start a mapping are commented, the
+// lambdas are commented with the name and position of the function
+func (d *GDVMDisassembler) sourceComment(line disasmLine) string {
+	if d.SrcMap == nil {
+		return ""
+	}
+
+	for _, fn := range d.SrcMap.Functions {
+		if fn.Start == int(line.off) && fn.Source >= 0 && fn.Source < len(d.SrcMap.Sources) {
+			pos := ir.GDSourcePos{File: d.SrcMap.Sources[fn.Source], Line: fn.Line, Col: fn.Col}
+			return pos.String() + " func " + fn.Name
+		}
+	}
+
+	mapping, ok := d.SrcMap.MappingAt(int(line.off))
+	if !ok || mapping.Offset != int(line.off) {
+		return ""
+	}
+
+	pos := ir.GDSourcePos{File: d.SrcMap.Sources[mapping.Source], Line: mapping.Line, Col: mapping.ColStart}
+
+	return pos.String()
+}
+
+func (d *GDVMDisassembler) addLine(off uint, text string) {
+	d.lines = append(d.lines, disasmLine{off, len(d.blocks), text})
+}
+
+func (d *GDVMDisassembler) decodeInst() error {
+	off := d.Off
+	instByte, err := d.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	inst := cpu.GDInst(instByte)
+	name := cpu.GetCPUInstName(inst)
+
+	var operands []string
+	switch inst {
+	case cpu.BBegin:
+		bLen, err := d.readUInt16()
+		if err != nil {
+			return err
+		}
+
+		d.addLine(off, name)
+
+		// The length counts from the last byte of the length to the end instruction
+		d.blocks = append(d.blocks, d.Off-1+uint(bLen))
+
+		return nil
+	case cpu.BEnd:
+		if len(d.blocks) == 0 || d.blocks[len(d.blocks)-1] != off {
+			return InvalidBlockEndErr
+		}
+
+		d.blocks = d.blocks[:len(d.blocks)-1]
+		d.addLine(off, name)
+
+		return nil
+	case cpu.Lambda:
+		typ, err := d.decodeType()
+		if err != nil {
+			return err
+		}
+
+		// The block of the function is decoded as the next instruction
+		if d.Off >= uint(len(d.Buff)) || cpu.GDInst(d.Buff[d.Off]) != cpu.BBegin {
+			return InvalidLambdaBlockErr
+		}
+
+		operands = []string{typ}
+	case cpu.Set, cpu.TypeAlias:
+		disc, ident, err := d.decodeDisc()
+		if err != nil {
+			return err
+		}
+
+		typ, err := d.ReadType(d.stack)
+		if err != nil {
+			return err
+		}
+
+		typStr, err := formatDisasmType(typ)
+		if err != nil {
+			return err
+		}
+
+		operands = []string{disc, typStr}
+		if inst == cpu.TypeAlias {
+			d.aliases[ident.GetRawValue()] = typ
+		} else {
+			obj, err := d.decodeObject()
+			if err != nil {
+				return err
+			}
+
+			operands = append(operands, obj)
+		}
+	case cpu.Mov, cpu.CastObj:
+		typ, err := d.decodeType()
+		if err != nil {
+			return err
+		}
+
+		obj, err := d.decodeObject()
+		if err != nil {
+			return err
+		}
+
+		operands = []string{typ, obj}
+	case cpu.AGet, cpu.IGet:
+		isNilSafe, err := d.readBool()
+		if err != nil {
+			return err
+		}
+
+		// Nil safe accesses are marked like in the source code, e.g. `aget?`
+		if isNilSafe {
+			name += "?"
+		}
+
+		obj, err := d.decodeObject()
+		if err != nil {
+			return err
+		}
+
+		var operand string
+		if inst == cpu.AGet {
+			operand, err = d.decodeIdent()
+		} else {
+			operand, err = d.decodeObject()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		operands = []string{obj, operand}
+	case cpu.Ret, cpu.ILen:
+		obj, err := d.decodeObject()
+		if err != nil {
+			return err
+		}
+
+		operands = []string{obj}
+	case cpu.Call, cpu.CAdd, cpu.CRemove:
+		operands, err = d.decodeObjects(2)
+		if err != nil {
+			return err
+		}
+	case cpu.CSet, cpu.Tif:
+		operands, err = d.decodeObjects(3)
+		if err != nil {
+			return err
+		}
+	case cpu.Operation:
+		opByte, err := d.ReadByte()
+		if err != nil {
+			return err
+		}
+
+		op, found := runtime.ExprOperationMap[runtime.ExprOperationType(opByte)]
+		if !found {
+			return InvalidOperationErr(opByte)
+		}
+
+		objs, err := d.decodeObjects(2)
+		if err != nil {
+			return err
+		}
+
+		operands = append([]string{op}, objs...)
+	case cpu.CompareJump:
+		operands, err = d.decodeObjects(2)
+		if err != nil {
+			return err
+		}
+
+		label, err := d.decodeLabel()
+		if err != nil {
+			return err
+		}
+
+		operands = append(operands, label)
+	case cpu.Jump:
+		label, err := d.decodeLabel()
+		if err != nil {
+			return err
+		}
+
+		operands = []string{label}
+	case cpu.Use:
+		operands, err = d.decodeUse()
+		if err != nil {
+			return err
+		}
+	default:
+		return InvalidInstErr(instByte)
+	}
+
+	d.addLine(off, name+" "+strings.Join(operands, " "))
+
+	return nil
+}
+
+// Reads the public and constant flags followed by the ident
+func (d *GDVMDisassembler) decodeDisc() (string, runtime.GDIdent, error) {
+	isPub, err := d.readBool()
+	if err != nil {
+		return "", nil, err
+	}
+
+	isConst, err := d.readBool()
+	if err != nil {
+		return "", nil, err
+	}
+
+	ident, err := d.ReadIdent()
+	if err != nil {
+		return "", nil, err
+	}
+
+	disc := formatDisasmIdent(ident)
+	if isConst {
+		disc = "const " + disc
+	}
+
+	if isPub {
+		disc = "pub " + disc
+	}
+
+	return disc, ident, nil
+}
+
+func (d *GDVMDisassembler) decodeUse() ([]string, error) {
+	modeByte, err := d.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	mode, found := runtime.PackageModeMap[runtime.GDPackageMode(modeByte)]
+	if !found {
+		return nil, InvalidPackageModeErr(modeByte)
+	}
+
+	pkg, err := d.decodeIdent()
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := d.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	imports := make([]string, count)
+	for i := range count {
+		imports[i], err = d.decodeIdent()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	operands := []string{mode, pkg, "[" + strings.Join(imports, ", ") + "]"}
+
+	hasAlias, err := d.readBool()
+	if err != nil {
+		return nil, err
+	}
+
+	if hasAlias {
+		alias, err := d.decodeIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, "as", alias)
+	}
+
+	return operands, nil
+}
+
+func (d *GDVMDisassembler) decodeLabel() (string, error) {
+	labelOff, err := d.readUInt16()
+	if err != nil {
+		return "", err
+	}
+
+	d.labels[uint(labelOff)] = true
+
+	return labelName(uint(labelOff)), nil
+}
+
+func (d *GDVMDisassembler) decodeIdent() (string, error) {
+	ident, err := d.ReadIdent()
+	if err != nil {
+		return "", err
+	}
+
+	return formatDisasmIdent(ident), nil
+}
+
+func (d *GDVMDisassembler) decodeType() (string, error) {
+	typ, err := d.ReadType(d.stack)
+	if err != nil {
+		return "", err
+	}
+
+	return formatDisasmType(typ)
+}
+
+func (d *GDVMDisassembler) decodeObjects(count int) ([]string, error) {
+	objs := make([]string, count)
+	for i := range count {
+		obj, err := d.decodeObject()
+		if err != nil {
+			return nil, err
+		}
+
+		objs[i] = obj
+	}
+
+	return objs, nil
+}
+
+// Objects are printed with their type, e.g. `(int: 1)`,
+// the references to other objects are printed by their ident
+func (d *GDVMDisassembler) decodeObject() (string, error) {
+	typ, err := d.ReadType(d.stack)
+	if err != nil {
+		return "", err
+	}
+
+	switch typ := typ.(type) {
+	case runtime.GDObjRefType:
+		return formatDisasmIdent(typ.GDIdent), nil
+	}
+
+	typStr, err := formatDisasmType(typ)
+	if err != nil {
+		return "", err
+	}
+
+	if typ.GetCode() == runtime.GDNilTypeCode {
+		return "(" + typStr + ")", nil
+	}
+
+	value, err := d.decodeValue(typ)
+	if err != nil {
+		return "", err
+	}
+
+	return "(" + typStr + ": " + value + ")", nil
+}
+
+func (d *GDVMDisassembler) decodeValue(typ runtime.GDTypable) (string, error) {
+	switch typ.GetCode() {
+	case runtime.GDTypeRefTypeCode:
+		// The compiler writes the type references of the composite
+		// literals, so an unknown reference is read as a collection
+		aliasType := d.resolveAlias(typ.(runtime.GDIdentRefType))
+		if aliasType == nil {
+			return d.decodeCollection()
+		}
+
+		return d.decodeValue(aliasType)
+	case runtime.GDIntTypeCode:
+		intVal, err := d.ReadInt()
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.Itoa(intVal), nil
+	case runtime.GDInt8TypeCode:
+		return readDisasmNumber(d, 1, d.ReadInt8, func(n int8) string { return strconv.FormatInt(int64(n), 10) })
+	case runtime.GDInt16TypeCode:
+		return readDisasmNumber(d, 2, d.ReadInt16, func(n int16) string { return strconv.FormatInt(int64(n), 10) })
+	case runtime.GDInt32TypeCode:
+		return readDisasmNumber(d, 4, d.ReadInt32, func(n int32) string { return strconv.FormatInt(int64(n), 10) })
+	case runtime.GDInt64TypeCode:
+		return readDisasmNumber(d, 8, d.ReadInt64, func(n int64) string { return strconv.FormatInt(n, 10) })
+	case runtime.GDUInt8TypeCode:
+		return readDisasmNumber(d, 1, d.ReadByte, func(n byte) string { return strconv.FormatUint(uint64(n), 10) })
+	case runtime.GDUInt16TypeCode:
+		return readDisasmNumber(d, 2, d.ReadUInt16, func(n uint16) string { return strconv.FormatUint(uint64(n), 10) })
+	case runtime.GDUInt32TypeCode:
+		return readDisasmNumber(d, 4, d.ReadUInt32, func(n uint32) string { return strconv.FormatUint(uint64(n), 10) })
+	case runtime.GDUInt64TypeCode:
+		return readDisasmNumber(d, 8, d.ReadUInt64, func(n uint64) string { return strconv.FormatUint(n, 10) })
+	case runtime.GDFloat32TypeCode:
+		return readDisasmNumber(d, 4, d.ReadFloat32, func(n float32) string { return strconv.FormatFloat(float64(n), 'g', -1, 32) })
+	case runtime.GDFloat64TypeCode:
+		return readDisasmNumber(d, 8, d.ReadFloat64, func(n float64) string { return strconv.FormatFloat(n, 'g', -1, 64) })
+	case runtime.GDComplex64TypeCode:
+		return readDisasmNumber(d, 8, d.ReadComplex64, func(n complex64) string { return strconv.FormatComplex(complex128(n), 'g', -1, 64) })
+	case runtime.GDComplex128TypeCode:
+		return readDisasmNumber(d, 16, d.ReadComplex128, func(n complex128) string { return strconv.FormatComplex(n, 'g', -1, 128) })
+	case runtime.GDBoolTypeCode:
+		boolVal, err := d.readBool()
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.FormatBool(boolVal), nil
+	case runtime.GDStringTypeCode, runtime.GDBigIntTypeCode, runtime.GDDecimalTypeCode:
+		str, err := d.readDisasmString()
+		if err != nil {
+			return "", err
+		}
+
+		return strconv.Quote(str), nil
+	case runtime.GDCharTypeCode:
+		char, size, err := d.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		if char == utf8.RuneError && size == 1 {
+			return "", InvalidCharErr
+		}
+
+		return strconv.QuoteRune(char), nil
+	case runtime.GDTupleTypeCode, runtime.GDArrayTypeCode, runtime.GDSetTypeCode, runtime.GDStructTypeCode:
+		return d.decodeCollection()
+	case runtime.GDNominalTypeCode, runtime.GDSpreadableTypeCode:
+		return d.decodeObject()
+	}
+
+	return "", InvalidTypeCodeReadingObjectErr(byte(typ.GetCode()))
+}
+
+func (d *GDVMDisassembler) decodeCollection() (string, error) {
+	count, err := d.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	objs, err := d.decodeObjects(int(count))
+	if err != nil {
+		return "", err
+	}
+
+	// The objects are written in reverse order
+	slices.Reverse(objs)
+
+	return "[" + strings.Join(objs, ", ") + "]", nil
+}
+
+// Follows the aliases of the aliases, the idents are unique in the release
+// builds and the last alias of an ident is used in the debug builds
+func (d *GDVMDisassembler) resolveAlias(ref runtime.GDIdentRefType) runtime.GDTypable {
+	var typ runtime.GDTypable = ref
+	for range len(d.aliases) + 1 {
+		ref, isRef := typ.(runtime.GDIdentRefType)
+		if !isRef {
+			return typ
+		}
+
+		typ = d.aliases[ref.GetRawValue()]
+		if typ == nil {
+			return nil
+		}
+	}
+
+	// The aliases refer to each other
+	return nil
+}
+
+// Strings are written with their length as an `int` object
+func (d *GDVMDisassembler) readDisasmString() (string, error) {
+	typ, err := d.ReadType(d.stack)
+	if err != nil {
+		return "", err
+	}
+
+	if typ.GetCode() != runtime.GDIntTypeCode {
+		return "", InvalidTypeErr("an `int` type", typ)
+	}
+
+	strLen, err := d.ReadInt()
+	if err != nil {
+		return "", err
+	}
+
+	if strLen < 0 || d.Off+uint(strLen) > uint(len(d.Buff)) {
+		return "", EOFErr
+	}
+
+	return d.readString(uint(strLen))
+}
+
+// The reader accepts any byte as a boolean, but the
+// listing only keeps the bytes written by the compiler
+func (d *GDVMDisassembler) readBool() (bool, error) {
+	b, err := d.ReadByte()
+	if err != nil {
+		return false, err
+	}
+
+	if b > 1 {
+		return false, InvalidBoolErr(b)
+	}
+
+	return b == 1, nil
+}
+
+func (d *GDVMDisassembler) readUInt16() (uint16, error) {
+	return readDisasmNumber(d, 2, d.ReadUInt16, func(n uint16) uint16 { return n })
+}
+
+// Reads a number of a fixed size, the reader does not check the end of the bytecode
+func readDisasmNumber[T GDNumberConstraints, R any](d *GDVMDisassembler, size uint, read func() (T, error), format func(T) R) (R, error) {
+	var zero R
+	if d.Off+size > uint(len(d.Buff)) {
+		return zero, EOFErr
+	}
+
+	n, err := read()
+	if err != nil {
+		return zero, err
+	}
+
+	return format(n), nil
+}
+
+// Types are printed by the names of their codes, the
+// composite types list their parts, e.g. `array(int)`
+func formatDisasmType(typ runtime.GDTypable) (string, error) {
+	code := typ.GetCode()
+	if int(code) >= len(runtime.GDTypeCodeMap) || runtime.GDTypeCodeMap[code] == "" {
+		return "", InvalidTypeCodeErr(byte(code))
+	}
+
+	name := runtime.GDTypeCodeMap[code]
+	var parts []string
+	switch typ := typ.(type) {
+	case runtime.GDStructType:
+		for _, attr := range typ {
+			attrType, err := formatDisasmType(attr.Type)
+			if err != nil {
+				return "", err
+			}
+
+			parts = append(parts, formatDisasmIdent(attr.Ident)+": "+attrType)
+		}
+	case *runtime.GDNominalType:
+		nominalType, err := formatDisasmType(typ.Type)
+		if err != nil {
+			return "", err
+		}
+
+		parts = []string{formatDisasmIdent(typ.Ident), strconv.FormatUint(uint64(typ.Decl), 10), nominalType}
+	case runtime.GDObjRefType:
+		parts = []string{formatDisasmIdent(typ.GDIdent)}
+	case runtime.GDIdentRefType:
+		parts = []string{formatDisasmIdent(typ.GDIdent)}
+	case *runtime.GDArrayType:
+		return formatDisasmTypes(name, typ.SubType)
+	case *runtime.GDSetType:
+		return formatDisasmTypes(name, typ.SubType)
+	case runtime.GDUnionType:
+		return formatDisasmTypes(name, typ...)
+	case runtime.GDTupleType:
+		return formatDisasmTypes(name, typ...)
+	case *runtime.GDLambdaType:
+		for _, arg := range typ.ArgTypes {
+			argType, err := formatDisasmType(arg.Value)
+			if err != nil {
+				return "", err
+			}
+
+			parts = append(parts, formatDisasmIdent(arg.Key)+": "+argType)
+		}
+
+		if typ.IsVariadic {
+			parts = append(parts, "...")
+		}
+
+		returnType, err := formatDisasmType(typ.ReturnType)
+		if err != nil {
+			return "", err
+		}
+
+		return name + "(" + strings.Join(parts, ", ") + ") " + returnType, nil
+	default:
+		return name, nil
+	}
+
+	return name + "(" + strings.Join(parts, ", ") + ")", nil
+}
+
+func formatDisasmTypes(name string, types ...runtime.GDTypable) (string, error) {
+	parts := make([]string, len(types))
+	for i, typ := range types {
+		str, err := formatDisasmType(typ)
+		if err != nil {
+			return "", err
+		}
+
+		parts[i] = str
+	}
+
+	return name + "(" + strings.Join(parts, ", ") + ")", nil
+}
+
+// Idents are printed by their mode, `#` for the numeric idents
+// of the release builds and `%` for the registers, e.g. `%rpop`
+func formatDisasmIdent(ident runtime.GDIdent) string {
+	switch ident.GetMode() {
+	case runtime.GDByteIdentMode:
+		reg := ident.GetRawValue().(byte)
+		if name := cpu.GetCPURegName(cpu.GDReg(reg)); name != "" {
+			return "%" + name
+		}
+
+		return "%" + strconv.Itoa(int(reg))
+	case runtime.GDUInt16IdentMode:
+		return "#" + strconv.Itoa(int(ident.GetRawValue().(uint16)))
+	}
+
+	name := ident.ToString()
+	if bareIdentRegex.MatchString(name) && !slices.Contains(disasmKeywords, name) {
+		return name
+	}
+
+	return strconv.Quote(name)
+}
+
+func labelName(off uint) string { return "L" + padOffset(off) }
+
+func padOffset(off uint) string {
+	str := strconv.FormatUint(uint64(off), 10)
+	if len(str) < 5 {
+		str = strings.Repeat("0", 5-len(str)) + str
+	}
+
+	return str
+}
+
+func NewGDVMDisassembler(bytes []byte, srcMap *ir.GDSourceMap) *GDVMDisassembler {
+	return &GDVMDisassembler{
+		SrcMap:     srcMap,
+		GDVMReader: NewGDVMReader(bytes),
+		stack:      runtime.NewRootGDSymbolStack(),
+		insts:      make(map[uint]bool),
+		labels:     make(map[uint]bool),
+		aliases:    make(map[any]runtime.GDTypable),
+	}
+}
@@ -41,10 +41,38 @@ var (
 		return VmErr{"Invalid object. Expected " + expected + ", got " + got.ToString()}
 	}
 	InvalidTypeCodeReadingObjectErr = func(code byte) VmErr {
+		if int(code) >= len(runtime.GDTypeCodeMap) || runtime.GDTypeCodeMap[code] == "" {
+			return VmErr{runtime.Sprintf("Invalid type code: `%@` reading object", code)}
+		}
+
 		return VmErr{"Invalid type code: `" + runtime.GDTypeCodeMap[code] + "` reading object"}
 	}
-	RuntimeErr = func(err error, inst cpu.GDInst, instOff uint) VmErr {
-		errMsg := formatRuntimeError(err.Error(), cpu.GetCPUInstName(inst), uint(inst), instOff)
+	InvalidTypeCodeErr = func(code byte) VmErr {
+		return VmErr{runtime.Sprintf("Invalid type code: `%@`", code)}
+	}
+	InvalidInstErr = func(code byte) VmErr {
+		return VmErr{runtime.Sprintf("Invalid instruction code: `%@`", code)}
+	}
+	InvalidOperationErr = func(code byte) VmErr {
+		return VmErr{runtime.Sprintf("Invalid operation code: `%@`", code)}
+	}
+	InvalidPackageModeErr = func(mode byte) VmErr {
+		return VmErr{runtime.Sprintf("Invalid package mode: `%@`", mode)}
+	}
+	InvalidBoolErr = func(b byte) VmErr {
+		return VmErr{runtime.Sprintf("Invalid bool: `%@`, expected `0` or `1`", b)}
+	}
+	InvalidLabelErr = func(off uint) VmErr {
+		return VmErr{runtime.Sprintf("Invalid label. The offset `%@` is not the start of an instruction", off)}
+	}
+	InvalidCharErr        = VmErr{"Invalid char. Expected an UTF-8 encoded rune"}
+	InvalidBlockEndErr    = VmErr{"Invalid block end. The length of the block does not match its end"}
+	InvalidLambdaBlockErr = VmErr{"Invalid lambda. Expected a block after the lambda type"}
+	DisassembleErr        = func(err error, off uint) VmErr {
+		return VmErr{runtime.Sprintf("%@ (byte offset: %@)", err.Error(), off)}
+	}
+	RuntimeErr = func(err error, inst cpu.GDInst, instOff uint, trace string) VmErr {
+		errMsg := formatRuntimeError(err.Error(), cpu.GetCPUInstName(inst), uint(inst), instOff) + trace
 		mdMsg := comn.NewMarkdown(errMsg)
 		return VmErr{mdMsg.Stylize()}
 	}
@@ -42,7 +42,13 @@ func (p *GDVMProc) evalIGet(stack *runtime.GDSymbolStack) (runtime.GDObject, err
 		return nil, err
 	}
 
-	obj, err := iter.Get(int(intVal))
+	var obj runtime.GDObject
+	if str, isStr := iter.(runtime.GDString); isStr {
+		obj, err = p.strCursor.Get(str, int(intVal))
+	} else {
+		obj, err = iter.Get(int(intVal))
+	}
+
 	if err != nil && !isNilSafe {
 		return nil, err
 	} else if err != nil && isNilSafe {
@@ -61,7 +67,12 @@ func (p *GDVMProc) evalILen(stack *runtime.GDSymbolStack) (runtime.GDObject, err
 		return nil, err
 	}
 
-	lenVal := iter.Length()
+	var lenVal int
+	if str, isStr := iter.(runtime.GDString); isStr {
+		lenVal = p.strCursor.Length(str)
+	} else {
+		lenVal = iter.Length()
+	}
 
 	stack.PushBuffer(runtime.NewGDIntNumber(runtime.GDInt(lenVal)))
 
@@ -78,6 +78,24 @@ func (p *GDVMProc) evalCRemove(stack *runtime.GDSymbolStack) (runtime.GDObject,
 		return nil, err
 	}
 
+	// Sets remove the elements by value
+	if set, isSet := left.(*runtime.GDSet); isSet {
+		obj, err := p.ReadObject(stack)
+		if err != nil {
+			return nil, err
+		}
+
+		removed, err := set.RemoveObject(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		// Push whether the object was in the set
+		stack.PushBuffer(runtime.GDBool(removed))
+
+		return nil, nil
+	}
+
 	idx, err := p.ReadIntObj(stack)
 	if err != nil {
 		return nil, err
@@ -23,12 +23,23 @@ import (
 	"gdlang/lib/builtin"
 	"gdlang/lib/runtime"
 	"gdlang/src/cpu"
+	"gdlang/src/gd/ir"
 )
 
 type GDVMProc struct {
 	Stack       *runtime.GDSymbolStack
 	CInstOffset uint
 	CInst       cpu.GDInst
+	// Stack of the current instruction
+	CStack *runtime.GDSymbolStack
+	// Frames of the lambdas being invoked
+	Frames []GDVMFrame
+	// Optional source map to print the stack traces
+	SrcMap *ir.GDSourceMap
+	// Optional debugger, called before each instruction
+	Debugger GDVMDebugHook
+	// Strings iterated by the for-in loops
+	strCursor runtime.GDStringCursor
 	*GDVMReader
 }
 
@@ -63,9 +74,19 @@ func (p *GDVMProc) Run() error {
 			break
 		}
 
-		_, err := p.evalInst(p.Stack)
-		if err != nil {
-			return RuntimeErr(err, p.CInst, p.CInstOffset)
+		obj, err := p.evalInst(p.Stack)
+		if err == DebuggerQuitErr {
+			return nil
+		} else if err != nil {
+			trace := p.StackTrace()
+			p.Frames = nil
+
+			return RuntimeErr(err, p.CInst, p.CInstOffset, trace)
+		}
+
+		// Instructions at the top level, e.g. the entries of the REPL, jump without a block
+		if jump, isJump := obj.(VMJump); isJump {
+			p.Off = uint(jump)
 		}
 	}
 
@@ -80,6 +101,14 @@ func (p *GDVMProc) evalInst(stack *runtime.GDSymbolStack) (runtime.GDObject, err
 
 	p.CInst = cpu.GDInst(instByte)
 	p.CInstOffset = p.Off - 1
+	p.CStack = stack
+
+	if p.Debugger != nil {
+		err = p.Debugger.OnInst(stack)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	switch p.CInst {
 	case cpu.BBegin:
@@ -134,7 +163,7 @@ func (p *GDVMProc) evalInst(stack *runtime.GDSymbolStack) (runtime.GDObject, err
 		return p.evalCompJump(stack)
 	}
 
-	panic("Unknown instruction: " + cpu.GetCPUInstName(cpu.GDInst(instByte)))
+	return nil, InvalidInstErr(instByte)
 }
 
 func (p *GDVMProc) evalDisc() (*GDVMDisc, error) {
@@ -221,6 +250,7 @@ func (p *GDVMProc) evalLambda(stack *runtime.GDSymbolStack) (runtime.GDObject, e
 		return nil, InvalidTypeErr("a `lambda` type", typ)
 	}
 
+	lambdaOff := p.CInstOffset
 	funcBlockStart := p.Off
 
 	lambda := runtime.NewGDLambdaWithType(lambdaType, stack, func(stack *runtime.GDSymbolStack, args runtime.GDLambdaArgs) (runtime.GDObject, error) {
@@ -239,6 +269,9 @@ func (p *GDVMProc) evalLambda(stack *runtime.GDSymbolStack) (runtime.GDObject, e
 		// Capture the return position, from where the function was called
 		returnOff := p.Off
 
+		// The frame is kept on errors to print the stack trace
+		p.pushFrame(lambdaOff)
+
 		// Jump to the function block start
 		p.Off = funcBlockStart
 
@@ -250,6 +283,7 @@ func (p *GDVMProc) evalLambda(stack *runtime.GDSymbolStack) (runtime.GDObject, e
 
 		// Jump back to the return position
 		p.Off = returnOff
+		p.popFrame()
 
 		if obj != nil {
 			return obj, nil
@@ -465,7 +499,7 @@ func (p *GDVMProc) evalCastObj(stack *runtime.GDSymbolStack) (runtime.GDObject,
 		return nil, err
 	}
 
-	castObj, err := obj.CastToType(typ, stack)
+	castObj, err := runtime.CastObject(obj, typ, stack)
 	if err != nil {
 		return nil, err
 	}
@@ -503,6 +537,19 @@ func (p *GDVMProc) evalUse(stack *runtime.GDSymbolStack) (runtime.GDObject, erro
 		imports[i] = ident
 	}
 
+	hasAlias, err := p.ReadBool()
+	if err != nil {
+		return nil, err
+	}
+
+	var alias runtime.GDIdent
+	if hasAlias {
+		alias, err = p.ReadIdent()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	switch packageMode {
 	case runtime.PackageModeBuiltin:
 		if pkg, found := builtin.Packages[pkgIdent.ToString()]; found {
@@ -512,6 +559,16 @@ func (p *GDVMProc) evalUse(stack *runtime.GDSymbolStack) (runtime.GDObject, erro
 					return nil, err
 				}
 
+				// Objects imported with an alias are accessed as `alias.object`
+				if alias != nil {
+					ident = runtime.NewGDPackageMemberIdent(alias, ident)
+				}
+
+				// The same object might be imported by several source files
+				if imported, err := stack.GetSymbol(ident); err == nil && imported == symbol {
+					continue
+				}
+
 				err = stack.AddSymbolStack(ident, symbol)
 				if err != nil {
 					return nil, err
@@ -20,6 +20,7 @@
 package vm
 
 import (
+	"encoding/binary"
 	"gdlang/lib/runtime"
 	"gdlang/src/cpu"
 	"unicode/utf8"
@@ -27,7 +28,7 @@ import (
 )
 
 type GDNumberConstraints interface {
-	~uint8 | ~uint16 | ~uint32 | ~int | ~int8 | ~int16 | ~float32 | ~float64 | ~complex64 | ~complex128
+	~uint8 | ~uint16 | ~uint32 | ~uint64 | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~float32 | ~float64 | ~complex64 | ~complex128
 }
 
 type GDVMReader struct {
@@ -67,6 +68,23 @@ func (p *GDVMReader) ReadType(stack *runtime.GDSymbolStack) (runtime.GDTypable,
 		}
 
 		return runtime.NewGDStructType(attrs...), nil
+	case runtime.GDNominalTypeCode:
+		ident, err := p.ReadIdent()
+		if err != nil {
+			return nil, err
+		}
+
+		decl, err := p.ReadUInt32()
+		if err != nil {
+			return nil, err
+		}
+
+		typ, err := p.ReadType(stack)
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.NewGDNominalType(ident, decl, typ), nil
 	case runtime.GDObjRefTypeCode:
 		ident, err := p.ReadIdent()
 		if err != nil {
@@ -84,6 +102,12 @@ func (p *GDVMReader) ReadType(stack *runtime.GDSymbolStack) (runtime.GDTypable,
 
 		symbol, err := stack.GetSymbol(ident)
 		if err != nil {
+			// Recursive types refer to types that are not declared yet,
+			// so the reference is kept and resolved lazily
+			if err, isGDErr := err.(runtime.GDRuntimeErr); isGDErr && err.Code == runtime.ObjectNotFoundErrCode {
+				return runtime.NewRefType(ident), nil
+			}
+
 			return nil, err
 		}
 
@@ -95,6 +119,13 @@ func (p *GDVMReader) ReadType(stack *runtime.GDSymbolStack) (runtime.GDTypable,
 		}
 
 		return runtime.NewGDArrayType(subType), nil
+	case runtime.GDSetTypeCode:
+		subType, err := p.ReadType(stack)
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.NewGDSetType(subType), nil
 	case runtime.GDUnionTypeCode:
 		uLen, err := p.ReadByte()
 		if err != nil {
@@ -254,7 +285,7 @@ func (p *GDVMReader) ReadIterObj(stack *runtime.GDSymbolStack) (runtime.GDIterab
 		return nil, err
 	}
 
-	iter, ok := runtime.Unwrap(obj).(runtime.GDIterableCollection)
+	iter, ok := runtime.UnwrapNominal(obj).(runtime.GDIterableCollection)
 	if !ok {
 		return nil, InvalidObjErr("an `iterable` object", obj)
 	}
@@ -282,7 +313,12 @@ func (p *GDVMReader) ReadAttributable(stack *runtime.GDSymbolStack) (runtime.GDA
 		return nil, err
 	}
 
-	attr, ok := runtime.Unwrap(obj).(runtime.GDAttributable)
+	attr, ok := runtime.UnwrapNominal(obj).(runtime.GDAttributable)
+	if !ok {
+		// Builtin types expose their native methods as attributes
+		attr, ok = runtime.NativeMethodsOf(obj)
+	}
+
 	if !ok {
 		return nil, InvalidObjErr("an `attributable` object", obj)
 	}
@@ -351,6 +387,48 @@ func (p *GDVMReader) ReadObject(stack *runtime.GDSymbolStack) (runtime.GDObject,
 		}
 
 		return runtime.GDInt16(int16Val), nil
+	case runtime.GDInt32TypeCode:
+		int32Val, err := p.ReadInt32()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.GDInt32(int32Val), nil
+	case runtime.GDInt64TypeCode:
+		int64Val, err := p.ReadInt64()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.GDInt64(int64Val), nil
+	case runtime.GDUInt8TypeCode:
+		uint8Val, err := p.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.GDUInt8(uint8Val), nil
+	case runtime.GDUInt16TypeCode:
+		uint16Val, err := p.ReadUInt16()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.GDUInt16(uint16Val), nil
+	case runtime.GDUInt32TypeCode:
+		uint32Val, err := p.ReadUInt32()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.GDUInt32(uint32Val), nil
+	case runtime.GDUInt64TypeCode:
+		uint64Val, err := p.ReadUInt64()
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.GDUInt64(uint64Val), nil
 	case runtime.GDFloat32TypeCode:
 		float32Val, err := p.ReadFloat32()
 		if err != nil {
@@ -387,22 +465,26 @@ func (p *GDVMReader) ReadObject(stack *runtime.GDSymbolStack) (runtime.GDObject,
 
 		return runtime.GDBool(boolVal), nil
 	case runtime.GDStringTypeCode:
-		sLen, err := p.ReadObject(stack)
+		strVal, err := p.ReadString(stack)
 		if err != nil {
 			return nil, err
 		}
 
-		sLenIntVal, err := runtime.ToInt(sLen)
+		return runtime.GDString(strVal), nil
+	case runtime.GDBigIntTypeCode:
+		strVal, err := p.ReadString(stack)
 		if err != nil {
 			return nil, err
 		}
 
-		strVal, err := p.readString(uint(sLenIntVal))
+		return runtime.NewGDBigIntFromString(strVal)
+	case runtime.GDDecimalTypeCode:
+		strVal, err := p.ReadString(stack)
 		if err != nil {
 			return nil, err
 		}
 
-		return runtime.GDString(strVal), nil
+		return runtime.NewGDDecimalFromString(strVal)
 	case runtime.GDCharTypeCode:
 		runeVal, _, err := p.ReadRune()
 		if err != nil {
@@ -453,6 +535,13 @@ func (p *GDVMReader) ReadObject(stack *runtime.GDSymbolStack) (runtime.GDObject,
 		}
 
 		return sObj, nil
+	case runtime.GDNominalTypeCode:
+		obj, err := p.ReadObject(stack)
+		if err != nil {
+			return nil, err
+		}
+
+		return runtime.NewGDNominalObject(typ.(*runtime.GDNominalType), obj), nil
 	case runtime.GDSpreadableTypeCode:
 		exprObj, err := p.ReadObject(stack)
 		if err != nil {
@@ -482,6 +571,23 @@ func (p *GDVMReader) ReadObject(stack *runtime.GDSymbolStack) (runtime.GDObject,
 		}
 
 		return runtime.NewGDArrayWithTypeAndObjects(typ.(*runtime.GDArrayType), aObjs), nil
+	case runtime.GDSetTypeCode:
+		sLen, err := p.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		sObjs := make([]runtime.GDObject, sLen)
+		for i := range sLen {
+			sObj, err := p.ReadObject(stack)
+			if err != nil {
+				return nil, err
+			}
+
+			sObjs[sLen-i-1] = sObj
+		}
+
+		return runtime.NewGDSetWithTypeAndObjects(typ.(*runtime.GDSetType), sObjs)
 	default:
 		return nil, InvalidTypeCodeReadingObjectErr(byte(typ.GetCode()))
 	}
@@ -519,10 +625,19 @@ func (p *GDVMReader) ReadByte() (byte, error) {
 
 // Numbers
 
+// Ints are read as zig-zag varints
 func (p *GDVMReader) ReadInt() (int, error) {
-	d, n := readNumber[int](p.Off, p.Buff)
-	p.Off += d
-	return n, nil
+	if p.Off >= uint(len(p.Buff)) {
+		return 0, EOFErr
+	}
+
+	n, d := binary.Varint(p.Buff[p.Off:])
+	if d <= 0 {
+		return 0, EOFErr
+	}
+
+	p.Off += uint(d)
+	return int(n), nil
 }
 
 func (p *GDVMReader) ReadInt8() (int8, error) {
@@ -537,6 +652,30 @@ func (p *GDVMReader) ReadInt16() (int16, error) {
 	return n, nil
 }
 
+func (p *GDVMReader) ReadInt32() (int32, error) {
+	d, n := readNumber[int32](p.Off, p.Buff)
+	p.Off += d
+	return n, nil
+}
+
+func (p *GDVMReader) ReadInt64() (int64, error) {
+	d, n := readNumber[int64](p.Off, p.Buff)
+	p.Off += d
+	return n, nil
+}
+
+func (p *GDVMReader) ReadUInt32() (uint32, error) {
+	d, n := readNumber[uint32](p.Off, p.Buff)
+	p.Off += d
+	return n, nil
+}
+
+func (p *GDVMReader) ReadUInt64() (uint64, error) {
+	d, n := readNumber[uint64](p.Off, p.Buff)
+	p.Off += d
+	return n, nil
+}
+
 func (p *GDVMReader) ReadFloat32() (float32, error) {
 	d, n := readNumber[float32](p.Off, p.Buff)
 	p.Off += d
@@ -571,6 +710,21 @@ func (p *GDVMReader) ReadUInt16() (uint16, error) {
 
 // Strings
 
+// Reads the length of the string followed by its bytes
+func (p *GDVMReader) ReadString(stack *runtime.GDSymbolStack) (string, error) {
+	sLen, err := p.ReadObject(stack)
+	if err != nil {
+		return "", err
+	}
+
+	sLenIntVal, err := runtime.ToInt(sLen)
+	if err != nil {
+		return "", err
+	}
+
+	return p.readString(uint(sLenIntVal))
+}
+
 func (p *GDVMReader) readString(strLen uint) (string, error) {
 	if strLen == 0 {
 		return "", nil
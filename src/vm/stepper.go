@@ -0,0 +1,209 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vm
+
+import (
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/ir"
+	"sync/atomic"
+)
+
+// Hook called by the process before each instruction,
+// an error stops the program
+type GDVMDebugHook interface {
+	OnInst(stack *runtime.GDSymbolStack) error
+}
+
+var DebuggerQuitErr = VmErr{"debugger quit"}
+
+type GDVMStepMode byte
+
+const (
+	// Runs until a breakpoint is reached
+	StepContinue GDVMStepMode = iota
+	// Stops at the next line, entering the called functions
+	StepIn
+	// Stops at the next line of the same function or of its callers
+	StepOver
+	// Stops at the next line of the callers
+	StepOut
+)
+
+// Reasons why the program stopped
+const (
+	StopOnEntry      = "entry"
+	StopOnStep       = "step"
+	StopOnBreakpoint = "breakpoint"
+	StopOnPause      = "pause"
+)
+
+// Decides where a debugger stops the program. It only stops before
+// the first instruction of a new line of the source code, either
+// because of a breakpoint or because a step has been completed.
+type GDVMStepper struct {
+	proc   *GDVMProc
+	srcMap *ir.GDSourceMap
+	// Breakpoints by the offset of the mappings of their lines
+	breakpoints map[int]bool
+	mode        GDVMStepMode
+	// Number of frames when the step started
+	stepDepth int
+	// Line and number of frames of the last mapped instruction
+	lastMapping ir.GDSourceMapping
+	lastDepth   int
+	hasStopped  bool
+	paused      atomic.Bool
+}
+
+// Checks if the program has to stop at the current instruction, the
+// mapping of the instruction and the reason of the stop are returned
+func (s *GDVMStepper) Next() (ir.GDSourceMapping, string, bool) {
+	// Instructions without their own mapping, such as blocks
+	// and lambdas, would take the line of the previous code
+	mapping, ok := s.srcMap.MappingAt(int(s.proc.CInstOffset))
+	if !ok || mapping.Offset != int(s.proc.CInstOffset) {
+		return mapping, "", false
+	}
+
+	depth := len(s.proc.Frames)
+	isNewLine := mapping.Source != s.lastMapping.Source || mapping.Line != s.lastMapping.Line || depth != s.lastDepth
+	s.lastMapping, s.lastDepth = mapping, depth
+	if !isNewLine {
+		return mapping, "", false
+	}
+
+	reason, stop := s.stopReason(mapping, depth)
+	if stop {
+		s.mode = StepContinue
+		s.hasStopped = true
+	}
+
+	return mapping, reason, stop
+}
+
+func (s *GDVMStepper) stopReason(mapping ir.GDSourceMapping, depth int) (string, bool) {
+	if s.paused.Swap(false) {
+		return StopOnPause, true
+	}
+
+	switch {
+	case s.mode == StepIn:
+		if !s.hasStopped {
+			return StopOnEntry, true
+		}
+		return StopOnStep, true
+	case s.mode == StepOver && depth <= s.stepDepth:
+		return StopOnStep, true
+	case s.mode == StepOut && depth < s.stepDepth:
+		return StopOnStep, true
+	}
+
+	return StopOnBreakpoint, s.breakpoints[mapping.Offset]
+}
+
+// Resumes the program until the step is completed
+func (s *GDVMStepper) Step(mode GDVMStepMode) {
+	s.mode = mode
+	s.stepDepth = len(s.proc.Frames)
+}
+
+// Stops the program at the next line, it can be called while the program is running
+func (s *GDVMStepper) Pause() {
+	s.paused.Store(true)
+}
+
+// Adds a breakpoint to the instructions of a line, it returns false when the line has no code
+func (s *GDVMStepper) AddBreakpoint(file string, line int) bool {
+	offsets := s.srcMap.LineOffsets(file, line)
+	for _, offset := range offsets {
+		s.breakpoints[offset] = true
+	}
+
+	return len(offsets) > 0
+}
+
+func (s *GDVMStepper) ClearBreakpoint(file string, line int) bool {
+	offsets := s.srcMap.LineOffsets(file, line)
+	for _, offset := range offsets {
+		delete(s.breakpoints, offset)
+	}
+
+	return len(offsets) > 0
+}
+
+func (s *GDVMStepper) ClearBreakpoints() {
+	s.breakpoints = make(map[int]bool)
+}
+
+// Source name of a symbol of the stack, the symbols are stored by the raw value of their
+// identifiers. The symbols without a name in the source map, such as the builtins, are skipped.
+func (s *GDVMStepper) SymbolName(off uint, key any) (string, bool) {
+	var ident runtime.GDIdent
+	switch key := key.(type) {
+	case uint16:
+		ident = runtime.NewGDUInt16Ident(key)
+	case string:
+		ident = runtime.NewGDStringIdent(key)
+	default:
+		return "", false
+	}
+
+	return s.srcMap.VariableName(int(off), ident)
+}
+
+// Type with the source names of the arguments of the functions,
+// the types of the functions are written with the runtime identifiers
+func (s *GDVMStepper) TypeName(typ runtime.GDTypable) string {
+	lambdaType, isLambdaType := typ.(*runtime.GDLambdaType)
+	if !isLambdaType {
+		return typ.ToString()
+	}
+
+	named := &runtime.GDLambdaType{
+		ArgTypes:   make(runtime.GDLambdaArgTypes, len(lambdaType.ArgTypes)),
+		ReturnType: lambdaType.ReturnType,
+		IsVariadic: lambdaType.IsVariadic,
+	}
+
+	for i, arg := range lambdaType.ArgTypes {
+		ident := arg.Key
+		if name, ok := s.srcMap.ArgumentName(ident); ok {
+			ident = runtime.NewGDStringIdent(name)
+		}
+
+		named.ArgTypes[i] = runtime.GDLambdaArgType{Key: ident, Value: arg.Value}
+	}
+
+	return named.ToString()
+}
+
+// Value of an object, functions are printed as their type
+func (s *GDVMStepper) ObjectString(obj runtime.GDObject) string {
+	if lambda, isLambda := obj.(*runtime.GDLambda); isLambda {
+		return s.TypeName(lambda.Type)
+	}
+
+	return obj.ToString()
+}
+
+// The program stops before its first line
+func NewGDVMStepper(proc *GDVMProc, srcMap *ir.GDSourceMap) *GDVMStepper {
+	return &GDVMStepper{proc: proc, srcMap: srcMap, breakpoints: make(map[int]bool), mode: StepIn}
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package vm
+
+import (
+	"gdlang/lib/runtime"
+	"gdlang/src/gd/ir"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Max number of frames printed by a stack trace,
+// the frames in the middle of a deeper stack are skipped
+const maxTraceFrames = 32
+
+// Name of the root in the stack traces
+const globalFrameName = "global"
+
+// Frame of a lambda invocation
+type GDVMFrame struct {
+	// Offset of the lambda instruction that defines the function
+	LambdaOff uint
+	// Offset and stack of the instruction that called the function
+	CallOff   uint
+	CallStack *runtime.GDSymbolStack
+}
+
+// State of a frame being executed
+type GDVMFrameState struct {
+	Name string
+	// Offset and stack of the current instruction of the frame
+	Off   uint
+	Stack *runtime.GDSymbolStack
+}
+
+func (p *GDVMProc) pushFrame(lambdaOff uint) {
+	p.Frames = append(p.Frames, GDVMFrame{lambdaOff, p.CInstOffset, p.CStack})
+}
+
+func (p *GDVMProc) popFrame() {
+	p.Frames = p.Frames[:len(p.Frames)-1]
+}
+
+// Stack trace of the current instruction, from the innermost frame
+// to the root. The frames are only printed with a source map.
+func (p *GDVMProc) StackTrace() string {
+	if p.SrcMap == nil {
+		return ""
+	}
+
+	lines := p.traceLines()
+	if len(lines) > maxTraceFrames {
+		skipped := "\t... " + strconv.Itoa(len(lines)-maxTraceFrames) + " more frames"
+		lines = slices.Concat(lines[:maxTraceFrames/2], []string{skipped}, lines[len(lines)-maxTraceFrames/2:])
+	}
+
+	return "Stack trace:\n" + strings.Join(lines, "\n") + "\n"
+}
+
+// States of the frames, from the innermost frame to the root
+func (p *GDVMProc) FrameStates() []GDVMFrameState {
+	states := make([]GDVMFrameState, 0, len(p.Frames)+1)
+	off, stack := p.CInstOffset, p.CStack
+	for i := len(p.Frames) - 1; i >= 0; i-- {
+		frame := p.Frames[i]
+		states = append(states, GDVMFrameState{p.frameName(frame), off, stack})
+		off, stack = frame.CallOff, frame.CallStack
+	}
+
+	// The root only runs the globals and calls the entry functions,
+	// so it is part of the trace when the error is raised there
+	if len(p.Frames) == 0 {
+		states = append(states, GDVMFrameState{globalFrameName, off, stack})
+	}
+
+	return states
+}
+
+// Lines of every frame, from the innermost frame to the root
+func (p *GDVMProc) traceLines() []string {
+	states := p.FrameStates()
+	lines := make([]string, len(states))
+	for i, state := range states {
+		lines[i] = p.traceLine(state.Name, state.Off)
+	}
+
+	return lines
+}
+
+func (p *GDVMProc) frameName(frame GDVMFrame) string {
+	if p.SrcMap == nil {
+		return "lambda"
+	}
+
+	name, ok := p.SrcMap.FunctionName(int(frame.LambdaOff))
+	if !ok {
+		return "lambda"
+	}
+
+	return name
+}
+
+func (p *GDVMProc) traceLine(name string, off uint) string {
+	pos, ok := p.SrcMap.Lookup(int(off))
+	if !ok {
+		return "\tat `" + name + "` (offset " + strconv.FormatUint(uint64(off), 10) + ")"
+	}
+
+	return "\tat `" + name + "` (" + pos.String() + ")"
+}
+
+// Loads the source map used to print the stack traces
+func (p *GDVMProc) LoadSourceMap(path string) error {
+	srcMap, err := ir.ReadGDSourceMap(path)
+	if err != nil {
+		return err
+	}
+
+	p.SrcMap = srcMap
+
+	return nil
+}
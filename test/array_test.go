@@ -96,5 +96,8 @@ func TestArrays(t *testing.T) {
 		// Remove array value
 		{`set a = [1, 2, 3]
 			print(a >> 0, a)`, "1[2, 3]", ""},
+		// Casted literals keep the order of the elements
+		{`print([1, 2, 3] as [int], [[1, 2], [3]] as [[int]])`, "[1, 2, 3][[1, 2], [3]]", ""},
+		{`print((1, 2, 3) as (int, int, int), {a: 1, b: 2} as {a: int, b: int})`, "(1, 2, 3){a: 1, b: 2}", ""},
 	})
 }
@@ -0,0 +1,126 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/src/gd/asm"
+	"gdlang/src/vm"
+	"strings"
+	"testing"
+)
+
+// Hand-written listings to test the instructions of the VM without the compiler
+func TestAssembledInstructions(t *testing.T) {
+	tests := []struct {
+		name, src, output, errMsg string
+	}{
+		{"set and call", `
+set x int (int: 1)
+call print (array(any): [x, (string: "a")])`, "1a", ""},
+		{"op", `
+op - (int: 1) (int: 5)
+op negative (nil) (int: 2)
+call print (array(any): [%rpop, %rpop])`, "4-2", ""},
+		{"mov", `
+set x int (int: 1)
+mov obj_ref(x) (int: 2)
+call print (array(any): [x])`, "2", ""},
+		{"tif", `
+tif (string: "no") (string: "yes") (bool: true)
+tif (string: "no") (string: "yes") (bool: false)
+call print (array(any): [%rpop, %rpop])`, "yesno", ""},
+		{"jump and cmpjump", `
+block
+  set i int (int: 0)
+loop:
+  op < (int: 3) i
+  cmpjump %rpop (bool: false) done
+  call print (array(any): [i])
+  op + (int: 1) i
+  mov obj_ref(i) %rpop
+  jump loop
+done:
+end`, "012", ""},
+		{"lambda, ret and call", `
+lambda func(n: int) int
+block
+  op * (int: 2) n
+  ret %rpop
+end
+set double func(n: int) int %rpop
+call double (array(any): [(int: 21)])
+call print (array(any): [%rpop])`, "42", ""},
+		{"collections", `
+set xs array(int) (array(int): [(int: 1), (int: 2)])
+cadd xs (int: 3)
+cset (int: 0) xs (int: 9)
+cremove xs (int: 1)
+ilen xs
+iget (int: 1) xs
+call print (array(any): [xs, %rpop, %rpop])`, "[9, 3]23", ""},
+		{"aget", `
+set p struct(x: int) (struct(x: int): [(int: 4)])
+aget p x
+aget? (nil) x
+call print (array(any): [%rpop, %rpop])`, "nil4", ""},
+		{"typealias and cast", `
+typealias Age int
+set a type_ref(Age) (int: 3)
+cast float64 a
+op / (int: 2) %rpop
+call print (array(any): [%rpop])`, "1.5", ""},
+		{"use", `
+use builtin math [abs] as m
+call m.abs (array(any): [(int: -2)])
+call print (array(any): [%rpop])`, "2", ""},
+		{"iget out of range", `
+iget (int: 5) (array(int): [(int: 1)])`, "", "index out of bounds"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytecode, err := asm.Assemble("main.gdasm", []byte(test.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var runErr error
+			output := CaptureStdout(func() {
+				vmProc := vm.NewGDVMProc()
+				runErr = vmProc.Init(bytecode)
+				if runErr == nil {
+					runErr = vmProc.Run()
+				}
+			})
+
+			if test.errMsg != "" {
+				if runErr == nil || !strings.Contains(runErr.Error(), test.errMsg) {
+					t.Errorf("Expected an error containing %q, got %v", test.errMsg, runErr)
+				}
+			} else if runErr != nil {
+				t.Errorf("Expected no error, got %q", runErr.Error())
+			}
+
+			if output != test.output {
+				t.Errorf("Expected %q but got %q", test.output, output)
+			}
+		})
+	}
+}
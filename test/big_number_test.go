@@ -0,0 +1,64 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestBigInts(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`print(9223372036854775807n + 1n)`, "9223372036854775808", ""},
+		{`set a: bigint = 2n
+		print(a * 9223372036854775807, typeof(a * 1))`, "18446744073709551614bigint", ""},
+		{`print(-7n / 2n, -7n % 2n)`, "-3-1", ""},
+		{`print(10n > 9n, 10n == 10n, 1n != 1)`, "truetruefalse", ""},
+		{`print(-(3n))`, "-3", ""},
+		{`print(0x_ffn, 1_000n)`, "2551000", ""},
+		{`print("123456789012345678901234567890" as bigint + 1n)`, "123456789012345678901234567891", ""},
+		{`print(9223372036854775807n as int, 255n as uint8, 18446744073709551615n as uint64)`, "922337203685477580725518446744073709551615", ""},
+		{`print(9223372036854775808n as int)`, "", "integer overflow of `int`"},
+		{`print(300n as uint8)`, "", "integer overflow of `uint8`"},
+		{`print((-1n) as uint64)`, "", "integer overflow of"},
+		{`print(5n as float + 0.5, 5 as bigint)`, "5.55", ""},
+		{`set a: bigint = 1`, "", "expected `bigint` but got `int`"},
+		{`print(1n / 0n)`, "", "division by zero"},
+	})
+}
+
+func TestDecimals(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`print(0.1d + 0.2d, typeof(0.1d))`, "0.3decimal", ""},
+		{`print(1.10d, 1.10d * 3)`, "1.103.30", ""},
+		{`print(1.10d - 0.1d)`, "1.00", ""},
+		{`print(1d / 3d)`, "0.3333333333333333", ""},
+		{`print(1.0d / 4d, 10d / 4d)`, "0.252.5", ""},
+		{`print(2d / 3d)`, "0.6666666666666667", ""},
+		{`print(5.5d % 2d, -(1.5d))`, "1.5-1.5", ""},
+		{`print(1.5d > 1.49d, 1.50d == 1.5d)`, "truetrue", ""},
+		{`print(1e3d, 12.5e-1d)`, "10001.25", ""},
+		{`print(1.99d as int, 2n + 0.5d, 0.5d + (0.25 as decimal), (0.5d as float) + 0.25)`, "12.50.750.75", ""},
+		{`print(1.10d + 0.2)`, "", "unsupported operation `+` between `decimal` and `float`"},
+		{`print(0.2 < 1.10d)`, "", "unsupported operation `<` between `float` and `decimal`"},
+		{`print(1e30d as int)`, "", "integer overflow of `int`"},
+		{`print("19.99" as decimal * 3, 0.1 as decimal)`, "59.970.1", ""},
+		{`set a: decimal = 0.5`, "", "expected `decimal` but got `float`"},
+		{`print(1d / 0d)`, "", "division by zero"},
+		{`print("abc" as decimal)`, "", "error trying to cast `abc` into a `decimal`"},
+	})
+}
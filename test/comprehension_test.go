@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestArrayComprehensions(t *testing.T) {
+	RunTests(t, []Test{
+		{`pub func main() {
+			set xs = [1, -2, 3]
+			set ys = [x * 2 for x in xs if x > 0]
+			print(typeof(ys), ys)
+		}`, "[int][2, 6]", ""},
+		{`pub func main() {
+			print([x for x in [1, 2, 3]])
+		}`, "[1, 2, 3]", ""},
+		{`pub func main() {
+			print([c as string + "!" for c in "hé"])
+		}`, `["h!", "é!"]`, ""},
+		{`pub func main() {
+			print([i for i, x in ["a", "b", "c"] if x != "b"])
+		}`, "[0, 2]", ""},
+		{`pub func main() {
+			set names = [(1, "a"), (2, "b")]
+			print([t[1] for t in names])
+		}`, `["a", "b"]`, ""},
+		{`func squares(xs: [int]) => [int] {
+			return [x * x for x: int in xs]
+		}
+		pub func main() {
+			print(squares([1, 2, 3]))
+		}`, "[1, 4, 9]", ""},
+		{`set squares = [x * x for x in [1, 2, 3]]
+		pub func main() {
+			print(squares)
+		}`, "[1, 4, 9]", ""},
+		// Nested comprehensions
+		{`pub func main() {
+			print([[y * x for y in [1, 2]] for x in [1, 2, 3]])
+		}`, "[[1, 2], [2, 4], [3, 6]]", ""},
+		// A fresh collection is built on each evaluation
+		{`pub func main() {
+			for set i in [1, 2] {
+				set ys = [x + i for x in [1, 2]]
+				print(ys)
+			}
+		}`, "[2, 3][3, 4]", ""},
+		{`pub func main() {
+			set ys = [x for x in [1, 2]]
+			ys << 3
+			print(ys)
+		}`, "[1, 2, 3]", ""},
+		// The objects declared by the comprehension are not visible outside of it
+		{`pub func main() {
+			print([x for x in [1, 2]], x)
+		}`, "", "object `x` was not found"},
+		{`pub func main() {
+			print([x for x in [1, 2] if x])
+		}`, "", "types `int` and `bool` are not equal"},
+		{`pub func main() {
+			set ys: [string] = [x * 2 for x in [1, 2]]
+		}`, "", "expected `[string]` but got `[int]`"},
+		{`pub func main() {
+			print([x for x: string in [1, 2]])
+		}`, "", "expected `string` but got `int`"},
+		{`pub func main() {
+			print([x for x in 1])
+		}`, "", "invalid iterable type"},
+	})
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"bytes"
+	"gdlang/src/compiler"
+	"gdlang/src/test_helper"
+	"gdlang/src/vm"
+	"strings"
+	"testing"
+)
+
+const debuggerSrc = `func div(a: int, b: int) => int {
+	set q = a / b
+	return q
+}
+
+func half(a: int) => int {
+	return div(a, 2)
+}
+
+pub func main() {
+	set x = half(8)
+	print(x)
+	print(x + 1)
+}`
+
+// Runs the source with the debugger reading the commands,
+// and returns the output of the debugger and of the program
+func runDebuggerTest(t *testing.T, src string, commands ...string) (string, string) {
+	var debugOutput bytes.Buffer
+	var output string
+
+	test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+		output = CaptureStdout(func() {
+			comp := compiler.NewGDCompiler()
+			defer comp.Dispose()
+
+			err := comp.Compile(tmpDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			buffer := &bytes.Buffer{}
+			err = comp.Root.BuildBytecode(buffer, comp.Ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			vmProc := vm.NewGDVMProc()
+			err = vmProc.Init(buffer.Bytes())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			vmProc.SrcMap = comp.Ctx.SrcMap
+			input := strings.NewReader(strings.Join(commands, "\n") + "\n")
+			vm.NewGDVMDebugger(vmProc, comp.Ctx.SrcMap, input, &debugOutput)
+
+			err = vmProc.Run()
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+
+		return nil
+	})
+
+	return debugOutput.String(), output
+}
+
+// Checks that the output contains the lines in order
+func expectInOrder(t *testing.T, output string, lines ...string) {
+	rest := output
+	for _, line := range lines {
+		i := strings.Index(rest, line)
+		if i == -1 {
+			t.Errorf("Expected the output to contain %q in order but got: %s", line, output)
+			return
+		}
+
+		rest = rest[i+len(line):]
+	}
+}
+
+func TestDebuggerBreakpoint(t *testing.T) {
+	debugOutput, output := runDebuggerTest(t, debuggerSrc, "b main.gd:3", "c", "locals", "bt", "c")
+
+	expectInOrder(t, debugOutput,
+		"Stopped at `global` (", "main.gd:1:6)",
+		"Breakpoint at main.gd:3",
+		"Stopped at `div` (", "main.gd:3:9)", "   3 | \treturn q",
+		"  q: int = 4", "  a: int = 8", "  b: int = 2",
+		"at `div` (", "main.gd:3:9)", "at `half` (", "main.gd:7:9)", "at `main` (", "main.gd:11:10)",
+	)
+
+	if output != "45" {
+		t.Errorf("Expected %q but got %q", "45", output)
+	}
+}
+
+func TestDebuggerFunctionLocals(t *testing.T) {
+	debugOutput, _ := runDebuggerTest(t, debuggerSrc, "b main.gd:12", "c", "locals", "c")
+
+	// The arguments of the functions are printed by their source names
+	expectInOrder(t, debugOutput,
+		"  x: int = 4",
+		"  div: (a: int, b: int) => int = (a: int, b: int) => int",
+		"  half: (a: int) => int = (a: int) => int",
+		"  main: () => nil = () => nil",
+	)
+}
+
+func TestDebuggerStepping(t *testing.T) {
+	debugOutput, _ := runDebuggerTest(t, debuggerSrc, "b main.gd:11", "c", "s", "s", "s", "o", "o", "n", "n", "c")
+
+	expectInOrder(t, debugOutput,
+		"Stopped at `main` (", "main.gd:11:10)",
+		// step into half and div
+		"Stopped at `half` (", "main.gd:7:9)",
+		"Stopped at `div` (", "main.gd:2:10)",
+		"Stopped at `div` (", "main.gd:3:9)",
+		// step out of div and half
+		"Stopped at `half` (", "main.gd:7:9)",
+		"Stopped at `main` (", "main.gd:11:6)",
+		// step over the prints
+		"Stopped at `main` (", "main.gd:12:2)",
+		"Stopped at `main` (", "main.gd:13:8)",
+	)
+
+	if strings.Contains(debugOutput, "`lambda`") {
+		t.Errorf("Expected the frames to be named, got: %s", debugOutput)
+	}
+}
+
+func TestDebuggerCommands(t *testing.T) {
+	debugOutput, output := runDebuggerTest(t, debuggerSrc, "b main.gd:99", "b main", "clear main.gd:3", "b main.gd:3", "clear main.gd:3", "foo", "q")
+
+	expectInOrder(t, debugOutput,
+		"No code at `main.gd:99`",
+		"Invalid location `main`, expected `file:line`",
+		"Cleared breakpoint at main.gd:3",
+		"Breakpoint at main.gd:3",
+		"Cleared breakpoint at main.gd:3",
+		"Unknown command `foo`",
+	)
+
+	// The program is stopped before the first print
+	if output != "" {
+		t.Errorf("Expected no output after quitting but got %q", output)
+	}
+}
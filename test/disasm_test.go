@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"bytes"
+	"gdlang/lib/runtime"
+	"gdlang/src/compiler"
+	"gdlang/src/cpu"
+	"gdlang/src/gd/ir"
+	"gdlang/src/test_helper"
+	"gdlang/src/vm"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDisassemble(t *testing.T) {
+	src := `typealias Point = {x: int, y: int}
+
+func sum(nums: [int]) => int {
+	set total = 0
+	for set n in nums {
+		total += n
+	}
+	return total
+}
+
+pub func main() {
+	set p: Point = {x: 1, y: 2}
+	set s = {| 1.5, 2.5 |}
+	set c: char = 'a'
+	print(sum([p.x, p.y]), p?.x, s, c, "hi", -2)
+}`
+
+	test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+		bytecode, srcMap := compileBytecode(t, tmpDir)
+
+		listing, err := vm.NewGDVMDisassembler(bytecode, srcMap).Disassemble()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, expected := range []string{
+			"00000  block\n",
+			"  typealias const Point struct(x: int, y: int)",
+			"  lambda func(",
+			"(int: 0)",
+			"(set(float): [(float32: 1.5), (float32: 2.5)])",
+			"(char: 'a')",
+			`(string: "hi")`,
+			"(type_ref(Point): [(int: 1), (int: 2)])",
+			"  aget ",
+			"  aget? ",
+			"  cmpjump ",
+			"  jump L",
+			"  ret ",
+			"  call print (array(any): [",
+			"op negative",
+			" func sum\n",
+			tmpDir + "/main.gd:12:",
+		} {
+			if !strings.Contains(listing, expected) {
+				t.Errorf("Expected the listing to contain %q, got\n%s", expected, listing)
+			}
+		}
+
+		// The jump targets are printed as labels before their instruction
+		labels := regexp.MustCompile(`L\d{5}`).FindAllString(listing, -1)
+		if len(labels) == 0 {
+			t.Errorf("Expected the listing to contain labels, got\n%s", listing)
+		}
+
+		for _, label := range labels {
+			if !strings.Contains(listing, "\n"+label+":\n") {
+				t.Errorf("Expected the label %s to be defined, got\n%s", label, listing)
+			}
+		}
+
+		// The offsets increase and the blocks are nested
+		lastOff, depth := "", 0
+		for _, line := range strings.Split(strings.TrimSuffix(listing, "\n"), "\n") {
+			if strings.HasSuffix(line, ":") {
+				continue
+			}
+
+			off, inst, _ := strings.Cut(line, "  ")
+			if off <= lastOff {
+				t.Errorf("Expected the offset %s to be greater than %s", off, lastOff)
+			}
+			lastOff = off
+
+			text := strings.TrimLeft(inst, " ")
+			if strings.HasPrefix(text, "end") {
+				depth--
+			}
+
+			if indent := (len(inst) - len(text)) / 2; indent != depth {
+				t.Errorf("Expected the line %q to be indented by %d blocks, got %d", line, depth, indent)
+			}
+
+			if strings.HasPrefix(text, "block") {
+				depth++
+			}
+		}
+
+		if depth != 0 {
+			t.Errorf("Expected all the blocks to be closed, got a depth of %d", depth)
+		}
+
+		// Without the source map there are no comments
+		listing, err = vm.NewGDVMDisassembler(bytecode, nil).Disassemble()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(listing, ";") {
+			t.Errorf("Expected no source positions without the source map, got\n%s", listing)
+		}
+
+		return nil
+	})
+}
+
+func TestDisassembleErrors(t *testing.T) {
+	tests := []struct {
+		bytecode []byte
+		errMsg   string
+	}{
+		{[]byte{byte(cpu.Jump), 0}, "EOF (byte offset: 0)"},
+		{[]byte{200}, "Invalid instruction code: `200`"},
+		{[]byte{byte(cpu.BBegin), 1, 0}, "EOF"},
+		{[]byte{byte(cpu.BBegin), 3, 0, byte(cpu.BEnd)}, "Invalid block end"},
+		{[]byte{byte(cpu.Jump), 1, 0}, "Invalid label. The offset `1`"},
+		{[]byte{byte(cpu.Operation), 255}, "Invalid operation code: `255`"},
+		{[]byte{byte(cpu.Ret), 255}, "Invalid type code: `255`"},
+		{[]byte{byte(cpu.AGet), 2}, "Invalid bool: `2`"},
+		{[]byte{byte(cpu.Lambda), byte(runtime.GDNilTypeCode)}, "Expected a block after the lambda type"},
+	}
+
+	for _, test := range tests {
+		_, err := vm.NewGDVMDisassembler(test.bytecode, nil).Disassemble()
+		if err == nil {
+			t.Errorf("Expected an error for %v, got none", test.bytecode)
+		} else if !strings.Contains(err.Error(), test.errMsg) {
+			t.Errorf("Expected the error for %v to contain %q, got %q", test.bytecode, test.errMsg, err.Error())
+		}
+	}
+}
+
+func compileBytecode(t *testing.T, pkgPath string) ([]byte, *ir.GDSourceMap) {
+	comp := compiler.NewGDCompiler()
+	defer comp.Dispose()
+
+	err := comp.Compile(pkgPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := &bytes.Buffer{}
+	err = comp.Root.BuildBytecode(buffer, comp.Ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return buffer.Bytes(), comp.Ctx.SrcMap
+}
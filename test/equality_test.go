@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestCompositeEquality(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`set a = [1, 2]
+		print(a == [1, 2], a != [1, 2], a == [2, 1], a == [1, 2, 3])`, "truefalsefalsefalse", ""},
+		{`set a = [[1, 2], [3]]
+		print(a == [[1, 2], [3]], a == [[1, 2], [4]])`, "truefalse", ""},
+		{`set a = (1, "a")
+		print(a == (1, "a"), a == (1, "b"))`, "truefalse", ""},
+		{`set a = {x: 1, y: "a"}
+		set b = {y: "a", x: 1}
+		print(a == b, a == {x: 1, y: "b"})`, "truefalse", ""},
+		{`set a: [any] = [1]
+		set b: any = (1,)
+		print(a == b)`, "false", ""},
+		{`set a: [(int | string)] = [1, "a"]
+		print(a == [1, "a"], a == ["1", "a"])`, "truefalse", ""},
+	})
+}
+
+func TestCompositeOrdering(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`print((1, "a") < (1, "b"), (2, "a") < (1, "b"), (1, "a") <= (1, "a"))`, "truefalsetrue", ""},
+		{`print([1, 2] < [1, 2, 3], [1, 3] > [1, 2, 3], [1, 2] >= [1, 2])`, "truetruetrue", ""},
+		{`set a = [true]
+		print(a < [false])`, "", "unsupported operation"},
+	})
+}
+
+func TestHash(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`print(hash([1, 2]) == hash([1.0, 2.0]), hash((1, "a")) == hash((1, "a")))`, "truetrue", ""},
+		{`print(hash({x: 1, y: 2}) == hash({y: 2, x: 1}), hash([1]) == hash((1,)))`, "truefalse", ""},
+		{`print(hash(print))`, "", "unhashable type"},
+	})
+}
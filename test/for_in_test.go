@@ -34,10 +34,15 @@ func TestForInCases(t *testing.T) {
 			}
 		}`, "1-2-3-", ""},
 		{`pub func main() {
-			for set a: char, b in "hola" {
+			for set a: int, b in "hola" {
 				print(b,a)
 			}
 		}`, "h0o1l2a3", ""},
+		{`pub func main() {
+			for set a: char, b in "hola" {
+				print(b,a)
+			}
+		}`, "", "expected `char` but got `int`"},
 		{`pub func main() {
 			for set t: int = 0, b = 1 in (1, 2, 3) {
 				print(t+b)
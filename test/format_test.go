@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/src/gd/format"
+	"testing"
+)
+
+// The formatted source prints the same output as the source
+func TestFormatKeepsBehaviour(t *testing.T) {
+	src := `func g(n: int) => func(x: int) => int {
+		if n > 1 {
+			return double
+		}
+		return inc
+	}
+	func inc(x: int) => int {
+		return x + 1
+	}
+	func double(x: int) => int {
+		return x * 2
+	}
+	func add(a: int, b: int) => int {
+		return a + b
+	}
+	pub func main() {
+		print(5 |> (g(2)), 6 |> add(1), 8, 7 |> (3 |> g), 0x1F, 1_000)
+	}`
+
+	res, err := format.Source("main.gd", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	RunTests(t, []Test{
+		{src, "107814311000", ""},
+		{string(res), "107814311000", ""},
+	})
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/lib/runtime"
+	"gdlang/src/compiler"
+	"gdlang/src/cpu"
+	"gdlang/src/gd/ir"
+	"gdlang/src/test_helper"
+	"gdlang/src/vm"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestGenerateArtifacts(t *testing.T) {
+	src := `pub func main() {
+	print("hello")
+}`
+
+	test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+		comp := compiler.NewGDCompiler()
+		defer comp.Dispose()
+
+		comp.Version = "1.2.0"
+		comp.EmbedSourceMap = true
+
+		err := comp.Compile(tmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = comp.GenerateArtifacts("hello", tmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bin, err := ir.ReadGDBin(path.Join(tmpDir, "hello.gdbin"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bin.CompilerVersion != "1.2.0" || bin.Metadata["package"] != "hello" {
+			t.Errorf("Expected the compiler 1.2.0 and the package hello, got %q and %v", bin.CompilerVersion, bin.Metadata)
+		}
+
+		if bin.SrcMap == nil || len(bin.SrcMap.Functions) == 0 {
+			t.Errorf("Expected the embedded source map to have the functions")
+		}
+
+		var runErr error
+		output := CaptureStdout(func() {
+			vmProc := vm.NewGDVMProc()
+			runErr = vmProc.Init(bin.Code)
+			if runErr == nil {
+				runErr = vmProc.Run()
+			}
+		})
+
+		if runErr != nil {
+			t.Fatal(runErr)
+		}
+
+		if output != "hello" {
+			t.Errorf("Expected %q but got %q", "hello", output)
+		}
+
+		// The binaries written before the container are rejected
+		rawPath := path.Join(tmpDir, "raw.gdbin")
+		err = os.WriteFile(rawPath, bin.Code, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ir.ReadGDBin(rawPath)
+		if err == nil {
+			t.Errorf("Expected the raw bytecode to be rejected")
+		}
+
+		return nil
+	})
+}
+
+func TestRunInvalidTypeCode(t *testing.T) {
+	tests := []struct {
+		bytecode []byte
+		typeCode string
+	}{
+		{[]byte{byte(cpu.Ret), 176}, "176"},
+		{[]byte{byte(cpu.Ret), byte(runtime.GDAnyTypeCode)}, "any"},
+	}
+
+	for _, test := range tests {
+		vmProc := vm.NewGDVMProc()
+		err := vmProc.Init(test.bytecode)
+		if err == nil {
+			err = vmProc.Run()
+		}
+
+		// The output is colored, so the type code is checked on its own
+		if err == nil || !strings.Contains(err.Error(), test.typeCode) || !strings.Contains(err.Error(), "reading object") {
+			t.Errorf("Expected an invalid type code %q reading object for %v, got %v", test.typeCode, test.bytecode, err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/src/test_helper"
+	"testing"
+)
+
+func TestGlobalInitialization(t *testing.T) {
+	RunTests(t, []Test{
+		{`set b = a + 1
+		set a = 1
+		pub func main() {
+			print(a, b)
+		}`, "12", ""},
+		{`set total = sum(values)
+		set values = [1, 2, 3]
+		func sum(xs: [int]) => int {
+			set total = 0
+			for set x in xs {
+				total += x
+			}
+			return total
+		}
+		pub func main() {
+			print(total)
+		}`, "6", ""},
+		{`set a = fib(10)
+		func fib(n: int) => int {
+			if n < 2 {
+				return n
+			}
+			return fib(n - 1) + fib(n - 2)
+		}
+		pub func main() {
+			print(a)
+		}`, "55", ""},
+		{`func h() => int {
+			return 2
+		}
+		func g() => int {
+			set x = h()
+			return x
+		}
+		pub func main() {
+			set x = 1
+			print(x + g())
+		}`, "3", ""},
+		{`set a = b + 1
+		set b = a + 1
+		pub func main() {
+			print(a)
+		}`, "", "initialization cycle: `a` refers to `b` refers to `a`"},
+		{`set a = next()
+		func next() => int {
+			return a + 1
+		}
+		pub func main() {
+			print(a)
+		}`, "", "initialization cycle: `a` refers to `a`"},
+	})
+}
+
+func TestInitFunc(t *testing.T) {
+	RunTests(t, []Test{
+		{`set names: [string] = []
+		func init() {
+			names << "a"
+		}
+		func init() {
+			names << "b"
+		}
+		pub func main() {
+			print(names)
+		}`, `["a", "b"]`, ""},
+		{`set registry = [0]
+		func init() {
+			registry << 1
+		}
+		pub func main() {
+			print(registry.len())
+		}`, "2", ""},
+		{`func init(a: int) {}
+		pub func main() {}`, "", "`init` function can't be public, have arguments or return a value"},
+		{`func init() => int {
+			return 1
+		}
+		pub func main() {}`, "", "`init` function can't be public, have arguments or return a value"},
+		{`pub func init() {}
+		pub func main() {}`, "", "`init` function can't be public, have arguments or return a value"},
+		{`func init() {}
+		pub func main() {
+			init()
+		}`, "", "object `init` was not found"},
+	})
+}
+
+func TestPackageInitFunc(t *testing.T) {
+	configPkg := test_helper.NDir("config", test_helper.NFile("config.gd", `
+		pub set settings: [string] = []
+
+		func init() {
+			settings << "config"
+		}
+	`))
+
+	RunPackageTests(t, []PackageTest{
+		{"imported packages are initialized first", test_helper.NRDir(configPkg, test_helper.NMFile(`use config { settings }
+			func init() {
+				settings << "main"
+			}
+			pub func main() {
+				print(settings)
+			}`)), `["config", "main"]`, ""},
+		{"initializers of several files", test_helper.NRDir(
+			test_helper.NFile("a.gd", `func init() {
+				print("a")
+			}`),
+			test_helper.NMFile(`func init() {
+				print("main")
+			}
+			pub func main() {
+				print("run")
+			}`),
+		), "amainrun", ""},
+	})
+}
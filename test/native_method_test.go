@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestStringMethods(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`print("héllo".len())`, "5", ""},
+		{`print("abc".upper(), "ABC".lower())`, "ABCabc", ""},
+		{`set s = "  hi  "
+		print(s.trim(), s.isEmpty(), "".isEmpty())`, "hifalsetrue", ""},
+		{`set s = "hello world"
+		print(s.contains("o w"), s.startsWith("he"), s.endsWith("he"))`, "truetruefalse", ""},
+		{`print("héllo".indexOf("l"), "abc".indexOf("z"))`, "2-1", ""},
+		{`set parts = "a,b,c".split(",")
+		print(parts, parts.len())`, "[\"a\", \"b\", \"c\"]3", ""},
+		{`print("a-b-c".replace("-", "+"))`, "a+b+c", ""},
+		{`set f = "abc".upper
+		print(f())`, "ABC", ""},
+		{`set n: int = "abc".upper()`, "", "expected `int` but got `string`"},
+		{`print("abc".contains(1))`, "", "invalid argument type"},
+		{`print("abc".reverse())`, "", "type `string` has no method `reverse`"},
+		{`"abc".len = 1`, "", "can't set the method `len` of a builtin type"},
+	})
+}
+
+func TestCollectionMethods(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`set xs = [1, 2, 3]
+		print(xs.len(), xs.isEmpty())`, "3false", ""},
+		{`set xs = [1, 2, 3]
+		xs << 4
+		print(xs.len(), xs.contains(4), xs.indexOf(3), xs.indexOf(9))`, "4true2-1", ""},
+		{`set xs = [[1], [2]]
+		print(xs.contains([2]))`, "true", ""},
+		{`print([1, 2, 3].join(", "))`, "1, 2, 3", ""},
+		{`set t = (1, "a")
+		print(t.len())`, "2", ""},
+		{`set s = {| 1, 2, 2 |}
+		print(s.len(), s.contains(2), s.contains(3))`, "2truefalse", ""},
+		{`set xs = [1, 2]
+		print(xs.contains("1"))`, "", "invalid argument type"},
+		{`set xs = [1, 2]
+		print(xs.push(3))`, "", "type `[int]` has no method `push`"},
+		{`set b = true
+		print(b.len())`, "", "invalid attributable type: `bool`"},
+	})
+}
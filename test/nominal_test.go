@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestNominalTypeCases(t *testing.T) {
+	RunTests(t, []Test{
+		// Explicit cast to the nominal type
+		{`type UserId = int
+		pub func main() {
+			set id: UserId = 5 as UserId
+			print(id)
+		}`, "5", ""},
+		// The underlying type is not assignable without a cast
+		{`type UserId = int
+		pub func main() {
+			set id: UserId = 5
+		}`, "", "expected `UserId` but got `int`"},
+		// Nominal types are not assignable to the underlying type
+		{`type UserId = int
+		pub func main() {
+			set id: UserId = 5 as UserId
+			set n: int = id
+		}`, "", "expected `int` but got `UserId`"},
+		// Two nominal types with the same underlying type are distinct
+		{`type UserId = int
+		type OrderId = int
+		pub func main() {
+			set id: UserId = 5 as UserId
+			set order: OrderId = id
+		}`, "", "expected `OrderId` but got `UserId`"},
+		// Structural aliases remain interchangeable
+		{`typealias UserId = int
+		typealias OrderId = int
+		pub func main() {
+			set id: UserId = 5
+			set order: OrderId = id
+			print(order)
+		}`, "5", ""},
+		// Operations between the same nominal type
+		{`type Cents = int
+		pub func main() {
+			set a: Cents = 100 as Cents
+			set b: Cents = 50 as Cents
+			set c: Cents = a + b
+			print(c, a > b, typeof(c))
+		}`, "150trueCents", ""},
+		// Operations mixing a nominal type with another type are not allowed
+		{`type Cents = int
+		pub func main() {
+			set a: Cents = 100 as Cents
+			print(a + 1)
+		}`, "", "unsupported operation"},
+		// Cast back to the underlying type
+		{`type Cents = int
+		pub func main() {
+			set a: Cents = 100 as Cents
+			set n: int = a as int
+			print(n + 1)
+		}`, "101", ""},
+		// Nominal types in function signatures
+		{`type UserId = int
+		func next(id: UserId) => UserId {
+			return ((id as int) + 1) as UserId
+		}
+		pub func main() {
+			print(next(1 as UserId))
+		}`, "2", ""},
+		{`type UserId = int
+		func next(id: UserId) => UserId {
+			return id
+		}
+		pub func main() {
+			print(next(1))
+		}`, "", "expected `UserId` but got `int`"},
+		// Nominal types with the same name declared in different scopes are distinct
+		{`type Id = int
+		pub func main() {
+			set outer = 1 as Id
+			if true {
+				type Id = int
+				set inner: Id = outer
+			}
+		}`, "", "expected `Id` but got `Id`"},
+		// Attributes of nominal structs
+		{`type Point = {x: int, y: int}
+		pub func main() {
+			set p = {x: 1, y: 2} as Point
+			print(p.x + p.y, typeof(p))
+		}`, "3Point", ""},
+		// Collections built at runtime are casted at runtime
+		{`type Point = {x: int, y: int}
+		pub func main() {
+			set n = 5
+			set p = {x: n - 2, y: n} as Point
+			print(p)
+		}`, "{x: 3, y: 5}", ""},
+		// Elements of nominal arrays
+		{`type Ids = [int]
+		pub func main() {
+			set ids = [4, 5, 6] as Ids
+			print(ids[1])
+			for set id in ids {
+				print(id)
+			}
+		}`, "5456", ""},
+		// Nominal type declared from another nominal type
+		{`type Id = int
+		type OtherId = Id
+		pub func main() {
+			set id: OtherId = (1 as Id) as OtherId
+			print(id, typeof(id))
+		}`, "1OtherId", ""},
+	})
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestOperatorFuncCases(t *testing.T) {
+	RunTests(t, []Test{
+		// Binary operator between structs
+		{`typealias Vec = {x: int, y: int}
+		func +(a: Vec, b: Vec) => Vec {
+			return {x: a.x + b.x, y: a.y + b.y}
+		}
+		pub func main() {
+			set a: Vec = {x: 1, y: 2}
+			set b: Vec = {x: 3, y: 4}
+			print(a + b)
+		}`, "{x: 4, y: 6}", ""},
+		// Several overloads of the same operator
+		{`typealias Vec = {x: int, y: int}
+		func *(a: Vec, b: Vec) => int {
+			return a.x * b.x + a.y * b.y
+		}
+		func *(a: Vec, k: int) => Vec {
+			return {x: a.x * k, y: a.y * k}
+		}
+		pub func main() {
+			set a: Vec = {x: 1, y: 2}
+			print(a * a, a * 3)
+		}`, "5{x: 3, y: 6}", ""},
+		// Comparison and unary operators
+		{`typealias Money = {cents: int}
+		func <(a: Money, b: Money) => bool {
+			return a.cents < b.cents
+		}
+		func ==(a: Money, b: Money) => bool {
+			return a.cents == b.cents
+		}
+		func -(a: Money) => Money {
+			return {cents: -a.cents}
+		}
+		pub func main() {
+			set a: Money = {cents: 100}
+			set b: Money = {cents: 250}
+			print(a < b, b < a, a == a, -a)
+		}`, "truefalsetrue{cents: -100}", ""},
+		// Operator function within a block
+		{`pub func main() {
+			typealias P = {v: int}
+			func -(a: P, b: P) => P {
+				return {v: a.v - b.v}
+			}
+			set a: P = {v: 5}
+			a -= {v: 2}
+			print(a)
+		}`, "{v: 3}", ""},
+		// Overloads are resolved by assignability, so the order of the attributes does not matter
+		{`typealias Vec = {x: int, y: int}
+		func +(a: Vec, b: Vec) => Vec {
+			return {x: a.x + b.x, y: a.y + b.y}
+		}
+		pub func main() {
+			set a: Vec = {x: 1, y: 2}
+			print(a + {y: 10, x: 20})
+		}`, "{x: 21, y: 12}", ""},
+		// Nominal structs
+		{`type P = {v: int}
+		func -(a: P, b: P) => P {
+			set r = {v: (a as {v: int}).v - (b as {v: int}).v}
+			return r as P
+		}
+		pub func main() {
+			set p = {v: 5} as P
+			set q = {v: 2} as P
+			print(p - q)
+		}`, "{v: 3}", ""},
+		// A nominal struct does not match the overload of its underlying struct
+		{`typealias V = {v: int}
+		func -(a: V, b: V) => V {
+			return {v: a.v - b.v}
+		}
+		type P = {v: int}
+		pub func main() {
+			set p = {v: 5} as P
+			print(p - p)
+		}`, "", "unsupported operation `-`"},
+		// Several overloads that accept the operands
+		{`func +(a: {x: int, y: int}, b: int) => int {
+			return 1
+		}
+		func +(a: {y: int, x: int}, b: int) => int {
+			return 2
+		}
+		pub func main() {
+			print({x: 1, y: 2} + 1)
+		}`, "", "ambiguous operation `+`, it is overloaded by both `(+)({x: int, y: int}, int)` and `(+)({y: int, x: int}, int)`"},
+		// No overload for the operation
+		{`typealias Vec = {x: int, y: int}
+		pub func main() {
+			set a: Vec = {x: 1, y: 2}
+			print(a + a)
+		}`, "", "unsupported operation `+` between `{x: int, y: int}` and `{x: int, y: int}`"},
+		// Builtin operations can't be overloaded
+		{`func +(a: int, b: int) => int {
+			return a
+		}
+		pub func main() {
+			print(1 + 1)
+		}`, "", "operator function `+` requires at least one argument of a struct type"},
+		// Comparison operators must return a bool
+		{`typealias Vec = {x: int}
+		func <(a: Vec, b: Vec) => int {
+			return 1
+		}
+		pub func main() {
+			set a: Vec = {x: 1}
+			print(a < a)
+		}`, "", "types `bool` and `int` are not equal"},
+		// Wrong number of arguments
+		{`typealias Vec = {x: int}
+		func *(a: Vec) => Vec {
+			return a
+		}
+		pub func main() {
+			set a: Vec = {x: 1}
+			print(a * a)
+		}`, "", "operator function `*` expects `2` arguments but got `1`"},
+	})
+}
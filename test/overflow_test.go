@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestIntOverflow(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`set a = 9223372036854775807
+		print(a + 1)`, "", "integer overflow of"},
+		{`set a = -9223372036854775807
+		print(a - 2)`, "", "integer overflow of"},
+		{`set a = 4611686018427387904
+		print(a * 2)`, "", "integer overflow of"},
+		{`set a = 9223372036854775807
+		print(a +% 1)`, "-9223372036854775808", ""},
+		{`set a = 9223372036854775807
+		print(a +| 1, -a -| 10)`, "9223372036854775807-9223372036854775808", ""},
+		{`set a = 3037000500
+		print(a *| a, a *| -a)`, "9223372036854775807-9223372036854775808", ""},
+		{`set a = 10
+		print(a -% 3 *% 2, a +| 1 * 2)`, "412", ""},
+		{`set a = 1
+		a += 9223372036854775807`, "", "integer overflow of"},
+		{`set a = 1.5
+		print(a +% 1)`, "", "unsupported operation `+%` between `float` and `int`"},
+	})
+}
+
+func TestDivisionByZero(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`set a = 0
+		print(1 / a)`, "", "division by zero"},
+		{`set a = 0
+		print(1 % a)`, "", "division by zero"},
+		{`set a = 0 as uint64
+		print(1 as uint64 / a)`, "", "division by zero"},
+		{`set a = 0.0
+		print(1.0 / a)`, "", "division by zero"},
+	})
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestPipeline(t *testing.T) {
+	RunTests(t, []Test{
+		{`func filter(xs: [int], f: func(x: int) => bool) => [int] {
+			return [x for x in xs if f(x)]
+		}
+		func mapInts(xs: [int], f: func(x: int) => int) => [int] {
+			return [f(x) for x in xs]
+		}
+		func square(x: int) => int {
+			return x * x
+		}
+		func isEven(x: int) => bool {
+			return x % 2 == 0
+		}
+		pub func main() {
+			set xs = [1, 2, 3, 4]
+			print(xs |> filter(isEven) |> mapInts(square))
+		}`, "[4, 16]", ""},
+		// The left value is passed as the first argument
+		{`func sub(a: int, b: int) => int {
+			return a - b
+		}
+		pub func main() {
+			print(10 |> sub(3))
+		}`, "7", ""},
+		// Binary operations are performed before the pipeline
+		{`func inc(x: int) => int {
+			return x + 1
+		}
+		pub func main() {
+			print(1 + 1 |> inc, 3 |> inc |> inc, typeof(2 |> inc))
+		}`, "35int", ""},
+		{`pub func main() {
+			print(2 |> func(x: int) => int {
+				return x * 10
+			})
+		}`, "20", ""},
+		{`use math {abs}
+		pub func main() {
+			print(-3 |> abs)
+		}`, "3", ""},
+		// A parenthesized call is evaluated before its result is called
+		{`func inc(x: int) => int {
+			return x + 1
+		}
+		func double(x: int) => int {
+			return x * 2
+		}
+		func pick(n: int) => func(x: int) => int {
+			if n > 0 {
+				return double
+			}
+			return inc
+		}
+		pub func main() {
+			print(5 |> (pick(1)), 5 |> (pick(0)))
+		}`, "106", ""},
+		{`pub func main() {
+			print(1 |> 2)
+		}`, "", "invalid callable type: `int`"},
+		{`func inc(x: int) => int {
+			return x + 1
+		}
+		pub func main() {
+			print("a" |> inc)
+		}`, "", "expected `int` but got `string`"},
+	})
+}
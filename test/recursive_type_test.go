@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestRecursiveTypeCases(t *testing.T) {
+	RunTests(t, []Test{
+		// Linked list
+		{`typealias Node = {value: int, next: Node}
+		pub func main() {
+			set n: Node = {value: 1, next: {value: 2, next: {value: 3, next: nil}}}
+			print(n.next.next.value)
+		}`, "3", ""},
+		{`typealias Node = {value: int, next: Node}
+		pub func main() {
+			set n: Node = {value: 1, next: {value: "2", next: nil}}
+		}`, "", "expected `Node` but got `{value: int, next: {value: string, next: nil}}`"},
+		// Tree passed to a function
+		{`typealias Tree = {left: Tree, right: Tree, value: int}
+		func leftValue(t: Tree) => int {
+			return t.left.value
+		}
+		pub func main() {
+			set t: Tree = {left: {left: nil, right: nil, value: 1}, right: nil, value: 2}
+			print(leftValue(t))
+		}`, "1", ""},
+		// Mutual recursion
+		{`typealias A = {b: B, v: int}
+		typealias B = {a: A, v: string}
+		pub func main() {
+			set a: A = {b: {a: {b: nil, v: 2}, v: "x"}, v: 1}
+			print(a.b.v, a.b.a.v)
+		}`, "x2", ""},
+		// Structurally equal recursive types
+		{`typealias A = {next: A}
+		typealias C = {next: C}
+		pub func main() {
+			set a: A = {next: {next: nil}}
+			set c: C = a
+			print(c)
+		}`, "{next: {next: nil}}", ""},
+		// JSON-like values
+		{`typealias Json = (int | string | [Json])
+		pub func main() {
+			set j: Json = [1, "a", [2, ["b"]]]
+			print(j)
+		}`, `[1, "a", [2, ["b"]]]`, ""},
+		// A pair of aliases that failed under an alternative of a union is not compatible under the next ones
+		{`typealias C = {c: string}
+		typealias D = {c: int}
+		typealias W1 = {v: [D]}
+		typealias W2 = {v: [D]}
+		typealias U = (W1 | W2)
+		func show(x: U) {
+			print(x)
+		}
+		pub func main() {
+			set c: C = {c: "a"}
+			set w: {v: [C]} = {v: [c]}
+			show(w)
+		}`, "", "invalid argument type for `x`: expected `U` but got `{v: [C]}`"},
+		{`typealias C = {c: string}
+		typealias D = {c: int}
+		typealias W1 = {v: [D]}
+		typealias W2 = {v: [C]}
+		typealias U = (W1 | W2)
+		func show(x: U) {
+			print(x)
+		}
+		pub func main() {
+			set c: C = {c: "a"}
+			set w: {v: [C]} = {v: [c]}
+			show(w)
+		}`, `{v: [{c: "a"}]}`, ""},
+		// Zero value of a recursive type
+		{`typealias Node = {value: int, next: Node}
+		func first(n: Node) => Node {
+			return n
+		}
+		pub func main() {
+			print(first({value: 1, next: nil}).value)
+		}`, "1", ""},
+		// Aliases that only refer to each other never reach a type
+		{`typealias A = B
+		typealias B = A
+		pub func main() {
+			set a: A = 1
+			set b: A = "x"
+		}`, "", "the type alias `A` refers to itself"},
+		{`typealias A = A
+		pub func main() {
+			set a: A = [1]
+		}`, "", "the type alias `A` refers to itself"},
+		{`type Id = Id
+		pub func main() {
+			set id: Id = 1 as Id
+		}`, "", "the type alias `Id` refers to itself"},
+		{`typealias A = B
+		typealias B = (A | int)
+		pub func main() {
+			set a: A = 1
+			print(a)
+		}`, "1", ""},
+	})
+}
@@ -40,6 +40,14 @@ type Test struct {
 	ErrMsg string
 }
 
+// A test with a tree of packages, the main package is the root directory
+type PackageTest struct {
+	Name   string
+	Pkgs   test_helper.FNode
+	Output string
+	ErrMsg string
+}
+
 func RunTests(t *testing.T, tests []Test) {
 	RunTestsWithTemplate(t, "", tests)
 }
@@ -53,37 +61,7 @@ func RunTestsWithTemplate(t *testing.T, tmpl string, tests []Test) {
 			src = test.Src
 		}
 		t.Run(src, func(t *testing.T) {
-			test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
-				output := CaptureStdout(func() {
-					_, _, err := RunFileTest(tmpDir)
-					// TODO: Dispose is failing
-					// defer proc.Dispose()
-					if err != nil {
-						if test.ErrMsg == "" {
-							t.Errorf("Expected no errors but got %s when running %s", err.Error(), src)
-						} else if !strings.Contains(err.Error(), test.ErrMsg) {
-							t.Errorf("Expected error message to contain %q but got: %q", test.ErrMsg, err.Error())
-						}
-
-						return
-					} else if test.ErrMsg != "" {
-						t.Errorf("Expected error message to contain %q but got no error", test.ErrMsg)
-					}
-				})
-
-				if strings.Contains(output, "warning") && test.ErrMsg != "" {
-					if !strings.Contains(output, test.ErrMsg) {
-						t.Errorf("Expected %q but got %q when running %s", test.Output, output, src)
-					}
-					return nil
-				}
-
-				if output != test.Output {
-					t.Errorf("Expected %q but got %q when running %s", test.Output, output, src)
-				}
-
-				return nil
-			})
+			runPackageTree(t, src, test_helper.NMFile(src), test.Output, test.ErrMsg)
 		})
 	}
 }
@@ -99,6 +77,48 @@ func RunTest(t *testing.T, test Test) {
 	RunTests(t, []Test{test})
 }
 
+func RunPackageTests(t *testing.T, tests []PackageTest) {
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			runPackageTree(t, test.Name, test.Pkgs, test.Output, test.ErrMsg)
+		})
+	}
+}
+
+func runPackageTree(t *testing.T, src string, pkgs test_helper.FNode, expectedOutput, errMsg string) {
+	test_helper.BuildPackageTree(pkgs, func(tmpDir string) error {
+		output := CaptureStdout(func() {
+			_, _, err := RunFileTest(tmpDir)
+			// TODO: Dispose is failing
+			// defer proc.Dispose()
+			if err != nil {
+				if errMsg == "" {
+					t.Errorf("Expected no errors but got %s when running %s", err.Error(), src)
+				} else if !strings.Contains(err.Error(), errMsg) {
+					t.Errorf("Expected error message to contain %q but got: %q", errMsg, err.Error())
+				}
+
+				return
+			} else if errMsg != "" {
+				t.Errorf("Expected error message to contain %q but got no error", errMsg)
+			}
+		})
+
+		if strings.Contains(output, "warning") && errMsg != "" {
+			if !strings.Contains(output, errMsg) {
+				t.Errorf("Expected %q but got %q when running %s", expectedOutput, output, src)
+			}
+			return nil
+		}
+
+		if output != expectedOutput {
+			t.Errorf("Expected %q but got %q when running %s", expectedOutput, output, src)
+		}
+
+		return nil
+	})
+}
+
 func RunFileTest(pkgPath string) (*vm.GDVMProc, *compiler.GDCompiler, error) {
 	comp := compiler.NewGDCompiler()
 	defer comp.Dispose()
@@ -121,6 +141,8 @@ func RunFileTest(pkgPath string) (*vm.GDVMProc, *compiler.GDCompiler, error) {
 		return nil, nil, err
 	}
 
+	vmProc.SrcMap = comp.Ctx.SrcMap
+
 	err = vmProc.Run()
 	if err != nil {
 		return nil, nil, err
@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestSetCollection(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`set s = {| 1, 2, 3, 2 |}
+		print(s, 2 in s, 5 in s)`, "{|1, 2, 3|}truefalse", ""},
+		{`set s: set<string> = {||}
+		s << "admin"
+		s << "admin"
+		print(s, "admin" in s)`, "{|\"admin\"|}true", ""},
+		{`set s = {| 1, 2, 3 |}
+		set removed = s >> 2
+		print(removed, s >> 2, s)`, "truefalse{|1, 3|}", ""},
+		{`set a = {| 1, 2, 3 |}
+		set b: set<int> = {| 3, 4 |}
+		print(a | b, a & b, a - b, b - a)`, "{|1, 2, 3, 4|}{|3|}{|1, 2|}{|4|}", ""},
+		{`set a = {| 1, 2 |}
+		print(a == {| 2, 1 |}, a != {| 1 |}, hash(a) == hash({| 2, 1 |}))`, "truetruetrue", ""},
+		{`set a = {| [1, 2], [1.0, 2.0] |}
+		print(a, [1, 2] in a)`, "{|[1, 2]|}true", ""},
+		{`set a = [3, 1, 3, 2] as set<int>
+		print(a, a as [int])`, "{|3, 1, 2|}[3, 1, 2]", ""},
+		{`set a: set<set<int>> = {| {| 1, 2 |}, {| 2, 1 |} |}
+		print(a)`, "{|{|1, 2|}|}", ""},
+		{`set s = {| 3, 1, 2 |}
+		for set i, x in s {
+			print(i, x)
+		}`, "031122", ""},
+		// The members are copies, a change of the added object doesn't change the set
+		{`set a = [1]
+		set s = {| a |}
+		a << 2
+		print([1, 2] in s, [1] in s)
+		s << [1, 2]
+		print(s)`, "falsetrue{|[1], [1, 2]|}", ""},
+		{`set s = {| [1] |}
+		for set x in s {
+			x << 2
+		}
+		print(s, [1] in s)`, "{|[1]|}true", ""},
+		{`set p = {a: [1]}
+		set s = {| p |}
+		p.a << 2
+		print(s, {a: [1]} in s)`, "{|{a: [1]}|}true", ""},
+		{`print(1 in [1, 2], "c" in ["a", "b"])`, "truefalse", ""},
+		{`set roles = {| "admin", "user" |}
+		set isAdmin = ("admin" in roles)
+		set isGuest = false
+		isGuest = "guest" in roles
+		print(isAdmin, isGuest)`, "truefalse", ""},
+	})
+}
+
+func TestSetCollectionErrors(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`set s = {| 1, 2 |}
+		s << "a"`, "", "expected `int` but got `string`"},
+		{`set s = {| 1, 2 |}
+		s >> "a"`, "", "expected `int` but got `string`"},
+		{`set s = {| 1, 2 |}
+		s[0] = 3`, "", "the elements of a set can't be assigned by index"},
+		{`set a = {| 1 |}
+		set b = {| "a" |}
+		print(a | b)`, "", "unsupported operation `|` between `set<int>` and `set<string>`"},
+		{`print(1 in 2)`, "", "unsupported operation `in` between `int` and `int`"},
+		{`print({| 1 |} < {| 2 |})`, "", "unsupported operation `<` between `set<int>` and `set<int>`"},
+		{`set f = func() {}
+		set s = {| f |}`, "", "unhashable type"},
+	})
+}
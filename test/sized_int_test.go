@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import "testing"
+
+func TestSizedInts(t *testing.T) {
+	RunTestsWithMainTemplate(t, []Test{
+		{`set a: uint8 = 255 as uint8
+		print(a +% 1, typeof(a +% 1))`, "0uint8", ""},
+		{`set a: int8 = 127 as int8
+		print(a +% 1)`, "-128", ""},
+		{`set a: int8 = 127 as int8
+		print(a + 1)`, "", "integer overflow of"},
+		{`set a: byte = 300 as byte
+		print(a, typeof(a))`, "44uint8", ""},
+		{`set a = -1 as uint64
+		print(a, a / 2 > a)`, "18446744073709551615false", ""},
+		// A negative `int` is compared with a `uint64` in the signed domain
+		{`set a = -1 as uint64
+		print(a == -1, (5 as uint64) > -1, (5 as uint32) > -1, -1 < (0 as uint64))`, "falsetruetruetrue", ""},
+		{`set a = 5 as uint64
+		print(a + -1, a - -1, a +% -6, a +| -6, a -| 6)`, "461844674407370955161500", ""},
+		{`set a = 5 as uint64
+		print(a + -6)`, "", "integer overflow of"},
+		{`set a = 5 as uint64
+		print(a * -1)`, "", "integer overflow of"},
+		{`set a: int32 = 2147483647 as int32
+		print(a *% 2)`, "-2", ""},
+		{`set a: uint16 = 0 as uint16
+		print(a -% 1, a -| 1)`, "655350", ""},
+		{`set a: int64 = 1 as int64
+		print(-a, typeof(-a))`, "-1int64", ""},
+		{`set a = "42" as int16
+		print(a + 1)`, "43", ""},
+		{`set a = 1.9 as uint32
+		print(a)`, "1", ""},
+		{`set a = 65 as uint8
+		print(a as char, a as float)`, "A65", ""},
+		{`set a = 1 as int8
+		set b = 1 as uint8
+		print(a + b)`, "", "unsupported operation `+` between `int8` and `uint8`"},
+		{`set a: int16 = 1`, "", "expected `int16` but got `int`"},
+		{`set a = "300" as uint8`, "", "error trying to cast `300` into a `uint8`"},
+	})
+}
+
+func TestSizedIntFuncArgs(t *testing.T) {
+	RunTests(t, []Test{
+		{`func inc(x: uint32) => uint32 {
+			return x + 1
+		}
+		pub func main() {
+			print(inc(4294967295 as uint32))
+		}`, "", "integer overflow of"},
+	})
+}
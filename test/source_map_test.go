@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/src/gd/ir"
+	"gdlang/src/test_helper"
+	"slices"
+	"testing"
+)
+
+func TestSourceMapOfFunctions(t *testing.T) {
+	src := `func add(a: int, b: int) => int {
+	set c = a + b
+	return c
+}
+
+pub func main() {
+	print(add(1, 2))
+}`
+
+	test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+		output := CaptureStdout(func() {
+			_, comp, err := RunFileTest(tmpDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			srcMap := comp.Ctx.SrcMap
+			if srcMap.Version != ir.GDSourceMapVersion {
+				t.Errorf("Expected the version %d, got %d", ir.GDSourceMapVersion, srcMap.Version)
+			}
+
+			i := slices.IndexFunc(srcMap.Functions, func(fn ir.GDSourceFunction) bool { return fn.Name == "add" })
+			if i == -1 {
+				t.Fatalf("Expected the function `add` in %+v", srcMap.Functions)
+			}
+
+			fn := srcMap.Functions[i]
+			if fn.Line != 1 || fn.Col != 6 || srcMap.Sources[fn.Source] != tmpDir+"/main.gd" {
+				t.Errorf("Expected the function `add` at main.gd:1:6, got %+v", fn)
+			}
+
+			// The local, the arguments and the globals are visible from the end of the function
+			names := make([]string, 0)
+			for _, scope := range srcMap.ScopesAt(fn.End - 1) {
+				for _, variable := range scope.Variables {
+					name, ok := srcMap.VariableName(fn.End-1, variable.Ident)
+					if !ok || name != variable.Name {
+						t.Errorf("Expected the ident %s to be named %q, got %q", variable.Ident.ToString(), variable.Name, name)
+					}
+
+					names = append(names, variable.Name)
+				}
+			}
+
+			if !slices.Equal(names, []string{"c", "a", "b", "add", "main"}) {
+				t.Errorf("Expected the variables c, a, b, add and main, got %v", names)
+			}
+		})
+
+		if output != "3" {
+			t.Errorf("Expected %q but got %q", "3", output)
+		}
+
+		return nil
+	})
+}
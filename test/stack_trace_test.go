@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/src/test_helper"
+	"strings"
+	"testing"
+)
+
+// Checks that the runtime error contains the frames of the stack trace in order
+func runStackTraceTest(t *testing.T, src string, frames []string) {
+	test_helper.BuildPackageTree(test_helper.NMFile(src), func(tmpDir string) error {
+		_, _, err := RunFileTest(tmpDir)
+		if err == nil {
+			t.Errorf("Expected a runtime error when running %s", src)
+			return nil
+		}
+
+		trace := err.Error()
+		for _, frame := range frames {
+			i := strings.Index(trace, frame)
+			if i == -1 {
+				t.Errorf("Expected the stack trace to contain %q in order but got: %q", frame, err.Error())
+				return nil
+			}
+
+			trace = trace[i+len(frame):]
+		}
+
+		return nil
+	})
+}
+
+func TestStackTrace(t *testing.T) {
+	runStackTraceTest(t, `func div(a: int, b: int) => int {
+	return a / b
+}
+
+func half(a: int) => int {
+	return div(a, 0)
+}
+
+pub func main() {
+	print(half(4))
+}`, []string{"Stack trace:", "div", "main.gd:2:9)", "half", "main.gd:6:9)", "main", "main.gd:10:8)"})
+}
+
+func TestStackTraceOfLambda(t *testing.T) {
+	runStackTraceTest(t, `pub func main() {
+	set f = func(a: int) => int {
+		return 1 / a
+	}
+	print(f(0))
+}`, []string{"lambda", "main.gd:3:10)", "main", "main.gd:5:8)"})
+}
+
+func TestStackTraceOfGlobal(t *testing.T) {
+	runStackTraceTest(t, `set zero = 0
+set x = 1 / zero
+
+pub func main() {
+	print(x)
+}`, []string{"global", "main.gd:2:9)"})
+}
+
+func TestStackTraceOfRecursion(t *testing.T) {
+	runStackTraceTest(t, `func down(n: int) => int {
+	if n == 0 {
+		return 1 / n
+	}
+	return down(n - 1)
+}
+
+pub func main() {
+	print(down(50))
+}`, []string{"main.gd:3:10)", "main.gd:5:9)", "... 20 more frames", "main.gd:5:9)", "main", "main.gd:9:8)"})
+}
@@ -42,3 +42,43 @@ func TestStrings(t *testing.T) {
 		}`, "", "invalid collectable type: `string`"},
 	})
 }
+
+func TestUnicodeStrings(t *testing.T) {
+	RunTests(t, []Test{
+		{`pub func main() {
+			for set c in "héllo" {
+				print(c, "-")
+			}
+		}`, "h-é-l-l-o-", ""},
+		{`pub func main() {
+			for set i, c: char in "日本語" {
+				print(i, c)
+			}
+		}`, "0日1本2語", ""},
+		{`pub func main() {
+			set x = "héllo"[1]
+			print(typeof(x), x)
+		}`, "charé", ""},
+		{`pub func main() {
+			set x: char = 'ñ'
+			print(x)
+		}`, "ñ", ""},
+		{`pub func main() {
+			set x = "ñ" as char
+			print(typeof(x), x)
+		}`, "charñ", ""},
+		{`use strings {len}
+		pub func main() {
+			print(len("héllo"), len("日本語"), len(""))
+		}`, "530", ""},
+		{`use strings {bytes}
+		pub func main() {
+			print(bytes("hé"))
+		}`, "[104, 195, 169]", ""},
+		{`use strings {graphemes, graphemeCount}
+		pub func main() {
+			set s = "e` + "́" + `🇪🇸!"
+			print(graphemeCount(s), graphemes(s)[1])
+		}`, "3🇪🇸", ""},
+	})
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/src/test_helper"
+	"testing"
+)
+
+func TestStructConstAttrCases(t *testing.T) {
+	RunTests(t, []Test{
+		{`typealias User = {const id: int, name: string}
+		pub func main() {
+			set u: User = {id: 1, name: "John"}
+			u.name = "Jane"
+			print(u)
+		}`, `{id: 1, name: "Jane"}`, ""},
+		{`typealias User = {const id: int, name: string}
+		pub func main() {
+			set u: User = {id: 1, name: "John"}
+			u.id = 2
+		}`, "", "can't set the constant attribute `id`"},
+		{`typealias User = {pub const id: int}
+		func rename(u: User) {
+			u.id += 1
+		}
+		pub func main() {
+			rename({id: 1})
+		}`, "", "can't set the constant attribute `id`"},
+		// Struct literals without a declared type are mutable
+		{`pub func main() {
+			set u = {id: 1}
+			u.id = 2
+			print(u.id)
+		}`, "2", ""},
+	})
+}
+
+func TestStructPubAttrCases(t *testing.T) {
+	userPkg := test_helper.NDir("users", test_helper.NFile("user.gd", `
+		pub typealias User = {pub name: string, pub const id: int, password: string}
+
+		pub func newUser(name: string) => User {
+			return {name: name, id: 1, password: "secret"}
+		}
+
+		pub func password(u: User) => string {
+			return u.password
+		}
+	`))
+
+	RunPackageTests(t, []PackageTest{
+		{"public attributes", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			pub func main() {
+				set u = newUser("John")
+				u.name = "Jane"
+				print(u.name, u.id)
+			}`)), "Jane1", ""},
+		{"private attribute from another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			pub func main() {
+				set u = newUser("John")
+				print(u.password)
+			}`)), "", "attribute `password` is not public outside its package"},
+		{"private attribute from its package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser, password }
+			pub func main() {
+				print(password(newUser("John")))
+			}`)), "secret", ""},
+		{"constant attribute from another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			pub func main() {
+				set u = newUser("John")
+				u.id = 2
+			}`)), "", "can't set the constant attribute `id`"},
+		{"cast to a public attribute from another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			pub func main() {
+				set u = newUser("John") as {pub name: string, pub const id: int, pub password: string}
+				print(u.password)
+			}`)), "", "can't change the `pub` or `const` modifiers of the attribute `password` outside its package"},
+		{"cast to a mutable attribute from another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			pub func main() {
+				set users = [newUser("John")] as [{pub name: string, pub id: int, password: string}]
+				users[0].id = 2
+			}`)), "", "can't change the `pub` or `const` modifiers of the attribute `id` outside its package"},
+		{"cast keeping the modifiers from another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser, User }
+			pub func main() {
+				set u = newUser("John") as User
+				print(u.name)
+			}`)), "John", ""},
+		// A private attribute declared again in another package would be readable there
+		{"cast to a private attribute of another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			pub func main() {
+				set u = newUser("John") as {pub name: string, pub const id: int, password: string}
+				print(u.password)
+			}`)), "", "attribute `password` is not public outside its package"},
+		{"argument with the modifiers of another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			func name(u: {pub name: string, pub const id: int}) => string {
+				return u.name
+			}
+			pub func main() {
+				print(name({name: "Jane", id: 2}))
+			}`)), "Jane", ""},
+		{"argument reading a private attribute of another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			func leak(x: {pub name: string, pub const id: int, password: string}) => string {
+				return x.password
+			}
+			pub func main() {
+				print(leak(newUser("John")))
+			}`)), "", "attribute `password` is not public outside its package"},
+		{"argument changing a constant attribute of another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser }
+			func bump(x: {pub name: string, id: int, password: string}) {
+				x.id = 99
+			}
+			pub func main() {
+				set u = newUser("John")
+				bump(u)
+				print(u.id)
+			}`)), "", "can't change the `pub` or `const` modifiers of the attribute `id` outside its package"},
+		{"return type exposing a private attribute of another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { newUser, User }
+			func expose(u: User) => {pub name: string, pub const id: int, password: string} {
+				return u
+			}
+			pub func main() {
+				print(expose(newUser("John")).password)
+			}`)), "", "attribute `password` is not public outside its package"},
+		{"struct literal setting a private attribute of another package", test_helper.NRDir(userPkg, test_helper.NMFile(`use users { User }
+			pub func main() {
+				set u: User = {name: "John", id: 2, password: "mine"}
+			}`)), "", "attribute `password` is not public outside its package"},
+	})
+}
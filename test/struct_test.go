@@ -148,5 +148,12 @@ func TestStructCases(t *testing.T) {
 			}
 			print(s.handler())
 		}`, "2", ""},
+		// Alias of an alias
+		{`typealias A = {x: int}
+		typealias B = A
+		pub func main() {
+			set b: B = {x: 1}
+			print(b)
+		}`, "{x: 1}", ""},
 	})
 }
@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2023 The GDLang Team.
+ *
+ * This file is part of GDLang.
+ *
+ * GDLang is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * GDLang is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with GDLang.  If not, see <http://www.gnu.org/licenses/>.
+ */
+
+package test
+
+import (
+	"gdlang/src/test_helper"
+	"testing"
+)
+
+func TestUseBuiltinPackage(t *testing.T) {
+	RunTests(t, []Test{
+		{`use math {*}
+		pub func main() {
+			print(abs(-2), floor(1.5))
+		}`, "21", ""},
+		{`use strings as s
+		pub func main() {
+			print(s.len("héllo"), s.graphemeCount("héllo"))
+		}`, "55", ""},
+		{`use math as m
+		func abs(x: int) => int {
+			return 0
+		}
+		pub func main() {
+			print(m.abs(-2), abs(-2))
+		}`, "20", ""},
+		{`use math as m
+		use strings {len}
+		pub func main() {
+			print(-3 |> m.abs, len("abc"))
+		}`, "33", ""},
+		{`use math as m
+		pub func main() {
+			print(m.nope(1))
+		}`, "", "public object `nope` was not found in package `math`"},
+		{`use math as m
+		pub func main() {
+			print(m)
+		}`, "", "package alias `m` can only be used to access the public objects of the package"},
+		{`use math as m
+		pub func main() {
+			print(abs(-1))
+		}`, "", "object `abs` was not found"},
+		{`use math {*}
+		func abs(x: int) => int {
+			return x
+		}
+		pub func main() {}`, "", "duplicated object `abs`"},
+		{`use math as m
+		set m = 1
+		pub func main() {}`, "", "duplicated object `m`"},
+		{`use math {*`, "", "when expecting a `}`"},
+	})
+}
+
+func TestUseSourcePackage(t *testing.T) {
+	userPkg := test_helper.NDir("users", test_helper.NFile("user.gd", `
+		pub typealias User = {pub name: string}
+
+		pub func newUser(name: string) => User {
+			return {name: name}
+		}
+
+		pub func greet(u: User) => string {
+			return "hi " + u.name
+		}
+
+		pub set host = "localhost"
+
+		func secret() => string {
+			return "secret"
+		}
+	`))
+
+	RunPackageTests(t, []PackageTest{
+		{"wildcard import", test_helper.NRDir(userPkg, test_helper.NMFile(`use users {*}
+			pub func main() {
+				print(greet(newUser("John")))
+			}`)), "hi John", ""},
+		{"aliased import", test_helper.NRDir(userPkg, test_helper.NMFile(`use users as u
+			pub func main() {
+				set john = u.newUser("John")
+				print(u.greet(john), john.name)
+			}`)), "hi JohnJohn", ""},
+		{"private object of an aliased import", test_helper.NRDir(userPkg, test_helper.NMFile(`use users as u
+			pub func main() {
+				print(u.secret())
+			}`)), "", "public object `secret` was not found in package `users`"},
+		{"wildcard collision", test_helper.NRDir(userPkg, test_helper.NMFile(`use users {*}
+			func greet() {}
+			pub func main() {}`)), "", "main.gd 2:9-13 fatal error: duplicated object `greet`"},
+		{"wildcard collision with a global", test_helper.NRDir(userPkg, test_helper.NMFile(`use users {*}
+			set host = "x"
+			pub func main() {}`)), "", "main.gd 2:8-11 fatal error: duplicated object `host`"},
+		{"imports in several files", test_helper.NRDir(
+			test_helper.NFile("util.gd", `use strings {len}
+				pub func width(a: string, b: string) => int {
+					return len(a) + len(b)
+				}`),
+			test_helper.NMFile(`use strings {len, bytes}
+				pub func main() {
+					print(width("ab", "c"), len("é"), bytes("é"))
+				}`),
+		), "31[195, 169]", ""},
+	})
+}